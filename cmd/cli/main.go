@@ -1,10 +1,15 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"flag"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
+	"os"
+	"sort"
 	"time"
 
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
@@ -20,18 +25,58 @@ import (
 	"github.com/MarioCarrion/todo-api/pkg/openapi3"
 )
 
+// defaultServer is the address used by the rest-server by default.
+const defaultServer = "http://0.0.0.0:9234"
+
 func main() {
+	server := flag.String("server", defaultServer, "rest-server address")
+
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), `Usage: %s [flags] [command]
+
+Commands:
+  seed    creates 100 searchable tasks, the default command
+  export  writes all the tasks to a file, use "export -h" for details
+  import  upserts the tasks included in a file, use "import -h" for details
+
+Flags:
+`, os.Args[0])
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
 	initTracer()
 
 	//-
 
 	clientOA3 := http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)}
 
-	client, err := openapi3.NewClientWithResponses("http://0.0.0.0:9234", openapi3.WithHTTPClient(&clientOA3))
+	client, err := openapi3.NewClientWithResponses(*server, openapi3.WithHTTPClient(&clientOA3))
 	if err != nil {
 		log.Fatalf("Couldn't instantiate client: %s", err)
 	}
 
+	ctx := context.Background()
+
+	switch cmd := flag.Arg(0); cmd {
+	case "", "seed":
+		err = seed(ctx, client)
+	case "export":
+		err = exportTasks(ctx, client, flag.Args()[1:])
+	case "import":
+		err = importTasks(ctx, client, flag.Args()[1:])
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		log.Fatalf("Couldn't run command: %s", err)
+	}
+}
+
+func seed(ctx context.Context, client *openapi3.ClientWithResponses) error {
 	newPtrStr := func(s string) *string {
 		return &s
 	}
@@ -45,7 +90,7 @@ func main() {
 	for count < 101 {
 		priority := openapi3.Low
 
-		_, err := client.CreateTaskWithResponse(context.Background(),
+		_, err := client.CreateTaskWithResponse(ctx,
 			openapi3.CreateTaskJSONRequestBody{
 				Dates: &openapi3.Dates{
 					Start: newPtrTime(time.Now()),
@@ -55,11 +100,152 @@ func main() {
 				Priority:    &priority,
 			})
 		if err != nil {
-			log.Fatalf("Couldn't create task %s", err)
+			return fmt.Errorf("couldn't create task: %w", err)
 		}
 
 		count++
 	}
+
+	return nil
+}
+
+// exportTasks streams the exported tasks to the output file, or stdout.
+func exportTasks(ctx context.Context, client *openapi3.ClientWithResponses, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	format := fs.String("format", "csv", `file format, "csv" or "ndjson"`)
+	output := fs.String("o", "", "file to write, defaults to stdout")
+
+	_ = fs.Parse(args) // Exits on error
+
+	res, err := client.ExportTasks(ctx, &openapi3.ExportTasksParams{
+		Format: (*openapi3.ExportTasksParamsFormat)(format),
+	})
+	if err != nil {
+		return fmt.Errorf("couldn't export tasks: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(res.Body)
+
+		return fmt.Errorf("couldn't export tasks: %s %s", res.Status, bytes.TrimSpace(body))
+	}
+
+	if *output == "" {
+		_, err = io.Copy(os.Stdout, res.Body)
+
+		return err //nolint: wrapcheck
+	}
+
+	file, err := os.Create(*output)
+	if err != nil {
+		return err //nolint: wrapcheck
+	}
+
+	// The connection is closed by the server when exporting fails, so the file is incomplete.
+	if _, err := io.Copy(file, res.Body); err != nil {
+		_ = file.Close()
+
+		return fmt.Errorf("couldn't write tasks: %w", err)
+	}
+
+	return file.Close() //nolint: wrapcheck
+}
+
+// importTasks upserts the tasks included in the input file, or stdin, and prints the results; it fails when any of
+// the rows was not imported.
+func importTasks(ctx context.Context, client *openapi3.ClientWithResponses, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	format := fs.String("format", "csv", `file format, "csv" or "ndjson"`)
+	dryRun := fs.Bool("dry-run", false, "validates the tasks and reports the changes without making them")
+
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s import [flags] [file]\n\nFlags:\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+
+	_ = fs.Parse(args) // Exits on error
+
+	var input io.Reader = os.Stdin
+
+	if path := fs.Arg(0); path != "" && path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return err //nolint: wrapcheck
+		}
+		defer file.Close()
+
+		input = file
+	}
+
+	contentType := "text/csv"
+	if *format == string(openapi3.UpsertTasksParamsFormatNdjson) {
+		contentType = "application/x-ndjson"
+	}
+
+	res, err := client.UpsertTasksWithBodyWithResponse(ctx,
+		&openapi3.UpsertTasksParams{
+			Format: (*openapi3.UpsertTasksParamsFormat)(format),
+			DryRun: dryRun,
+		},
+		contentType,
+		input)
+	if err != nil {
+		return fmt.Errorf("couldn't import tasks: %w", err)
+	}
+
+	if res.JSON200 == nil {
+		return fmt.Errorf("couldn't import tasks: %s %s", res.Status(), bytes.TrimSpace(res.Body))
+	}
+
+	results := res.JSON200
+
+	if *dryRun {
+		fmt.Println("Dry run, nothing was changed.")
+	}
+
+	fmt.Printf("Created: %d, updated: %d, unchanged: %d\n",
+		value(results.Created), value(results.Updated), value(results.Unchanged))
+
+	if results.Errors == nil || len(*results.Errors) == 0 {
+		return nil
+	}
+
+	for _, ierr := range *results.Errors {
+		fmt.Printf("Row %d", value(ierr.Row))
+
+		if id := value(ierr.ExternalId); id != "" {
+			fmt.Printf(" (%s)", id)
+		}
+
+		fmt.Printf(": %s\n", value(ierr.Error))
+
+		validations := value(ierr.Validations)
+
+		fields := make([]string, 0, len(validations))
+		for field := range validations {
+			fields = append(fields, field)
+		}
+
+		sort.Strings(fields)
+
+		for _, field := range fields {
+			fmt.Printf("  %s: %s\n", field, validations[field])
+		}
+	}
+
+	return fmt.Errorf("%d rows were not imported", len(*results.Errors))
+}
+
+// value returns the value referenced by p, or the zero value when p is nil.
+func value[T any](p *T) T {
+	var res T
+
+	if p != nil {
+		res = *p
+	}
+
+	return res
 }
 
 func initTracer() {
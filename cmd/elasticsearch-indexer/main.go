@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api/cmd/internal"
+	internaldomain "github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/elasticsearch"
+	"github.com/MarioCarrion/todo-api/internal/envvar"
+	"github.com/MarioCarrion/todo-api/internal/indexer"
+	"github.com/MarioCarrion/todo-api/internal/memcached"
+)
+
+func main() {
+	var (
+		env     string
+		address string
+		opts    = internal.SourceOptions{Name: "elasticsearch-indexer"}
+	)
+
+	hostname, _ := os.Hostname()
+
+	flag.StringVar(&env, "env", "", "Environment Variables filename")
+	flag.StringVar(&address, "address", ":9235", "HTTP Server Address, serves /health and /metrics")
+	flag.IntVar(&opts.BatchSize, "batch-size", 500, "Number of tasks indexed per batch")
+	flag.DurationVar(&opts.FlushInterval, "flush-interval", time.Second, "Maximum time to wait before indexing a batch")
+	flag.IntVar(&opts.MaxAttempts, "max-attempts", 5, "RabbitMQ, Redis and NATS: Number of attempts before dead-lettering a message")
+	flag.DurationVar(&opts.RetryBackoff, "retry-backoff", time.Second,
+		"RabbitMQ and NATS: Delay before retrying a message, doubled per attempt in RabbitMQ")
+	flag.StringVar(&opts.Group, "group", "elasticsearch-indexer", "Redis: Consumer group, shared by all the replicas")
+	flag.StringVar(&opts.Consumer, "consumer", hostname, "Redis: Consumer name, must be unique for each replica")
+	flag.DurationVar(&opts.MinIdle, "min-idle", time.Minute, "Redis: Time before claiming the events pending in other consumers")
+	flag.DurationVar(&opts.AckWait, "ack-wait", 30*time.Second, "NATS: Time to wait for an acknowledgement before redelivering")
+	flag.Parse()
+
+	errC, err := run(env, address, opts)
+	if err != nil {
+		log.Fatalf("Couldn't run: %s", err)
+	}
+
+	if err := <-errC; err != nil {
+		log.Fatalf("Error while running: %s", err)
+	}
+}
+
+func run(env, address string, opts internal.SourceOptions) (<-chan error, error) {
+	logger, err := zap.NewProduction()
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "zap.NewProduction")
+	}
+
+	if err := envvar.Load(env); err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "envvar.Load")
+	}
+
+	vault, err := internal.NewVaultProvider()
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewVaultProvider")
+	}
+
+	conf := envvar.New(vault)
+
+	//-
+
+	name, err := conf.Get("INDEXER_SOURCE")
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "conf.Get INDEXER_SOURCE")
+	}
+
+	if name == "" {
+		name = internal.SourceRedis
+	}
+
+	source, closeSource, err := internal.NewSource(conf, logger, name, opts)
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewSource")
+	}
+
+	esClient, err := internal.NewElasticSearch(conf)
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewElasticSearch")
+	}
+
+	task := elasticsearch.NewTask(esClient)
+
+	if err := task.Init(context.Background()); err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "task.Init")
+	}
+
+	cache, err := internal.NewCache(conf, logger)
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewCache")
+	}
+
+	//-
+
+	promExporter, err := internal.NewOTExporter(conf, "elasticsearch-indexer-"+name)
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewOTExporter")
+	}
+
+	//-
+
+	generation := memcached.NewSearchGeneration(cache.Remote, logger)
+
+	consumer := indexer.NewConsumer(logger, name, source,
+		elasticsearch.NewIndexer(logger, memcached.NewBulkSearchableTask(generation, task), opts.BatchSize, opts.FlushInterval))
+
+	mux := http.NewServeMux()
+	mux.Handle("/health", indexer.HealthHandler(consumer))
+	mux.Handle("/metrics", promExporter)
+
+	srv := &http.Server{
+		Addr:              address,
+		Handler:           mux,
+		ReadHeaderTimeout: time.Second,
+	}
+
+	errC := make(chan error, 1)
+
+	ctx, stop := signal.NotifyContext(context.Background(),
+		os.Interrupt,
+		syscall.SIGTERM,
+		syscall.SIGQUIT)
+
+	go func() {
+		<-ctx.Done()
+
+		logger.Info("Shutdown signal received")
+
+		ctxTimeout, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+
+		defer func() {
+			_ = logger.Sync()
+
+			closeSource()
+			stop()
+			cancel()
+			close(errC)
+		}()
+
+		if err := consumer.Shutdown(ctxTimeout); err != nil { //nolint: contextcheck
+			errC <- err
+		}
+
+		if err := srv.Shutdown(ctxTimeout); err != nil { //nolint: contextcheck
+			errC <- err
+		}
+
+		logger.Info("Shutdown completed")
+	}()
+
+	go func() {
+		logger.Info("Listening and serving", zap.String("source", name), zap.String("address", address))
+
+		if err := consumer.ListenAndServe(); err != nil {
+			errC <- err
+		}
+	}()
+
+	go func() {
+		// "ListenAndServe always returns a non-nil error. After Shutdown or Close, the returned error is
+		// ErrServerClosed."
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errC <- err
+		}
+	}()
+
+	return errC, nil
+}
@@ -0,0 +1,93 @@
+package internal
+
+import (
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/envvar"
+	"github.com/MarioCarrion/todo-api/internal/lru"
+	"github.com/MarioCarrion/todo-api/internal/memcached"
+	"github.com/MarioCarrion/todo-api/internal/redis"
+)
+
+const localCacheTTL = 5 * time.Second
+
+// Cache defines the caches used by the decorators in "internal/memcached".
+type Cache struct {
+	// Remote is the cache shared by all the instances.
+	Remote memcached.Cache
+	// Tiered is the Remote cache with an optional in-process LRU cache in front of it.
+	Tiered memcached.Cache
+	// Ring is the ring of Memcached nodes used by Remote, nil when Memcached is not used.
+	Ring *memcached.Ring
+}
+
+// NewCache instantiates the caches using configuration defined in environment variables:
+//
+//   - CACHE_BACKEND: "memcached" (default), "redis" or "lru"; "lru" is only meant for running one instance.
+//   - CACHE_LOCAL_SIZE: number of values kept in an in-process LRU cache in front of the remote one, 0 disables it.
+//   - MEMCACHED_HOST: comma separated list of Memcached servers.
+func NewCache(conf *envvar.Configuration, logger *zap.Logger) (Cache, error) {
+	backend, err := conf.Get("CACHE_BACKEND")
+	if err != nil {
+		return Cache{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "conf.Get CACHE_BACKEND")
+	}
+
+	var (
+		remote memcached.Cache
+		ring   *memcached.Ring
+	)
+
+	switch backend {
+	case "", "memcached":
+		ring, err = NewMemcached(conf, logger)
+		if err != nil {
+			return Cache{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "NewMemcached")
+		}
+
+		remote = memcached.NewRingClient(ring)
+	case "redis":
+		client, err := NewRedis(conf)
+		if err != nil {
+			return Cache{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "NewRedis")
+		}
+
+		remote = redis.NewCache(client)
+	case "lru":
+		cache := lru.NewCache(10_000)
+
+		return Cache{Remote: cache, Tiered: cache}, nil
+	default:
+		return Cache{}, internal.NewErrorf(internal.ErrorCodeInvalidArgument, "unknown cache backend %q", backend)
+	}
+
+	size, err := conf.Get("CACHE_LOCAL_SIZE")
+	if err != nil {
+		return Cache{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "conf.Get CACHE_LOCAL_SIZE")
+	}
+
+	var sizei int
+
+	if size != "" {
+		if sizei, err = strconv.Atoi(size); err != nil {
+			return Cache{}, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "strconv.Atoi CACHE_LOCAL_SIZE")
+		}
+	}
+
+	if sizei < 0 {
+		return Cache{}, internal.NewErrorf(internal.ErrorCodeInvalidArgument, "invalid CACHE_LOCAL_SIZE %d", sizei)
+	}
+
+	if sizei == 0 {
+		return Cache{Remote: remote, Tiered: remote, Ring: ring}, nil
+	}
+
+	return Cache{
+		Remote: remote,
+		Tiered: memcached.NewTwoTierCache(lru.NewCache(sizei), remote, localCacheTTL),
+		Ring:   ring,
+	}, nil
+}
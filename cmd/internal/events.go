@@ -0,0 +1,27 @@
+package internal
+
+import (
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/envvar"
+	"github.com/MarioCarrion/todo-api/internal/events"
+)
+
+// NewEventsCodec returns the codec used for publishing events defined in EVENTS_CODEC: "json" (default) or
+// "protobuf". Consumers decode events published using any of them.
+func NewEventsCodec(conf *envvar.Configuration) (events.Codec, error) {
+	name, err := conf.Get("EVENTS_CODEC")
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "conf.Get EVENTS_CODEC")
+	}
+
+	if name == "" {
+		return events.JSON, nil
+	}
+
+	codec, err := events.NewCodec(name)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "events.NewCodec")
+	}
+
+	return codec, nil
+}
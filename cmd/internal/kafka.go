@@ -1,7 +1,10 @@
 package internal
 
 import (
+	"context"
+
 	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"go.uber.org/zap"
 
 	"github.com/MarioCarrion/todo-api/internal"
 	"github.com/MarioCarrion/todo-api/internal/envvar"
@@ -12,30 +15,63 @@ type KafkaProducer struct {
 	Topic    string
 }
 
-// NewKafkaProducer instantiates the Kafka producer using configuration defined in environment variables.
-func NewKafkaProducer(conf *envvar.Configuration) (*KafkaProducer, error) {
+// NewKafkaProducer instantiates the idempotent Kafka producer using configuration defined in environment variables.
+func NewKafkaProducer(conf *envvar.Configuration, logger *zap.Logger) (*KafkaProducer, error) {
 	host, topic, err := newKafkaConfig(conf)
 	if err != nil {
-		return nil, internal.NewErrorf(internal.ErrorCodeUnknown, "newKafkaConfig")
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "newKafkaConfig")
 	}
 
+	// The idempotent producer requires "acks=all" and retries the messages without duplicating or reordering them.
 	config := kafka.ConfigMap{
-		"bootstrap.servers": host,
+		"bootstrap.servers":  host,
+		"enable.idempotence": true,
+		"acks":               "all",
 	}
 
 	client, err := kafka.NewProducer(&config)
 	if err != nil {
-		return nil, internal.NewErrorf(internal.ErrorCodeUnknown, "kafka.NewProducer")
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "kafka.NewProducer")
 	}
 
+	// Delivery reports are sent to the channels used when producing the messages, the rest of the events, like
+	// errors, are sent to the Events channel; it's closed when the producer is closed.
+	go func() {
+		for evt := range client.Events() {
+			if err, ok := evt.(kafka.Error); ok {
+				logger.Error("Kafka producer error", zap.Error(err), zap.Bool("fatal", err.IsFatal()))
+			}
+		}
+	}()
+
 	return &KafkaProducer{
 		Producer: client,
 		Topic:    topic,
 	}, nil
 }
 
+// Close waits for the messages still being produced to be delivered, until ctx is done, and closes the producer.
+func (k *KafkaProducer) Close(ctx context.Context) error {
+	defer k.Producer.Close()
+
+	for {
+		remaining := k.Producer.Flush(100)
+
+		if remaining == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return internal.WrapErrorf(ctx.Err(), internal.ErrorCodeUnknown, "%d messages not delivered", remaining)
+		default:
+		}
+	}
+}
+
 type KafkaConsumer struct {
 	Consumer *kafka.Consumer
+	Topic    string
 }
 
 // NewKafkaConsumer instantiates the Kafka consumer using configuration defined in environment variables.
@@ -63,6 +99,32 @@ func NewKafkaConsumer(conf *envvar.Configuration, groupID string) (*KafkaConsume
 
 	return &KafkaConsumer{
 		Consumer: client,
+		Topic:    topic,
+	}, nil
+}
+
+// NewKafkaReader instantiates a Kafka consumer, not subscribed to the topic, meant to be used for reading it
+// using "kafka.Reader"; offsets are never committed.
+func NewKafkaReader(conf *envvar.Configuration, groupID string) (*KafkaConsumer, error) {
+	host, topic, err := newKafkaConfig(conf)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "kafka.newKafkaConfig")
+	}
+
+	config := kafka.ConfigMap{
+		"bootstrap.servers":  host,
+		"group.id":           groupID,
+		"enable.auto.commit": false,
+	}
+
+	client, err := kafka.NewConsumer(&config)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "kafka.NewConsumer")
+	}
+
+	return &KafkaConsumer{
+		Consumer: client,
+		Topic:    topic,
 	}, nil
 }
 
@@ -1,29 +1,50 @@
 package internal
 
 import (
+	"strings"
 	"time"
 
-	"github.com/bradfitz/gomemcache/memcache"
+	"go.uber.org/zap"
 
 	"github.com/MarioCarrion/todo-api/internal"
 	"github.com/MarioCarrion/todo-api/internal/envvar"
+	"github.com/MarioCarrion/todo-api/internal/memcached"
 )
 
-func NewMemcached(conf *envvar.Configuration) (*memcache.Client, error) {
-	host, err := conf.Get("MEMCACHED_HOST")
+// NewMemcached instantiates the ring of Memcached nodes defined in MEMCACHED_HOST, a comma separated list of
+// servers; at least one of them must be healthy.
+func NewMemcached(conf *envvar.Configuration, logger *zap.Logger) (*memcached.Ring, error) {
+	ring := memcached.NewRing(logger, 100*time.Millisecond, 100, 30*time.Second)
+
+	if err := ReloadMemcached(conf, ring); err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "ReloadMemcached")
+	}
+
+	if len(ring.Healthy()) == 0 {
+		return nil, internal.NewErrorf(internal.ErrorCodeUnknown, "no healthy servers")
+	}
+
+	return ring, nil
+}
+
+// ReloadMemcached replaces the nodes in the ring with the ones currently defined in MEMCACHED_HOST.
+func ReloadMemcached(conf *envvar.Configuration, ring *memcached.Ring) error {
+	hosts, err := conf.Get("MEMCACHED_HOST")
 	if err != nil {
-		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "conf.Get MEMCACHED_HOST")
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "conf.Get MEMCACHED_HOST")
 	}
 
-	// XXX Assuming environment variable contains only one server
-	client := memcache.New(host)
+	var servers []string
 
-	if err := client.Ping(); err != nil {
-		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "ping")
+	for _, host := range strings.Split(hosts, ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			servers = append(servers, host)
+		}
 	}
 
-	client.Timeout = 100 * time.Millisecond
-	client.MaxIdleConns = 100
+	if err := ring.SetServers(servers...); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "ring.SetServers")
+	}
 
-	return client, nil
+	return nil
 }
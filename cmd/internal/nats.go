@@ -0,0 +1,55 @@
+package internal
+
+import (
+	"context"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/envvar"
+	internalnats "github.com/MarioCarrion/todo-api/internal/nats"
+)
+
+// NATS ...
+type NATS struct {
+	Connection *nats.Conn
+	JetStream  jetstream.JetStream
+}
+
+// NewNATS instantiates the NATS JetStream instances using configuration defined in environment variables, the
+// stream keeping the task events is created when it doesn't exist.
+func NewNATS(conf *envvar.Configuration) (*NATS, error) {
+	url, err := conf.Get("NATS_URL")
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "conf.Get")
+	}
+
+	conn, err := nats.Connect(url, nats.Name("todo-api"))
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "nats.Connect")
+	}
+
+	js, err := jetstream.New(conn)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "jetstream.New")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := internalnats.CreateStream(ctx, js); err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "nats.CreateStream")
+	}
+
+	return &NATS{
+		Connection: conn,
+		JetStream:  js,
+	}, nil
+}
+
+// Close ...
+func (n *NATS) Close() {
+	n.Connection.Close()
+}
@@ -1,20 +1,18 @@
 package internal
 
 import (
+	"net/http"
 	"time"
 
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.opentelemetry.io/contrib/instrumentation/runtime"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/jaeger"
 	"go.opentelemetry.io/otel/exporters/prometheus"
-	"go.opentelemetry.io/otel/metric/global"
 	"go.opentelemetry.io/otel/propagation"
-	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
-	"go.opentelemetry.io/otel/sdk/metric/aggregator/histogram"
-	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
-	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
-	selector "go.opentelemetry.io/otel/sdk/metric/selector/simple"
+	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	"go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
@@ -23,52 +21,56 @@ import (
 	"github.com/MarioCarrion/todo-api/internal/envvar"
 )
 
-// NewOTExporter instantiates the OpenTelemetry exporters using configuration defined in environment variables.
-func NewOTExporter(conf *envvar.Configuration) (*prometheus.Exporter, error) {
-	if err := runtime.Start(runtime.WithMinimumReadMemStatsInterval(time.Second)); err != nil {
-		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "runtime.Start")
-	}
+// NewOTExporter instantiates the OpenTelemetry exporters using configuration defined in environment variables,
+// serviceName identifies the process in the traces. The returned handler serves the metrics using the Prometheus
+// format. Traces are only exported when "JAEGER_ENDPOINT" is defined.
+func NewOTExporter(conf *envvar.Configuration, serviceName string) (http.Handler, error) {
+	res := resource.NewSchemaless(attribute.KeyValue{
+		Key:   semconv.ServiceNameKey,
+		Value: attribute.StringValue(serviceName),
+	})
 
-	config := prometheus.Config{}
-	c := controller.New(
-		processor.NewFactory(
-			selector.NewWithHistogramDistribution(
-				histogram.WithExplicitBoundaries(config.DefaultHistogramBoundaries),
-			),
-			aggregation.CumulativeTemporalitySelector(),
-			processor.WithMemory(true),
-		),
-	)
+	// A dedicated registry only includes the instruments created using the global meter provider.
+	registry := prom.NewRegistry()
 
-	promExporter, err := prometheus.New(config, c)
+	promExporter, err := prometheus.New(prometheus.WithRegisterer(registry))
 	if err != nil {
 		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "prometheus.New")
 	}
 
-	global.SetMeterProvider(promExporter.MeterProvider())
+	otel.SetMeterProvider(metric.NewMeterProvider(
+		metric.WithReader(promExporter),
+		metric.WithResource(res),
+	))
+
+	// Started after setting the meter provider, it uses the global one.
+	if err := runtime.Start(runtime.WithMinimumReadMemStatsInterval(time.Second)); err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "runtime.Start")
+	}
 
 	//-
 
-	jaegerEndpoint, _ := conf.Get("JAEGER_ENDPOINT")
+	opts := []trace.TracerProviderOption{
+		trace.WithSampler(trace.AlwaysSample()),
+		trace.WithResource(res),
+	}
+
+	// Traces are not exported when Jaeger is not configured, for example when running locally without it.
+	if jaegerEndpoint, _ := conf.Get("JAEGER_ENDPOINT"); jaegerEndpoint != "" {
+		jaegerExporter, err := jaeger.New(
+			jaeger.WithCollectorEndpoint(jaeger.WithEndpoint(jaegerEndpoint)),
+		)
+		if err != nil {
+			return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "jaeger.New")
+		}
 
-	jaegerExporter, err := jaeger.New(
-		jaeger.WithCollectorEndpoint(jaeger.WithEndpoint(jaegerEndpoint)),
-	)
-	if err != nil {
-		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "jaeger.New")
+		opts = append(opts, trace.WithSyncer(jaegerExporter))
 	}
 
-	tp := trace.NewTracerProvider(
-		trace.WithSampler(trace.AlwaysSample()),
-		trace.WithSyncer(jaegerExporter),
-		trace.WithResource(resource.NewSchemaless(attribute.KeyValue{
-			Key:   semconv.ServiceNameKey,
-			Value: attribute.StringValue("rest-server"),
-		})),
-	)
+	tp := trace.NewTracerProvider(opts...)
 
 	otel.SetTracerProvider(tp)
 	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
 
-	return promExporter, nil
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{}), nil
 }
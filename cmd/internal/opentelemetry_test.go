@@ -0,0 +1,54 @@
+package internal_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api/cmd/internal"
+	"github.com/MarioCarrion/todo-api/internal/envvar"
+	"github.com/MarioCarrion/todo-api/internal/envvar/envvartesting"
+	"github.com/MarioCarrion/todo-api/internal/lru"
+	"github.com/MarioCarrion/todo-api/internal/memcached"
+	"github.com/MarioCarrion/todo-api/internal/memory"
+)
+
+func TestNewOTExporter(t *testing.T) {
+	// Not parallel, it sets the global meter provider.
+
+	handler, err := internal.NewOTExporter(envvar.New(&envvartesting.FakeProvider{}), "test")
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	// Instruments created by the packages use the global meter provider.
+	task := memcached.NewTask(lru.NewCache(10), memory.NewTask(), zap.NewNop())
+
+	for i := 0; i < 2; i++ {
+		// Not found errors are cached, so the first one is a miss and the second one a hit.
+		_, _ = task.Find(context.Background(), "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee")
+	}
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	res := rr.Result()
+	defer res.Body.Close()
+
+	body, _ := io.ReadAll(res.Body)
+
+	for _, expected := range []string{
+		`cache_hits_total{cache="task"`,
+		`cache_misses_total{cache="task"`,
+		`runtime_go_goroutines`,
+	} {
+		if !strings.Contains(string(body), expected) {
+			t.Fatalf("expected %s metric, got\n%s", expected, body)
+		}
+	}
+}
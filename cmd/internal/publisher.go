@@ -0,0 +1,85 @@
+package internal
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/envvar"
+	"github.com/MarioCarrion/todo-api/internal/kafka"
+	"github.com/MarioCarrion/todo-api/internal/nats"
+	"github.com/MarioCarrion/todo-api/internal/rabbitmq"
+	"github.com/MarioCarrion/todo-api/internal/redis"
+	"github.com/MarioCarrion/todo-api/internal/scheduler"
+	"github.com/MarioCarrion/todo-api/internal/service"
+)
+
+// Publisher publishes the Task events, it's implemented by the Task repositories of all the message brokers.
+type Publisher interface {
+	service.TaskMessageBrokerRepository
+	scheduler.Publisher
+}
+
+// NewPublisher instantiates the publisher of the message broker selected by name, using the same names as
+// NewSource; the returned function closes the connections it uses, after delivering the pending messages.
+func NewPublisher(conf *envvar.Configuration, logger *zap.Logger, name string,
+) (Publisher, func(ctx context.Context) error, error) {
+	codec, err := NewEventsCodec(conf)
+	if err != nil {
+		return nil, nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "internal.NewEventsCodec")
+	}
+
+	switch name {
+	case SourceKafka:
+		producer, err := NewKafkaProducer(conf, logger)
+		if err != nil {
+			return nil, nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "internal.NewKafkaProducer")
+		}
+
+		return kafka.NewTask(producer.Producer, producer.Topic, codec), producer.Close, nil
+	case SourceNATS:
+		nc, err := NewNATS(conf)
+		if err != nil {
+			return nil, nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "internal.NewNATS")
+		}
+
+		return nats.NewTask(nc.JetStream, codec), func(context.Context) error {
+			nc.Close()
+
+			return nil
+		}, nil
+	case SourceRabbitMQ:
+		rmq, err := NewRabbitMQ(conf)
+		if err != nil {
+			return nil, nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "internal.NewRabbitMQ")
+		}
+
+		task, err := rabbitmq.NewTask(rmq.Channel, codec)
+		if err != nil {
+			return nil, nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "rabbitmq.NewTask")
+		}
+
+		return task, func(context.Context) error {
+			rmq.Close()
+
+			return nil
+		}, nil
+	case SourceRedis:
+		maxLen, err := NewRedisStreamMaxLen(conf)
+		if err != nil {
+			return nil, nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "internal.NewRedisStreamMaxLen")
+		}
+
+		rdb, err := NewRedis(conf)
+		if err != nil {
+			return nil, nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "internal.NewRedis")
+		}
+
+		return redis.NewTaskStream(rdb, codec, maxLen), func(context.Context) error {
+			return rdb.Close() //nolint: wrapcheck
+		}, nil
+	}
+
+	return nil, nil, internal.NewErrorf(internal.ErrorCodeInvalidArgument, "unknown message broker %q", name)
+}
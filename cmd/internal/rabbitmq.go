@@ -54,7 +54,7 @@ func NewRabbitMQ(conf *envvar.Configuration) (*RabbitMQ, error) {
 		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "ch.Qos")
 	}
 
-	// XXX: Dead Letter Exchange will be implemented in future episodes
+	// Queues, and their dead letter exchanges, are declared by the consumers using "rabbitmq.Retry".
 
 	return &RabbitMQ{
 		Connection: conn,
@@ -10,6 +10,8 @@ import (
 	"github.com/MarioCarrion/todo-api/internal/envvar"
 )
 
+const defaultRedisStreamMaxLen = 100_000
+
 // NewRedis instantiates the Redis client using configuration defined in environment variables.
 func NewRedis(conf *envvar.Configuration) (*redis.Client, error) {
 	host, err := conf.Get("REDIS_HOST")
@@ -35,3 +37,23 @@ func NewRedis(conf *envvar.Configuration) (*redis.Client, error) {
 
 	return rdb, nil
 }
+
+// NewRedisStreamMaxLen returns the approximate maximum length of the Task events stream defined in
+// "REDIS_STREAM_MAXLEN", defaults to 100,000 entries.
+func NewRedisStreamMaxLen(conf *envvar.Configuration) (int64, error) {
+	val, err := conf.Get("REDIS_STREAM_MAXLEN")
+	if err != nil {
+		return 0, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "conf.Get REDIS_STREAM_MAXLEN")
+	}
+
+	if val == "" {
+		return defaultRedisStreamMaxLen, nil
+	}
+
+	res, err := strconv.ParseInt(val, 10, 64)
+	if err != nil || res <= 0 {
+		return 0, internal.NewErrorf(internal.ErrorCodeInvalidArgument, "invalid REDIS_STREAM_MAXLEN %q", val)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,41 @@
+package internal
+
+import (
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/envvar"
+	"github.com/MarioCarrion/todo-api/internal/notifier"
+)
+
+// NewSMTP instantiates the SMTP sender using configuration defined in environment variables, the credentials are
+// optional.
+func NewSMTP(conf *envvar.Configuration) (*notifier.SMTP, error) {
+	var config notifier.SMTPConfig
+
+	for _, v := range []struct {
+		key   string
+		value *string
+	}{
+		{"SMTP_HOST", &config.Host},
+		{"SMTP_PORT", &config.Port},
+		{"SMTP_USERNAME", &config.Username},
+		{"SMTP_PASSWORD", &config.Password},
+		{"SMTP_FROM", &config.From},
+	} {
+		val, err := conf.Get(v.key)
+		if err != nil {
+			return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "conf.Get %s", v.key)
+		}
+
+		*v.value = val
+	}
+
+	if config.Host == "" || config.From == "" {
+		return nil, internal.NewErrorf(internal.ErrorCodeInvalidArgument, "SMTP_HOST and SMTP_FROM are required")
+	}
+
+	if config.Port == "" {
+		config.Port = "25"
+	}
+
+	return notifier.NewSMTP(config), nil
+}
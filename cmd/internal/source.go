@@ -0,0 +1,110 @@
+package internal
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/envvar"
+	"github.com/MarioCarrion/todo-api/internal/indexer"
+	"github.com/MarioCarrion/todo-api/internal/kafka"
+	"github.com/MarioCarrion/todo-api/internal/nats"
+	"github.com/MarioCarrion/todo-api/internal/rabbitmq"
+	"github.com/MarioCarrion/todo-api/internal/redis"
+)
+
+// Names of the sources consuming the Task events, see NewSource.
+const (
+	SourceKafka    = "kafka"
+	SourceNATS     = "nats"
+	SourceRabbitMQ = "rabbitmq"
+	SourceRedis    = "redis"
+)
+
+// SourceOptions ...
+type SourceOptions struct {
+	// Name is the name of the queue, consumer group or durable consumer, depending on the source; each service
+	// consuming the events uses its own.
+	Name          string
+	BatchSize     int
+	FlushInterval time.Duration
+	MaxAttempts   int
+	RetryBackoff  time.Duration
+	Group         string
+	Consumer      string
+	MinIdle       time.Duration
+	AckWait       time.Duration
+}
+
+// NewSource instantiates the source selected by name, the returned function closes the connections it uses.
+func NewSource(conf *envvar.Configuration, logger *zap.Logger, name string,
+	opts SourceOptions,
+) (indexer.Source, func(), error) {
+	switch name {
+	case SourceKafka:
+		consumer, err := NewKafkaConsumer(conf, opts.Name)
+		if err != nil {
+			return nil, nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "internal.NewKafkaConsumer")
+		}
+
+		// Used for publishing to the dead letter topic.
+		producer, err := NewKafkaProducer(conf, logger)
+		if err != nil {
+			return nil, nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "internal.NewKafkaProducer")
+		}
+
+		return kafka.NewSource(logger, consumer.Consumer, producer.Producer, consumer.Topic), func() {
+			_ = consumer.Consumer.Close()
+
+			producer.Producer.Close()
+		}, nil
+	case SourceNATS:
+		nc, err := NewNATS(conf)
+		if err != nil {
+			return nil, nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "internal.NewNATS")
+		}
+
+		// Messages are acknowledged after their batch is indexed: waiting for the acknowledgement must take longer
+		// than filling a batch, and allowing less pending messages than a batch would never fill it.
+		if opts.AckWait <= opts.FlushInterval {
+			return nil, nil, internal.NewErrorf(internal.ErrorCodeInvalidArgument,
+				"ack-wait %s must be longer than flush-interval %s", opts.AckWait, opts.FlushInterval)
+		}
+
+		consumer, err := nats.CreateConsumer(context.Background(), nc.JetStream, opts.Name, opts.AckWait,
+			2*opts.BatchSize, opts.MaxAttempts)
+		if err != nil {
+			return nil, nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "nats.CreateConsumer")
+		}
+
+		return nats.NewSource(logger, nc.Connection, nc.JetStream, consumer, opts.BatchSize, opts.RetryBackoff),
+			nc.Close, nil
+	case SourceRabbitMQ:
+		rmq, err := NewRabbitMQ(conf)
+		if err != nil {
+			return nil, nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "internal.NewRabbitMQ")
+		}
+
+		// Messages are acknowledged after their batch is indexed, prefetching less than a batch would never fill it.
+		if err := rmq.Channel.Qos(opts.BatchSize, 0, false); err != nil {
+			return nil, nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "channel.Qos")
+		}
+
+		retry := rabbitmq.NewRetry(rmq.Channel, opts.Name, opts.MaxAttempts, opts.RetryBackoff)
+
+		return rabbitmq.NewSource(logger, rmq.Connection, rmq.Channel, retry, opts.Name, opts.Name), rmq.Close, nil
+	case SourceRedis:
+		rdb, err := NewRedis(conf)
+		if err != nil {
+			return nil, nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "internal.NewRedis")
+		}
+
+		consumer := redis.NewStreamConsumer(rdb, opts.Group, opts.Consumer, opts.MinIdle, int64(opts.MaxAttempts))
+
+		return redis.NewStreamSource(logger, rdb, consumer, int64(opts.BatchSize)), func() { _ = rdb.Close() }, nil
+	}
+
+	return nil, nil, internal.NewErrorf(internal.ErrorCodeInvalidArgument, "unknown source %q", name)
+}
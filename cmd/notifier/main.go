@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"math"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api/cmd/internal"
+	internaldomain "github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/envvar"
+	"github.com/MarioCarrion/todo-api/internal/indexer"
+	"github.com/MarioCarrion/todo-api/internal/notifier"
+	"github.com/MarioCarrion/todo-api/internal/postgresql"
+)
+
+// lockKey is the default key of the PostgreSQL advisory lock electing the replica sending the digests, "mail" in
+// ASCII.
+const lockKey = 0x6d61696c
+
+func main() {
+	var (
+		env        string
+		address    string
+		templates  string
+		key        int64
+		digestSize int
+		opts       = internal.SourceOptions{Name: "notifier", BatchSize: 1}
+		notOpts    notifier.Options
+	)
+
+	hostname, _ := os.Hostname()
+
+	flag.StringVar(&env, "env", "", "Environment Variables filename")
+	flag.StringVar(&address, "address", ":9238", "HTTP Server Address, serves /health and /metrics")
+	flag.StringVar(&templates, "templates", "",
+		"Directory with the text.tmpl and html.tmpl email templates, uses the embedded ones by default")
+	flag.Int64Var(&key, "lock-key", lockKey, "PostgreSQL advisory lock key, shared by all the replicas")
+	flag.IntVar(&notOpts.MaxAttempts, "attempts", 5, "Number of times an email is sent before giving up")
+	flag.DurationVar(&notOpts.Backoff, "backoff", time.Second, "Delay before sending an email again, doubled per attempt")
+	flag.DurationVar(&notOpts.MaxBackoff, "max-backoff", 30*time.Second, "Maximum delay before sending an email again")
+	flag.DurationVar(&notOpts.DigestInterval, "digest-interval", time.Hour, "Time between digests")
+	flag.IntVar(&digestSize, "digest-size", 100, "Maximum number of events included in each digest email")
+	flag.IntVar(&opts.MaxAttempts, "max-attempts", 5, "RabbitMQ, Redis and NATS: Number of attempts before dead-lettering a message")
+	flag.DurationVar(&opts.RetryBackoff, "retry-backoff", time.Second,
+		"RabbitMQ and NATS: Delay before retrying a message, doubled per attempt in RabbitMQ")
+	flag.StringVar(&opts.Group, "group", "notifier", "Redis: Consumer group, shared by all the replicas")
+	flag.StringVar(&opts.Consumer, "consumer", hostname, "Redis: Consumer name, must be unique for each replica")
+	flag.DurationVar(&opts.MinIdle, "min-idle", 5*time.Minute, "Redis: Time before claiming the events pending in other consumers")
+	flag.DurationVar(&opts.AckWait, "ack-wait", 5*time.Minute, "NATS: Time to wait for an acknowledgement before redelivering")
+	flag.Parse()
+
+	if digestSize <= 0 || digestSize > math.MaxInt32 {
+		log.Fatalf("Invalid digest size: %d", digestSize)
+	}
+
+	notOpts.DigestSize = int32(digestSize)
+
+	errC, err := run(env, address, templates, key, opts, notOpts)
+	if err != nil {
+		log.Fatalf("Couldn't run: %s", err)
+	}
+
+	if err := <-errC; err != nil {
+		log.Fatalf("Error while running: %s", err)
+	}
+}
+
+func run(env, address, templatesDir string, key int64,
+	opts internal.SourceOptions, notOpts notifier.Options,
+) (<-chan error, error) {
+	logger, err := zap.NewProduction()
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "zap.NewProduction")
+	}
+
+	if err := envvar.Load(env); err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "envvar.Load")
+	}
+
+	vault, err := internal.NewVaultProvider()
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewVaultProvider")
+	}
+
+	conf := envvar.New(vault)
+
+	//-
+
+	fsys := notifier.DefaultTemplates()
+	if templatesDir != "" {
+		fsys = os.DirFS(templatesDir)
+	}
+
+	templates, err := notifier.NewTemplates(fsys)
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "notifier.NewTemplates")
+	}
+
+	sender, err := internal.NewSMTP(conf)
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewSMTP")
+	}
+
+	name, err := conf.Get("NOTIFIER_SOURCE")
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "conf.Get NOTIFIER_SOURCE")
+	}
+
+	if name == "" {
+		name = internal.SourceRedis
+	}
+
+	source, closeSource, err := internal.NewSource(conf, logger, name, opts)
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewSource")
+	}
+
+	pool, err := internal.NewPostgreSQL(conf)
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewPostgreSQL")
+	}
+
+	//-
+
+	promExporter, err := internal.NewOTExporter(conf, "notifier")
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewOTExporter")
+	}
+
+	//-
+
+	n := notifier.NewNotifier(logger,
+		source,
+		postgresql.NewNotification(pool),
+		sender,
+		templates,
+		postgresql.NewAdvisoryLock(pool, key),
+		notOpts)
+
+	mux := http.NewServeMux()
+	mux.Handle("/health", indexer.HealthHandler(n))
+	mux.Handle("/metrics", promExporter)
+
+	srv := &http.Server{
+		Addr:              address,
+		Handler:           mux,
+		ReadHeaderTimeout: time.Second,
+	}
+
+	errC := make(chan error, 1)
+
+	ctx, stop := signal.NotifyContext(context.Background(),
+		os.Interrupt,
+		syscall.SIGTERM,
+		syscall.SIGQUIT)
+
+	go func() {
+		<-ctx.Done()
+
+		logger.Info("Shutdown signal received")
+
+		ctxTimeout, cancel := context.WithTimeout(context.Background(), 35*time.Second)
+
+		defer func() {
+			_ = logger.Sync()
+
+			closeSource()
+			pool.Close()
+			stop()
+			cancel()
+			close(errC)
+		}()
+
+		if err := n.Shutdown(ctxTimeout); err != nil { //nolint: contextcheck
+			errC <- err
+		}
+
+		if err := srv.Shutdown(ctxTimeout); err != nil { //nolint: contextcheck
+			errC <- err
+		}
+
+		logger.Info("Shutdown completed")
+	}()
+
+	go func() {
+		logger.Info("Listening and serving", zap.String("source", name), zap.String("address", address))
+
+		if err := n.ListenAndServe(); err != nil {
+			errC <- err
+		}
+	}()
+
+	go func() {
+		// "ListenAndServe always returns a non-nil error. After Shutdown or Close, the returned error is
+		// ErrServerClosed."
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errC <- err
+		}
+	}()
+
+	return errC, nil
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"github.com/streadway/amqp"
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api/cmd/internal"
+	internaldomain "github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/envvar"
+	"github.com/MarioCarrion/todo-api/internal/events"
+	"github.com/MarioCarrion/todo-api/internal/rabbitmq"
+)
+
+func main() {
+	var (
+		env    string
+		queue  string
+		replay bool
+		limit  int
+	)
+
+	flag.StringVar(&env, "env", "", "Environment Variables filename")
+	flag.StringVar(&queue, "queue", "elasticsearch-indexer", "Queue the dead-lettered messages were consumed from")
+	flag.BoolVar(&replay, "replay", false, "Publish the dead-lettered messages to the queue again")
+	flag.IntVar(&limit, "limit", 100, "Maximum number of messages to inspect or replay")
+	flag.Parse()
+
+	if err := run(env, queue, replay, limit); err != nil {
+		log.Fatalf("Couldn't run: %s", err)
+	}
+}
+
+// run lists the messages in the dead letter queue, or replays them when replay is true.
+func run(env, queue string, replay bool, limit int) error {
+	logger, err := zap.NewProduction()
+	if err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "zap.NewProduction")
+	}
+
+	defer func() {
+		_ = logger.Sync()
+	}()
+
+	if err := envvar.Load(env); err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "envvar.Load")
+	}
+
+	vault, err := internal.NewVaultProvider()
+	if err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewVaultProvider")
+	}
+
+	conf := envvar.New(vault)
+
+	//-
+
+	rmq, err := internal.NewRabbitMQ(conf)
+	if err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewRabbitMQ")
+	}
+
+	defer rmq.Close()
+
+	//-
+
+	if replay {
+		count, err := replayMessages(rmq.Channel, queue, limit)
+		if err != nil {
+			return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "replayMessages")
+		}
+
+		logger.Info("Messages replayed", zap.Int("count", count), zap.String("queue", queue))
+
+		return nil
+	}
+
+	if err := inspectMessages(rmq.Channel, queue, limit); err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "inspectMessages")
+	}
+
+	return nil
+}
+
+// inspectMessages prints the messages in the dead letter queue, they are requeued afterwards.
+func inspectMessages(ch *amqp.Channel, queue string, limit int) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "ID\tROUTING KEY\tTYPE\tATTEMPTS\tTASK\tERROR")
+
+	var lastTag uint64
+
+	for i := 0; i < limit; i++ {
+		msg, ok, err := ch.Get(rabbitmq.DeadLetterQueue(queue), false)
+		if err != nil {
+			return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "ch.Get")
+		}
+
+		if !ok {
+			break
+		}
+
+		lastTag = msg.DeliveryTag
+
+		var taskID string
+
+		if evt, err := events.Decode(rabbitmq.RoutingKey(msg), msg.ContentType, msg.Body); err == nil {
+			taskID = evt.Task.ID
+		}
+
+		errMsg, _ := msg.Headers[rabbitmq.HeaderError].(string)
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\n",
+			msg.MessageId, rabbitmq.RoutingKey(msg), msg.Type, rabbitmq.Attempts(msg), taskID, errMsg)
+	}
+
+	if err := w.Flush(); err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "w.Flush")
+	}
+
+	if lastTag == 0 {
+		return nil
+	}
+
+	// Inspecting must not remove the messages, all of them are requeued at once.
+	if err := ch.Nack(lastTag, true, true); err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "ch.Nack")
+	}
+
+	return nil
+}
+
+// replayMessages publishes the messages in the dead letter queue to the queue again.
+func replayMessages(ch *amqp.Channel, queue string, limit int) (int, error) {
+	retry := rabbitmq.NewRetry(ch, queue, 0, 0)
+
+	var count int
+
+	for ; count < limit; count++ {
+		msg, ok, err := ch.Get(rabbitmq.DeadLetterQueue(queue), false)
+		if err != nil {
+			return count, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "ch.Get")
+		}
+
+		if !ok {
+			break
+		}
+
+		if err := retry.Replay(msg); err != nil {
+			return count, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "retry.Replay")
+		}
+	}
+
+	return count, nil
+}
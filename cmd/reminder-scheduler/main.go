@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"math"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api/cmd/internal"
+	internaldomain "github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/envvar"
+	"github.com/MarioCarrion/todo-api/internal/indexer"
+	"github.com/MarioCarrion/todo-api/internal/postgresql"
+	"github.com/MarioCarrion/todo-api/internal/scheduler"
+)
+
+// lockKey is the default key of the PostgreSQL advisory lock electing the leader, "todo" in ASCII.
+const lockKey = 0x746f646f
+
+func main() {
+	var (
+		env     string
+		address string
+		key     int64
+		size    int
+		opts    scheduler.Options
+	)
+
+	flag.StringVar(&env, "env", "", "Environment Variables filename")
+	flag.StringVar(&address, "address", ":9237", "HTTP Server Address, serves /health and /metrics")
+	flag.DurationVar(&opts.Interval, "interval", time.Minute, "Time between looking for tasks due soon or overdue")
+	flag.IntVar(&size, "batch-size", 100, "Number of reminders read at once")
+	flag.Int64Var(&key, "lock-key", lockKey, "PostgreSQL advisory lock key, shared by all the replicas")
+	flag.Parse()
+
+	if size <= 0 || size > math.MaxInt32 {
+		log.Fatalf("Invalid batch size: %d", size)
+	}
+
+	opts.BatchSize = int32(size)
+
+	errC, err := run(env, address, key, opts)
+	if err != nil {
+		log.Fatalf("Couldn't run: %s", err)
+	}
+
+	if err := <-errC; err != nil {
+		log.Fatalf("Error while running: %s", err)
+	}
+}
+
+func run(env, address string, key int64, opts scheduler.Options) (<-chan error, error) {
+	logger, err := zap.NewProduction()
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "zap.NewProduction")
+	}
+
+	if err := envvar.Load(env); err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "envvar.Load")
+	}
+
+	vault, err := internal.NewVaultProvider()
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewVaultProvider")
+	}
+
+	conf := envvar.New(vault)
+
+	//-
+
+	name, err := conf.Get("SCHEDULER_BROKER")
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "conf.Get SCHEDULER_BROKER")
+	}
+
+	if name == "" {
+		name = internal.SourceRedis
+	}
+
+	publisher, closePublisher, err := internal.NewPublisher(conf, logger, name)
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewPublisher")
+	}
+
+	pool, err := internal.NewPostgreSQL(conf)
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewPostgreSQL")
+	}
+
+	//-
+
+	promExporter, err := internal.NewOTExporter(conf, "reminder-scheduler")
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewOTExporter")
+	}
+
+	//-
+
+	sched := scheduler.NewScheduler(logger,
+		postgresql.NewAdvisoryLock(pool, key),
+		postgresql.NewTaskReminder(pool),
+		publisher,
+		opts)
+
+	mux := http.NewServeMux()
+	mux.Handle("/health", indexer.HealthHandler(sched))
+	mux.Handle("/metrics", promExporter)
+
+	srv := &http.Server{
+		Addr:              address,
+		Handler:           mux,
+		ReadHeaderTimeout: time.Second,
+	}
+
+	errC := make(chan error, 1)
+
+	ctx, stop := signal.NotifyContext(context.Background(),
+		os.Interrupt,
+		syscall.SIGTERM,
+		syscall.SIGQUIT)
+
+	go func() {
+		<-ctx.Done()
+
+		logger.Info("Shutdown signal received")
+
+		ctxTimeout, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+
+		defer func() {
+			_ = logger.Sync()
+
+			// Reminders published while shutting down are delivered before exiting.
+			if err := closePublisher(ctxTimeout); err != nil {
+				logger.Error("Couldn't close publisher", zap.Error(err))
+			}
+
+			pool.Close()
+			stop()
+			cancel()
+			close(errC)
+		}()
+
+		if err := sched.Shutdown(ctxTimeout); err != nil { //nolint: contextcheck
+			errC <- err
+		}
+
+		if err := srv.Shutdown(ctxTimeout); err != nil { //nolint: contextcheck
+			errC <- err
+		}
+
+		logger.Info("Shutdown completed")
+	}()
+
+	go func() {
+		logger.Info("Listening and serving", zap.String("broker", name), zap.String("address", address))
+
+		if err := sched.ListenAndServe(); err != nil {
+			errC <- err
+		}
+	}()
+
+	go func() {
+		// "ListenAndServe always returns a non-nil error. After Shutdown or Close, the returned error is
+		// ErrServerClosed."
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errC <- err
+		}
+	}()
+
+	return errC, nil
+}
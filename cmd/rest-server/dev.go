@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/riandyrn/otelchi"
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api/cmd/internal"
+	internaldomain "github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/envvar"
+	"github.com/MarioCarrion/todo-api/internal/lru"
+	"github.com/MarioCarrion/todo-api/internal/memcached"
+	"github.com/MarioCarrion/todo-api/internal/memory"
+)
+
+// profileDev is the "-profile" value running the server without external services, meant for local development.
+const profileDev = "dev"
+
+// memoryRepositories defines the in-memory repositories used by the "dev" profile.
+type memoryRepositories struct {
+	Task          *memory.Task
+	Search        *memory.SearchableTask
+	MessageBroker *memory.MessageBroker
+}
+
+// devProvider is the envvar.Provider used by the "dev" profile, secure values are not supported because Vault is
+// not used.
+type devProvider struct{}
+
+// Get always fails, values must be defined using environment variables instead of "<key>_SECURE" ones.
+func (devProvider) Get(key string) (string, error) {
+	return "", internaldomain.NewErrorf(internaldomain.ErrorCodeInvalidArgument,
+		"%s: secure values are not supported by the dev profile", key)
+}
+
+// runDev runs the server using in-memory repositories, an in-process cache and an in-process indexer instead of
+// PostgreSQL, Elasticsearch, Memcached and a message broker; tasks are lost when exiting.
+func runDev(logger *zap.Logger, address string) (<-chan error, error) {
+	// Environment variables are optional, without "JAEGER_ENDPOINT" traces are not exported.
+	conf := envvar.New(devProvider{})
+
+	calendarToken, err := conf.Get("CALENDAR_TOKEN")
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "conf.Get CALENDAR_TOKEN")
+	}
+
+	promExporter, err := internal.NewOTExporter(conf, "rest-server")
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewOTExporter")
+	}
+
+	//-
+
+	cache := lru.NewCache(10_000)
+
+	repos := memoryRepositories{
+		Task:          memory.NewTask(),
+		Search:        memory.NewSearchableTask(),
+		MessageBroker: memory.NewMessageBroker(1_000),
+	}
+
+	// Indexing drops the cached search results, the same way "elasticsearch-indexer" does.
+	generation := memcached.NewSearchGeneration(cache, logger)
+
+	indexer := memory.NewIndexer(logger, repos.MessageBroker,
+		memcached.NewBulkSearchableTask(generation, repos.Search))
+
+	srv, err := newServer(serverConfig{
+		Address:       address,
+		Metrics:       promExporter,
+		Middlewares:   []func(next http.Handler) http.Handler{otelchi.Middleware("todo-api-server")},
+		Logger:        logger,
+		Cache:         internal.Cache{Remote: cache, Tiered: cache},
+		Memory:        &repos,
+		CalendarToken: calendarToken,
+	})
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "newServer")
+	}
+
+	indexer.Start()
+
+	ctx, stop := signal.NotifyContext(context.Background(),
+		os.Interrupt,
+		syscall.SIGTERM,
+		syscall.SIGQUIT)
+
+	logger.Info("Running using the dev profile, tasks are kept in memory")
+
+	return serve(ctx, stop, srv, logger, func(ctx context.Context) {
+		if err := indexer.Close(ctx); err != nil {
+			logger.Error("Couldn't close indexer", zap.Error(err))
+		}
+	}), nil
+}
@@ -13,16 +13,11 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/bradfitz/gomemcache/memcache"
 	"github.com/didip/tollbooth/v6"
 	"github.com/didip/tollbooth/v6/limiter"
 	esv7 "github.com/elastic/go-elasticsearch/v7"
-
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
-	rv8 "github.com/go-redis/redis/v8"
-
-  
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/riandyrn/otelchi"
 	"go.uber.org/zap"
@@ -33,7 +28,6 @@ import (
 	"github.com/MarioCarrion/todo-api/internal/envvar"
 	"github.com/MarioCarrion/todo-api/internal/memcached"
 	"github.com/MarioCarrion/todo-api/internal/postgresql"
-	"github.com/MarioCarrion/todo-api/internal/redis"
 	"github.com/MarioCarrion/todo-api/internal/rest"
 	"github.com/MarioCarrion/todo-api/internal/service"
 )
@@ -41,14 +35,18 @@ import (
 //go:embed static
 var content embed.FS
 
+// searchEnginePostgreSQL is the "SEARCH_ENGINE" value selecting PostgreSQL full-text search instead of Elasticsearch.
+const searchEnginePostgreSQL = "postgresql"
+
 func main() {
-	var env, address string
+	var env, address, profile string
 
 	flag.StringVar(&env, "env", "", "Environment Variables filename")
 	flag.StringVar(&address, "address", ":9234", "HTTP Server Address")
+	flag.StringVar(&profile, "profile", "", `Profile, "dev" uses in-memory repositories instead of external services`)
 	flag.Parse()
 
-	errC, err := run(env, address)
+	errC, err := run(env, address, profile)
 	if err != nil {
 		log.Fatalf("Couldn't run: %s", err)
 	}
@@ -58,12 +56,20 @@ func main() {
 	}
 }
 
-func run(env, address string) (<-chan error, error) {
+func run(env, address, profile string) (<-chan error, error) {
 	logger, err := zap.NewProduction()
 	if err != nil {
 		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "zap.NewProduction")
 	}
 
+	switch profile {
+	case "":
+	case profileDev:
+		return runDev(logger, address)
+	default:
+		return nil, internaldomain.NewErrorf(internaldomain.ErrorCodeInvalidArgument, "unknown profile %q", profile)
+	}
+
 	if err := envvar.Load(env); err != nil {
 		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "envvar.Load")
 	}
@@ -82,44 +88,60 @@ func run(env, address string) (<-chan error, error) {
 		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewPostgreSQL")
 	}
 
-	esClient, err := internal.NewElasticSearch(conf)
+	searchEngine, err := conf.Get("SEARCH_ENGINE")
 	if err != nil {
-		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewElasticSearch")
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "conf.Get SEARCH_ENGINE")
 	}
 
-	memcached, err := internal.NewMemcached(conf)
+	var esClient *esv7.Client
+
+	if searchEngine != searchEnginePostgreSQL {
+		esClient, err = internal.NewElasticSearch(conf)
+		if err != nil {
+			return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewElasticSearch")
+		}
+	}
+
+	cache, err := internal.NewCache(conf, logger)
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewCache")
+	}
+
+	brokerName, err := conf.Get("EVENTS_BROKER")
 	if err != nil {
-		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewMemcached")
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "conf.Get EVENTS_BROKER")
 	}
 
-	// rmq, err := internal.NewRabbitMQ(conf)
-	// if err != nil {
-	// 	return nil, fmt.Errorf("internal.NewRabbitMQ %w", err)
-	// }
+	if brokerName == "" {
+		brokerName = internal.SourceRedis
+	}
 
-	// kafka, err := internal.NewKafkaProducer(conf)
-	// if err != nil {
-	// 	return nil, fmt.Errorf("internal.NewKafka %w", err)
-	// }
+	publisher, closePublisher, err := internal.NewPublisher(conf, logger, brokerName)
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewPublisher")
+	}
 
-	rdb, err := internal.NewRedis(conf)
+	calendarToken, err := conf.Get("CALENDAR_TOKEN")
 	if err != nil {
-		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewRedis")
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "conf.Get CALENDAR_TOKEN")
 	}
 
 	//-
 
-	promExporter, err := internal.NewOTExporter(conf)
+	promExporter, err := internal.NewOTExporter(conf, "rest-server")
 	if err != nil {
 		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewOTExporter")
 	}
 
+	logging := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			logger.Info(r.Method,
+				zap.Time("time", time.Now()),
+				zap.String("url", r.URL.String()),
+			)
 
-	logging := func(c *gin.Context) {
-		logger.Info(c.Request.Method,
-			zap.Time("time", time.Now()),
-			zap.String("url", c.Request.URL.String()),
-		)
+			next.ServeHTTP(w, r)
+		})
 	}
 
 	//-
@@ -128,27 +150,44 @@ func run(env, address string) (<-chan error, error) {
 		Address:       address,
 		DB:            pool,
 		ElasticSearch: esClient,
+		SearchEngine:  searchEngine,
 		Metrics:       promExporter,
-
-
 		Middlewares:   []func(next http.Handler) http.Handler{otelchi.Middleware("todo-api-server"), logging},
-		Redis:         rdb,
 		Logger:        logger,
-		Memcached:     memcached,
-		// RabbitMQ:      rmq,
-		// Kafka:         kafka,
+		Cache:         cache,
+		Publisher:     publisher,
+		CalendarToken: calendarToken,
 	})
 	if err != nil {
 		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "newServer")
 	}
 
-	errC := make(chan error, 1)
-
 	ctx, stop := signal.NotifyContext(context.Background(),
 		os.Interrupt,
 		syscall.SIGTERM,
 		syscall.SIGQUIT)
 
+	if cache.Ring != nil {
+		go reloadMemcached(ctx, env, conf, cache.Ring, logger)
+	}
+
+	return serve(ctx, stop, srv, logger, func(shutdownCtx context.Context) {
+		// Events published while serving the last requests are delivered before exiting, Kafka flushes them.
+		if err := closePublisher(shutdownCtx); err != nil {
+			logger.Error("Couldn't close message broker", zap.Error(err))
+		}
+
+		pool.Close()
+	}), nil
+}
+
+// serve listens and serves until ctx is done, then shuts down srv; closeFn releases the resources used by the
+// server after shutting it down.
+func serve(ctx context.Context, stop context.CancelFunc, srv *http.Server, logger *zap.Logger,
+	closeFn func(ctx context.Context),
+) <-chan error {
+	errC := make(chan error, 1)
+
 	go func() {
 		<-ctx.Done()
 
@@ -157,11 +196,10 @@ func run(env, address string) (<-chan error, error) {
 		ctxTimeout, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 
 		defer func() {
+			closeFn(ctxTimeout)
+
 			_ = logger.Sync()
 
-			pool.Close()
-			// rmq.Close()
-			rdb.Close()
 			stop()
 			cancel()
 			close(errC)
@@ -177,7 +215,7 @@ func run(env, address string) (<-chan error, error) {
 	}()
 
 	go func() {
-		logger.Info("Listening and serving", zap.String("address", address))
+		logger.Info("Listening and serving", zap.String("address", srv.Addr))
 
 		// "ListenAndServe always returns a non-nil error. After Shutdown or Close, the returned error is
 		// ErrServerClosed."
@@ -186,27 +224,57 @@ func run(env, address string) (<-chan error, error) {
 		}
 	}()
 
-	return errC, nil
+	return errC
+}
+
+// reloadMemcached replaces the Memcached nodes with the ones defined in the env file every time SIGHUP is
+// received, until ctx is done.
+func reloadMemcached(ctx context.Context, env string, conf *envvar.Configuration, ring *memcached.Ring,
+	logger *zap.Logger,
+) {
+	hupC := make(chan os.Signal, 1)
+	signal.Notify(hupC, syscall.SIGHUP)
+
+	defer signal.Stop(hupC)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hupC:
+		}
+
+		if err := envvar.Reload(env); err != nil {
+			logger.Error("Couldn't reload env file", zap.Error(err))
+
+			continue
+		}
+
+		if err := internal.ReloadMemcached(conf, ring); err != nil {
+			logger.Error("Couldn't reload Memcached nodes", zap.Error(err))
+
+			continue
+		}
+
+		logger.Info("Memcached nodes reloaded", zap.Strings("healthy", ring.Healthy()))
+	}
 }
 
 type serverConfig struct {
 	Address       string
 	DB            *pgxpool.Pool
 	ElasticSearch *esv7.Client
-	Kafka         *internal.KafkaProducer
-	RabbitMQ      *internal.RabbitMQ
-	Redis         *rv8.Client
-	Memcached     *memcache.Client
+	SearchEngine  string
+	Cache         internal.Cache
+	Memory        *memoryRepositories
+	Publisher     internal.Publisher
 	Metrics       http.Handler
-
-
+	CalendarToken string
 	Middlewares   []func(next http.Handler) http.Handler
 	Logger        *zap.Logger
 }
 
 func newServer(conf serverConfig) (*http.Server, error) {
-
-
 	router := chi.NewRouter()
 	router.Use(render.SetContentType(render.ContentTypeJSON))
 
@@ -216,35 +284,65 @@ func newServer(conf serverConfig) (*http.Server, error) {
 
 	//-
 
-	repo := postgresql.NewTask(conf.DB)
-	mrepo := memcached.NewTask(conf.Memcached, repo, conf.Logger)
+	var (
+		repo     memcached.TaskStore
+		transfer memcached.TaskTransferStore
+		search   memcached.SearchableTaskStore
+		broker   memcached.TaskMessageBrokerStore
+	)
+
+	if conf.Memory != nil {
+		repo, search, broker = conf.Memory.Task, conf.Memory.Search, conf.Memory.MessageBroker
+		transfer = conf.Memory.Task
+	} else {
+		task := postgresql.NewTask(conf.DB)
+		repo, transfer = task, task
+
+		search = elasticsearch.NewTask(conf.ElasticSearch)
+		if conf.SearchEngine == searchEnginePostgreSQL {
+			search = postgresql.NewSearchableTask(conf.DB)
+		}
+
+		broker = conf.Publisher
+	}
 
-	search := elasticsearch.NewTask(conf.ElasticSearch)
-	msearch := memcached.NewSearchableTask(conf.Memcached, search)
+	mrepo := memcached.NewTask(conf.Cache.Tiered, repo, conf.Logger)
 
-	// XXX mclient := memcached.NewSearchableTask(conf.Memcached, search, conf.Logger)
-	// msgBroker, err := rabbitmq.NewTask(conf.RabbitMQ.Channel)
-	// if err != nil {
-	// 	return nil, fmt.Errorf("rabbitmq.NewTask %w", err)
-	// }
+	// Search generations must be shared by all the instances, that's why they are not cached in-process.
+	generation := memcached.NewSearchGeneration(conf.Cache.Remote, conf.Logger)
 
-	// msgBroker := kafka.NewTask(conf.Kafka.Producer, conf.Kafka.Topic)
+	msearch := memcached.NewSearchableTask(conf.Cache.Tiered, generation, search, conf.Logger)
 
-	msgBroker := redis.NewTask(conf.Redis)
+	msgBroker := memcached.NewTaskMessageBroker(generation, broker)
 
 	svc := service.NewTask(conf.Logger, mrepo, msearch, msgBroker)
 
 	rest.RegisterOpenAPI(router)
 	rest.NewTaskHandler(svc).Register(router)
 
+	// The calendar lists the tasks without caching them, imported tasks are created using the service so their
+	// events are published.
+	rest.NewCalendarHandler(service.NewCalendar(transfer, svc), conf.CalendarToken).Register(router)
+
+	// Imported tasks replace the cached ones, and their events are published the same way the service does.
+	mtransfer := memcached.NewTaskTransfer(conf.Cache.Tiered, transfer, conf.Logger)
+
+	rest.NewTransferHandler(service.NewTransfer(mtransfer, msgBroker)).Register(router)
+
+	// XXX: The "dev" profile doesn't support webhooks nor notifications, "webhook-dispatcher" and "notifier" consume
+	// the events from a message broker.
+	if conf.Memory == nil {
+		rest.NewWebhookHandler(service.NewWebhook(postgresql.NewWebhook(conf.DB))).Register(router)
+		rest.NewNotificationHandler(service.NewNotification(postgresql.NewNotification(conf.DB))).Register(router)
+	}
+
 	//-
 
 	fsys, _ := fs.Sub(content, "static")
 
-
 	router.Handle("/static/*", http.StripPrefix("/static/", http.FileServer(http.FS(fsys))))
 
-	router.GET("/metrics", gin.WrapH(conf.Metrics))
+	router.Handle("/metrics", conf.Metrics)
 
 	//-
 
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api/cmd/internal"
+	internaldomain "github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/elasticsearch"
+	"github.com/MarioCarrion/todo-api/internal/envvar"
+	"github.com/MarioCarrion/todo-api/internal/postgresql"
+)
+
+const pageSize = 500
+
+func main() {
+	var (
+		env            string
+		version        int
+		wait           time.Duration
+		deletePrevious bool
+	)
+
+	flag.StringVar(&env, "env", "", "Environment Variables filename")
+	flag.IntVar(&version, "version", 0, "Version of the index to create, for example 2 creates tasks_v2")
+	flag.DurationVar(&wait, "wait", 10*time.Second, "Time to wait for the indexers to start writing to the new index")
+	flag.BoolVar(&deletePrevious, "delete-previous", false, "Delete the indices previously used after switching")
+	flag.Parse()
+
+	if version < 1 {
+		log.Fatalln("version is required")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(),
+		os.Interrupt,
+		syscall.SIGTERM,
+		syscall.SIGQUIT)
+	defer stop()
+
+	if err := run(ctx, env, version, wait, deletePrevious); err != nil {
+		log.Fatalf("Couldn't run: %s", err)
+	}
+}
+
+// run migrates the Task records to a new versioned index:
+//
+//  1. Creates the new index using the explicit mapping,
+//  2. Points the write alias to it, so the indexers write new events to both indices,
+//  3. Populates it with all the records stored in PostgreSQL,
+//  4. Atomically switches the read alias to it.
+//
+//nolint:cyclop
+func run(ctx context.Context, env string, version int, wait time.Duration, deletePrevious bool) error {
+	logger, err := zap.NewProduction()
+	if err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "zap.NewProduction")
+	}
+
+	defer func() {
+		_ = logger.Sync()
+	}()
+
+	if err := envvar.Load(env); err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "envvar.Load")
+	}
+
+	vault, err := internal.NewVaultProvider()
+	if err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewVaultProvider")
+	}
+
+	conf := envvar.New(vault)
+
+	//-
+
+	pool, err := internal.NewPostgreSQL(conf)
+	if err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewPostgreSQL")
+	}
+
+	defer pool.Close()
+
+	esClient, err := internal.NewElasticSearch(conf)
+	if err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewElasticSearch")
+	}
+
+	//-
+
+	indices := elasticsearch.NewIndices(esClient)
+	name := elasticsearch.IndexName(version)
+
+	previous, _, err := indices.Aliases(ctx)
+	if err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "indices.Aliases")
+	}
+
+	logger.Info("Creating index", zap.String("index", name), zap.Strings("previous", previous))
+
+	if err := indices.Create(ctx, name); err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "indices.Create")
+	}
+
+	if err := indices.StartMigration(ctx, name); err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "indices.StartMigration")
+	}
+
+	select {
+	case <-ctx.Done():
+		return internaldomain.WrapErrorf(ctx.Err(), internaldomain.ErrorCodeUnknown, "context.Done")
+	case <-time.After(wait):
+	}
+
+	//-
+
+	// Tasks are written using their versions, so the ones changed or deleted by the indexer while migrating are not
+	// overwritten by the older copies read here.
+
+	repo := postgresql.NewTask(pool)
+
+	var (
+		lastID string
+		total  int
+	)
+
+	for {
+		tasks, err := repo.ListAfter(ctx, lastID, pageSize)
+		if err != nil {
+			return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "repo.ListAfter")
+		}
+
+		if err := indices.Bulk(ctx, name, tasks, nil); err != nil {
+			return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "indices.Bulk")
+		}
+
+		total += len(tasks)
+
+		logger.Info("Indexed", zap.Int("total", total))
+
+		if len(tasks) < pageSize {
+			break
+		}
+
+		lastID = tasks[len(tasks)-1].ID
+	}
+
+	//-
+
+	if err := indices.Switch(ctx, name); err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "indices.Switch")
+	}
+
+	logger.Info("Switched alias", zap.String("alias", elasticsearch.ReadAlias), zap.String("index", name))
+
+	if !deletePrevious {
+		return nil
+	}
+
+	for _, index := range previous {
+		if index == name {
+			continue
+		}
+
+		if err := indices.Delete(ctx, index); err != nil {
+			return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "indices.Delete")
+		}
+
+		logger.Info("Deleted index", zap.String("index", index))
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api/cmd/internal"
+	internaldomain "github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/elasticsearch"
+	"github.com/MarioCarrion/todo-api/internal/envvar"
+	"github.com/MarioCarrion/todo-api/internal/postgresql"
+	"github.com/MarioCarrion/todo-api/internal/service"
+)
+
+func main() {
+	var (
+		env    string
+		verify bool
+		size   int
+	)
+
+	flag.StringVar(&env, "env", "", "Environment Variables filename")
+	flag.BoolVar(&verify, "verify", false, "Only report the drift between PostgreSQL and Elasticsearch, nothing is changed")
+	flag.IntVar(&size, "size", 500, "Number of records read and indexed per batch")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(),
+		os.Interrupt,
+		syscall.SIGTERM,
+		syscall.SIGQUIT)
+	defer stop()
+
+	res, err := run(ctx, env, verify, int32(size))
+	if err != nil {
+		log.Fatalf("Couldn't run: %s", err)
+	}
+
+	fmt.Printf("Total: %d, Missing: %d, Stale: %d, Orphaned: %d\n",
+		res.Total, len(res.Missing), len(res.Stale), len(res.Orphaned))
+
+	if !verify {
+		return
+	}
+
+	for _, val := range []struct {
+		name string
+		ids  []string
+	}{
+		{"missing", res.Missing},
+		{"stale", res.Stale},
+		{"orphaned", res.Orphaned},
+	} {
+		for _, id := range val.ids {
+			fmt.Printf("%s\t%s\n", val.name, id)
+		}
+	}
+}
+
+func run(ctx context.Context, env string, verify bool, size int32) (service.ReindexResults, error) {
+	logger, err := zap.NewProduction()
+	if err != nil {
+		return service.ReindexResults{}, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "zap.NewProduction")
+	}
+
+	defer func() {
+		_ = logger.Sync()
+	}()
+
+	if err := envvar.Load(env); err != nil {
+		return service.ReindexResults{}, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "envvar.Load")
+	}
+
+	vault, err := internal.NewVaultProvider()
+	if err != nil {
+		return service.ReindexResults{}, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewVaultProvider")
+	}
+
+	conf := envvar.New(vault)
+
+	//-
+
+	pool, err := internal.NewPostgreSQL(conf)
+	if err != nil {
+		return service.ReindexResults{}, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewPostgreSQL")
+	}
+
+	defer pool.Close()
+
+	esClient, err := internal.NewElasticSearch(conf)
+	if err != nil {
+		return service.ReindexResults{}, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewElasticSearch")
+	}
+
+	//-
+
+	logger.Info("Reindexing", zap.Bool("verify", verify), zap.Int32("size", size))
+
+	svc := service.NewReindex(postgresql.NewTask(pool), elasticsearch.NewTask(esClient), size)
+
+	res, err := svc.Run(ctx, verify)
+	if err != nil {
+		return service.ReindexResults{}, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "svc.Run")
+	}
+
+	return res, nil
+}
@@ -0,0 +1,166 @@
+// task-events reads the Task events kept in the Kafka topic, it's meant for debugging indexing drift:
+//
+//	task-events tail    -env env.example [-type updated] [-task <id>] [-from 1h]
+//	task-events replay  -env env.example -index tasks_replay [-from 2024-01-01T00:00:00Z] [-to 1h]
+//	task-events rebuild -env env.example -task <id> [-at 2024-01-01T00:00:00Z] [-history]
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/MarioCarrion/todo-api/cmd/internal"
+	internaldomain "github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/envvar"
+	"github.com/MarioCarrion/todo-api/internal/events"
+	"github.com/MarioCarrion/todo-api/internal/kafka"
+)
+
+const usage = `Usage: task-events <command> [flags]
+
+Commands:
+  tail     Prints the events published to the topic, optionally filtered by type or task
+  replay   Indexes the events in a time or offset range into a new Elasticsearch index
+  rebuild  Prints the state of a task as of a point in time, by folding its events
+
+Use "task-events <command> -help" for the flags of each command.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	commands := map[string]func(ctx context.Context, args []string) error{
+		"tail":    tail,
+		"replay":  replay,
+		"rebuild": rebuild,
+	}
+
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(),
+		os.Interrupt,
+		syscall.SIGTERM,
+		syscall.SIGQUIT)
+	defer stop()
+
+	if err := cmd(ctx, os.Args[2:]); err != nil {
+		log.Fatalf("Couldn't run %s: %s", os.Args[1], err)
+	}
+}
+
+// newConfiguration loads the environment variables defined in env.
+func newConfiguration(env string) (*envvar.Configuration, error) {
+	if err := envvar.Load(env); err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "envvar.Load")
+	}
+
+	vault, err := internal.NewVaultProvider()
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewVaultProvider")
+	}
+
+	return envvar.New(vault), nil
+}
+
+// newReader instantiates the reader of the topic, using a unique consumer group so it never interferes with the
+// indexers; the returned function closes the consumer.
+func newReader(conf *envvar.Configuration) (*kafka.Reader, func(), error) {
+	consumer, err := internal.NewKafkaReader(conf, "task-events-"+uuid.NewString())
+	if err != nil {
+		return nil, nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewKafkaReader")
+	}
+
+	return kafka.NewReader(consumer.Consumer, consumer.Topic), func() { _ = consumer.Consumer.Close() }, nil
+}
+
+// parseTime parses RFC 3339 times, and durations relative to now: "1h" is one hour ago.
+func parseTime(val string) (time.Time, error) {
+	if val == "" {
+		return time.Time{}, nil
+	}
+
+	if d, err := time.ParseDuration(val); err == nil {
+		return time.Now().Add(-d), nil
+	}
+
+	t, err := time.Parse(time.RFC3339, val)
+	if err != nil {
+		return time.Time{}, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeInvalidArgument, "time.Parse")
+	}
+
+	return t, nil
+}
+
+// parseTypes parses the comma separated list of event types, the "tasks.event." prefix is optional.
+func parseTypes(val string) map[string]struct{} {
+	if val == "" {
+		return nil
+	}
+
+	res := make(map[string]struct{})
+
+	for _, typ := range strings.Split(val, ",") {
+		typ = strings.TrimSpace(typ)
+		if !strings.HasPrefix(typ, "tasks.event.") {
+			typ = "tasks.event." + typ
+		}
+
+		res[typ] = struct{}{}
+	}
+
+	return res
+}
+
+// newFlagSet instantiates the flags of a command, including "-env".
+func newFlagSet(name string, env *string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.StringVar(env, "env", "", "Environment Variables filename")
+
+	return fs
+}
+
+// printRecord prints the position of the record followed by its event, indented, using the CloudEvents JSON format.
+func printRecord(w io.Writer, record kafka.Record) error {
+	if record.Err != nil {
+		_, err := fmt.Fprintf(w, "%s\tpartition=%d offset=%d\tinvalid: %s\n\n",
+			record.Timestamp.Format(time.RFC3339), record.Partition, record.Offset, record.Err)
+
+		return err //nolint: wrapcheck
+	}
+
+	b, err := events.JSON.Marshal(record.Event)
+	if err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "JSON.Marshal")
+	}
+
+	var buf bytes.Buffer
+
+	if err := json.Indent(&buf, b, "", "  "); err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "json.Indent")
+	}
+
+	_, err = fmt.Fprintf(w, "%s\tpartition=%d offset=%d\t%s\t%s\n%s\n\n",
+		record.Timestamp.Format(time.RFC3339), record.Partition, record.Offset, record.Event.Type,
+		record.Event.Task.ID, buf.String())
+
+	return err //nolint: wrapcheck
+}
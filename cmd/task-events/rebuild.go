@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	internaldomain "github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/events"
+	"github.com/MarioCarrion/todo-api/internal/kafka"
+)
+
+// rebuild prints the state of a task as of a point in time by folding its events, the whole topic is read
+// because events published before messages were keyed by task ID may be in any partition.
+func rebuild(ctx context.Context, args []string) error {
+	var (
+		env     string
+		taskID  string
+		at      string
+		history bool
+	)
+
+	fs := newFlagSet("rebuild", &env)
+	fs.StringVar(&taskID, "task", "", "Task ID to rebuild")
+	fs.StringVar(&at, "at", "", `Point in time, RFC 3339 or relative like "1h"; now by default`)
+	fs.BoolVar(&history, "history", false, "Print the events folded")
+	_ = fs.Parse(args)
+
+	if taskID == "" {
+		return internaldomain.NewErrorf(internaldomain.ErrorCodeInvalidArgument, "task is required")
+	}
+
+	opts := kafka.NewReadOptions()
+
+	var err error
+
+	if opts.To, err = parseTime(at); err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeInvalidArgument, "parseTime at")
+	}
+
+	conf, err := newConfiguration(env)
+	if err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "newConfiguration")
+	}
+
+	reader, closeFn, err := newReader(conf)
+	if err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "newReader")
+	}
+
+	defer closeFn()
+
+	//-
+
+	var records []kafka.Record
+
+	if err := reader.Read(ctx, opts, func(record kafka.Record) error {
+		if record.Err == nil && record.Event.Task.ID == taskID {
+			records = append(records, record)
+		}
+
+		return nil
+	}); err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "reader.Read")
+	}
+
+	// Events are folded in the order they happened, version 0 events don't include their time.
+	sort.SliceStable(records, func(i, j int) bool {
+		return eventTime(records[i]).Before(eventTime(records[j]))
+	})
+
+	evts := make([]events.Event, len(records))
+
+	for i, record := range records {
+		evts[i] = record.Event
+
+		if history {
+			if err := printRecord(os.Stdout, record); err != nil {
+				return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "printRecord")
+			}
+		}
+	}
+
+	state := events.Fold(evts)
+
+	if state.Events == 0 {
+		return internaldomain.NewErrorf(internaldomain.ErrorCodeNotFound, "no events found for task %q", taskID)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(state); err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "json.Encode")
+	}
+
+	if !state.Exists {
+		fmt.Println("The task was deleted.")
+	}
+
+	return nil
+}
+
+func eventTime(record kafka.Record) time.Time {
+	if record.Event.Time.IsZero() {
+		return record.Timestamp
+	}
+
+	return record.Event.Time
+}
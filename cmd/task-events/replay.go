@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api/cmd/internal"
+	internaldomain "github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/elasticsearch"
+	"github.com/MarioCarrion/todo-api/internal/events"
+	"github.com/MarioCarrion/todo-api/internal/kafka"
+)
+
+// replay indexes the events in a time or offset range into a new index, it's meant to be compared with the
+// index used by the indexers; the aliases are not changed.
+//
+// nolint: cyclop
+func replay(ctx context.Context, args []string) error {
+	var (
+		env        string
+		index      string
+		from, to   string
+		fromOffset int64
+		toOffset   int64
+		partition  int
+		batchSize  int
+	)
+
+	fs := newFlagSet("replay", &env)
+	fs.StringVar(&index, "index", "", "Name of the new index, it must not exist")
+	fs.StringVar(&from, "from", "", `Replay events published after, RFC 3339 or relative like "1h"; from the beginning by default`)
+	fs.StringVar(&to, "to", "", `Replay events published before, RFC 3339 or relative like "1h"; until the end by default`)
+	fs.Int64Var(&fromOffset, "from-offset", -1, "First offset to replay, used when -from is not set")
+	fs.Int64Var(&toOffset, "to-offset", -1, "Last offset to replay")
+	fs.IntVar(&partition, "partition", -1, "Partition to replay, all of them by default")
+	fs.IntVar(&batchSize, "batch-size", 500, "Number of tasks indexed per batch")
+	_ = fs.Parse(args)
+
+	if index == "" {
+		return internaldomain.NewErrorf(internaldomain.ErrorCodeInvalidArgument, "index is required")
+	}
+
+	opts := kafka.NewReadOptions()
+	opts.Partition = int32(partition) //nolint: gosec
+	opts.FromOffset = fromOffset
+	opts.ToOffset = toOffset
+
+	var err error
+
+	if opts.From, err = parseTime(from); err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeInvalidArgument, "parseTime from")
+	}
+
+	if opts.To, err = parseTime(to); err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeInvalidArgument, "parseTime to")
+	}
+
+	logger, err := zap.NewProduction()
+	if err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "zap.NewProduction")
+	}
+
+	defer func() {
+		_ = logger.Sync()
+	}()
+
+	conf, err := newConfiguration(env)
+	if err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "newConfiguration")
+	}
+
+	esClient, err := internal.NewElasticSearch(conf)
+	if err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewElasticSearch")
+	}
+
+	reader, closeFn, err := newReader(conf)
+	if err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "newReader")
+	}
+
+	defer closeFn()
+
+	//-
+
+	indices := elasticsearch.NewIndices(esClient)
+
+	exists, err := indices.Exists(ctx, index)
+	if err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "indices.Exists")
+	}
+
+	if exists {
+		return internaldomain.NewErrorf(internaldomain.ErrorCodeInvalidArgument, "index %q already exists", index)
+	}
+
+	if err := indices.Create(ctx, index); err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "indices.Create")
+	}
+
+	logger.Info("Replaying", zap.String("index", index))
+
+	batch := newReplayBatch(indices, index)
+
+	var total, invalid int
+
+	if err := reader.Read(ctx, opts, func(record kafka.Record) error {
+		total++
+
+		if record.Err != nil {
+			invalid++
+
+			logger.Info("Skipping invalid event", zap.Int32("partition", record.Partition),
+				zap.Int64("offset", record.Offset), zap.Error(record.Err))
+
+			return nil
+		}
+
+		batch.Apply(record.Event)
+
+		if batch.Len() < batchSize {
+			return nil
+		}
+
+		logger.Info("Indexing batch", zap.Int("total", total))
+
+		return batch.Flush(ctx)
+	}); err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "reader.Read")
+	}
+
+	if err := batch.Flush(ctx); err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "batch.Flush")
+	}
+
+	fmt.Printf("Index: %s, Events: %d, Invalid: %d\n", index, total, invalid)
+
+	return nil
+}
+
+// replayBatch coalesces the events for the same task, only the last state of each task in the batch is written.
+type replayBatch struct {
+	indices *elasticsearch.Indices
+	index   string
+	states  map[string]events.State
+}
+
+func newReplayBatch(indices *elasticsearch.Indices, index string) *replayBatch {
+	return &replayBatch{
+		indices: indices,
+		index:   index,
+		states:  make(map[string]events.State),
+	}
+}
+
+func (b *replayBatch) Apply(evt events.Event) {
+	b.states[evt.Task.ID] = b.states[evt.Task.ID].Apply(evt)
+}
+
+func (b *replayBatch) Len() int {
+	return len(b.states)
+}
+
+func (b *replayBatch) Flush(ctx context.Context) error {
+	var tasks, deleted []internaldomain.Task
+
+	for _, state := range b.states {
+		if state.Exists {
+			tasks = append(tasks, state.Task)
+		} else {
+			deleted = append(deleted, state.Task)
+		}
+	}
+
+	if err := b.indices.Bulk(ctx, b.index, tasks, deleted); err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "indices.Bulk")
+	}
+
+	b.states = make(map[string]events.State)
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	internaldomain "github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/kafka"
+)
+
+// tail prints the events published to the topic, starting with the ones published after "-from", until
+// interrupted.
+func tail(ctx context.Context, args []string) error {
+	var (
+		env       string
+		types     string
+		taskID    string
+		from      string
+		partition int
+	)
+
+	fs := newFlagSet("tail", &env)
+	fs.StringVar(&types, "type", "", `Comma separated event types to print, for example "created,deleted"`)
+	fs.StringVar(&taskID, "task", "", "Task ID to print the events of")
+	fs.StringVar(&from, "from", "", `Print events published after, RFC 3339 or relative like "1h"; only new ones by default`)
+	fs.IntVar(&partition, "partition", -1, "Partition to read, all of them by default")
+	_ = fs.Parse(args)
+
+	conf, err := newConfiguration(env)
+	if err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "newConfiguration")
+	}
+
+	reader, closeFn, err := newReader(conf)
+	if err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "newReader")
+	}
+
+	defer closeFn()
+
+	opts := kafka.NewReadOptions()
+	opts.Partition = int32(partition) //nolint: gosec
+	opts.Follow = true
+
+	if opts.From, err = parseTime(from); err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeInvalidArgument, "parseTime from")
+	}
+
+	filter := parseTypes(types)
+
+	if err := reader.Read(ctx, opts, func(record kafka.Record) error {
+		if record.Err != nil {
+			// Invalid events can't match any of the filters.
+			if filter != nil || taskID != "" {
+				return nil
+			}
+
+			return printRecord(os.Stdout, record)
+		}
+
+		if _, ok := filter[record.Event.Type]; filter != nil && !ok {
+			return nil
+		}
+
+		if taskID != "" && record.Event.Task.ID != taskID {
+			return nil
+		}
+
+		return printRecord(os.Stdout, record)
+	}); err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "reader.Read")
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api/cmd/internal"
+	internaldomain "github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/envvar"
+	"github.com/MarioCarrion/todo-api/internal/indexer"
+	"github.com/MarioCarrion/todo-api/internal/postgresql"
+	"github.com/MarioCarrion/todo-api/internal/webhook"
+)
+
+func main() {
+	var (
+		env      string
+		address  string
+		timeout  time.Duration
+		opts     = internal.SourceOptions{Name: "webhook-dispatcher"}
+		dispOpts webhook.Options
+	)
+
+	hostname, _ := os.Hostname()
+
+	flag.StringVar(&env, "env", "", "Environment Variables filename")
+	flag.StringVar(&address, "address", ":9236", "HTTP Server Address, serves /health and /metrics")
+	flag.DurationVar(&timeout, "timeout", 10*time.Second, "Timeout of each request sent to a webhook")
+	flag.IntVar(&dispOpts.MaxAttempts, "attempts", 5, "Number of times an event is sent to a webhook before giving up")
+	flag.DurationVar(&dispOpts.Backoff, "backoff", time.Second, "Delay before sending an event again, doubled per attempt")
+	flag.DurationVar(&dispOpts.MaxBackoff, "max-backoff", 30*time.Second, "Maximum delay before sending an event again")
+	flag.Int64Var(&dispOpts.BreakerFailures, "breaker-failures", 5, "Consecutive failures opening the circuit breaker of a webhook")
+	flag.DurationVar(&dispOpts.BreakerOpenTimeout, "breaker-timeout", time.Minute, "Time the circuit breaker of a webhook is open")
+	flag.IntVar(&dispOpts.QueueSize, "queue-size", 100, "Maximum number of events waiting to be sent to each webhook")
+	flag.DurationVar(&dispOpts.WebhooksTTL, "webhooks-ttl", 30*time.Second, "Time the webhooks are cached")
+	flag.IntVar(&opts.MaxAttempts, "max-attempts", 5, "RabbitMQ, Redis and NATS: Number of attempts before dead-lettering a message")
+	flag.DurationVar(&opts.RetryBackoff, "retry-backoff", time.Second,
+		"RabbitMQ and NATS: Delay before retrying a message, doubled per attempt in RabbitMQ")
+	flag.StringVar(&opts.Group, "group", "webhook-dispatcher", "Redis: Consumer group, shared by all the replicas")
+	flag.StringVar(&opts.Consumer, "consumer", hostname, "Redis: Consumer name, must be unique for each replica")
+	flag.DurationVar(&opts.MinIdle, "min-idle", 5*time.Minute, "Redis: Time before claiming the events pending in other consumers")
+	flag.DurationVar(&opts.AckWait, "ack-wait", 5*time.Minute, "NATS: Time to wait for an acknowledgement before redelivering")
+	flag.Parse()
+
+	// Events are acknowledged after being sent, prefetching less than a queue would wait for the slowest webhook.
+	opts.BatchSize = dispOpts.QueueSize
+
+	errC, err := run(env, address, timeout, opts, dispOpts)
+	if err != nil {
+		log.Fatalf("Couldn't run: %s", err)
+	}
+
+	if err := <-errC; err != nil {
+		log.Fatalf("Error while running: %s", err)
+	}
+}
+
+func run(env, address string, timeout time.Duration,
+	opts internal.SourceOptions, dispOpts webhook.Options,
+) (<-chan error, error) {
+	logger, err := zap.NewProduction()
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "zap.NewProduction")
+	}
+
+	if err := envvar.Load(env); err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "envvar.Load")
+	}
+
+	vault, err := internal.NewVaultProvider()
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewVaultProvider")
+	}
+
+	conf := envvar.New(vault)
+
+	//-
+
+	name, err := conf.Get("WEBHOOK_SOURCE")
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "conf.Get WEBHOOK_SOURCE")
+	}
+
+	if name == "" {
+		name = internal.SourceRedis
+	}
+
+	source, closeSource, err := internal.NewSource(conf, logger, name, opts)
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewSource")
+	}
+
+	pool, err := internal.NewPostgreSQL(conf)
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewPostgreSQL")
+	}
+
+	//-
+
+	promExporter, err := internal.NewOTExporter(conf, "webhook-dispatcher")
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewOTExporter")
+	}
+
+	//-
+
+	client := &http.Client{
+		Transport: otelhttp.NewTransport(webhook.NewTransport()),
+		Timeout:   timeout,
+	}
+
+	dispatcher := webhook.NewDispatcher(logger, source, postgresql.NewWebhook(pool), client, dispOpts)
+
+	mux := http.NewServeMux()
+	mux.Handle("/health", indexer.HealthHandler(dispatcher))
+	mux.Handle("/metrics", promExporter)
+
+	srv := &http.Server{
+		Addr:              address,
+		Handler:           mux,
+		ReadHeaderTimeout: time.Second,
+	}
+
+	errC := make(chan error, 1)
+
+	ctx, stop := signal.NotifyContext(context.Background(),
+		os.Interrupt,
+		syscall.SIGTERM,
+		syscall.SIGQUIT)
+
+	go func() {
+		<-ctx.Done()
+
+		logger.Info("Shutdown signal received")
+
+		ctxTimeout, cancel := context.WithTimeout(context.Background(), timeout+5*time.Second)
+
+		defer func() {
+			_ = logger.Sync()
+
+			closeSource()
+			pool.Close()
+			stop()
+			cancel()
+			close(errC)
+		}()
+
+		if err := dispatcher.Shutdown(ctxTimeout); err != nil { //nolint: contextcheck
+			errC <- err
+		}
+
+		if err := srv.Shutdown(ctxTimeout); err != nil { //nolint: contextcheck
+			errC <- err
+		}
+
+		logger.Info("Shutdown completed")
+	}()
+
+	go func() {
+		logger.Info("Listening and serving", zap.String("source", name), zap.String("address", address))
+
+		if err := dispatcher.ListenAndServe(); err != nil {
+			errC <- err
+		}
+	}()
+
+	go func() {
+		// "ListenAndServe always returns a non-nil error. After Shutdown or Close, the returned error is
+		// ErrServerClosed."
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errC <- err
+		}
+	}()
+
+	return errC, nil
+}
@@ -0,0 +1,11 @@
+ALTER TABLE tasks
+  ADD COLUMN description_tsv TSVECTOR GENERATED ALWAYS AS (to_tsvector('english', description)) STORED;
+
+CREATE INDEX tasks_description_tsv_idx ON tasks USING GIN (description_tsv);
+
+---- create above / drop below ----
+
+DROP INDEX tasks_description_tsv_idx;
+
+ALTER TABLE tasks
+  DROP COLUMN description_tsv;
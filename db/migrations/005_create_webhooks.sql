@@ -0,0 +1,27 @@
+CREATE TABLE webhooks (
+  id          UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+  url         VARCHAR NOT NULL,
+  event_types VARCHAR[] NOT NULL DEFAULT '{}',
+  secret      VARCHAR NOT NULL,
+  created_at  TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (NOW() AT TIME ZONE 'UTC')
+);
+
+CREATE TABLE webhook_deliveries (
+  id          UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+  webhook_id  UUID NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+  event_id    VARCHAR NOT NULL,
+  event_type  VARCHAR NOT NULL,
+  attempt     INTEGER NOT NULL,
+  status_code INTEGER NOT NULL DEFAULT 0,
+  error       VARCHAR NOT NULL DEFAULT '',
+  duration_ms BIGINT NOT NULL,
+  created_at  TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (NOW() AT TIME ZONE 'UTC')
+);
+
+CREATE INDEX webhook_deliveries_webhook_id_created_at_idx ON webhook_deliveries (webhook_id, created_at DESC);
+
+---- create above / drop below ----
+
+DROP TABLE webhook_deliveries;
+
+DROP TABLE webhooks;
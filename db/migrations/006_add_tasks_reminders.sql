@@ -0,0 +1,22 @@
+ALTER TABLE tasks
+  ADD COLUMN reminders BIGINT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX tasks_due_date_idx ON tasks (due_date) WHERE NOT done;
+
+CREATE TABLE task_reminders (
+  task_id    UUID NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+  type       VARCHAR NOT NULL,
+  due_date   TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+  reminder   BIGINT NOT NULL,
+  created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (NOW() AT TIME ZONE 'UTC'),
+  PRIMARY KEY (task_id, type, due_date, reminder)
+);
+
+---- create above / drop below ----
+
+DROP TABLE task_reminders;
+
+DROP INDEX tasks_due_date_idx;
+
+ALTER TABLE tasks
+  DROP COLUMN reminders;
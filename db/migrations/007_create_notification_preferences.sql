@@ -0,0 +1,23 @@
+CREATE TABLE notification_preferences (
+  email       VARCHAR PRIMARY KEY,
+  event_types VARCHAR[] NOT NULL DEFAULT '{}',
+  digest      BOOLEAN NOT NULL DEFAULT FALSE,
+  created_at  TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (NOW() AT TIME ZONE 'UTC'),
+  updated_at  TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (NOW() AT TIME ZONE 'UTC')
+);
+
+CREATE TABLE notification_digests (
+  email      VARCHAR NOT NULL REFERENCES notification_preferences (email) ON DELETE CASCADE,
+  event_id   VARCHAR NOT NULL,
+  event_type VARCHAR NOT NULL,
+  event_time TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+  task       JSONB NOT NULL,
+  created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (NOW() AT TIME ZONE 'UTC'),
+  PRIMARY KEY (email, event_id)
+);
+
+---- create above / drop below ----
+
+DROP TABLE notification_digests;
+
+DROP TABLE notification_preferences;
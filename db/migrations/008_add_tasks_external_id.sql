@@ -0,0 +1,7 @@
+ALTER TABLE tasks
+  ADD COLUMN external_id VARCHAR UNIQUE;
+
+---- create above / drop below ----
+
+ALTER TABLE tasks
+  DROP COLUMN external_id;
@@ -0,0 +1,160 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	esv7 "github.com/elastic/go-elasticsearch/v7"
+	esv7api "github.com/elastic/go-elasticsearch/v7/esapi"
+
+	"github.com/MarioCarrion/todo-api/internal"
+)
+
+// Bulk indexes and deletes tasks using one "_bulk" request per index being written, only the ID and Version of
+// the deleted tasks are used.
+//
+// Tasks are written using external versioning: writes older than, or as old as, the indexed document are ignored,
+// so writing the same events more than once, or out of order, is safe. Deleted tasks are kept as tombstones for
+// the same reason, those are excluded when searching.
+func (t *Task) Bulk(ctx context.Context, tasks []internal.Task, deleted []internal.Task) error {
+	defer newOTELSpan(ctx, "Task.Bulk").End()
+
+	//-
+
+	if len(tasks) == 0 && len(deleted) == 0 {
+		return nil
+	}
+
+	indices, err := t.writeIndices(ctx)
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "writeIndices")
+	}
+
+	for _, index := range indices {
+		if err := bulk(ctx, t.client, index, tasks, deleted); err != nil {
+			return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "bulk")
+		}
+	}
+
+	return nil
+}
+
+// BulkError indicates some of the tasks were not written, the rest of them were written successfully.
+type BulkError struct {
+	// IDs of the tasks that were not written.
+	IDs []string
+}
+
+// Error ...
+func (e *BulkError) Error() string {
+	return fmt.Sprintf("%d items failed", len(e.IDs))
+}
+
+//nolint:tagliatelle
+type bulkAction struct {
+	ID          string `json:"_id"`
+	Version     int64  `json:"version,omitempty"`
+	VersionType string `json:"version_type,omitempty"`
+}
+
+//nolint:tagliatelle
+type bulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []map[string]struct {
+		ID     string `json:"_id"`
+		Status int    `json:"status"`
+	} `json:"items"`
+}
+
+func bulk(ctx context.Context, client *esv7.Client, index string, tasks []internal.Task, deleted []internal.Task) error {
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+
+	write := func(task internal.Task, doc indexedTask) error {
+		if err := enc.Encode(map[string]bulkAction{"index": newBulkAction(task)}); err != nil {
+			return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "json.Encoder.Encode")
+		}
+
+		if err := enc.Encode(doc); err != nil {
+			return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "json.Encoder.Encode")
+		}
+
+		return nil
+	}
+
+	for _, task := range tasks {
+		if err := write(task, newIndexedTask(task)); err != nil {
+			return err
+		}
+	}
+
+	for _, task := range deleted {
+		if err := write(task, newTombstone(task.ID)); err != nil {
+			return err
+		}
+	}
+
+	req := esv7api.BulkRequest{
+		Index: index,
+		Body:  &buf,
+	}
+
+	resp, err := req.Do(ctx, client)
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "BulkRequest.Do")
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		return internal.NewErrorf(internal.ErrorCodeUnknown, "BulkRequest.Do %d", resp.StatusCode)
+	}
+
+	var res bulkResponse
+
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "json.NewDecoder.Decode")
+	}
+
+	if !res.Errors {
+		return nil
+	}
+
+	var failed []string
+
+	for _, item := range res.Items {
+		for _, val := range item {
+			// The indexed document is newer, or as new as, the one being written.
+			if val.Status == http.StatusConflict {
+				continue
+			}
+
+			if val.Status >= http.StatusBadRequest {
+				failed = append(failed, val.ID)
+			}
+		}
+	}
+
+	if len(failed) > 0 {
+		return internal.WrapErrorf(&BulkError{IDs: failed}, internal.ErrorCodeUnknown, "BulkRequest.Do")
+	}
+
+	return nil
+}
+
+// newBulkAction uses external versioning unless the task has no version, which is the case for events published
+// before versions were introduced.
+func newBulkAction(task internal.Task) bulkAction {
+	if task.Version == 0 {
+		return bulkAction{ID: task.ID}
+	}
+
+	return bulkAction{
+		ID:          task.ID,
+		Version:     task.Version,
+		VersionType: "external",
+	}
+}
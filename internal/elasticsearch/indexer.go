@@ -0,0 +1,276 @@
+package elasticsearch
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api/internal"
+)
+
+// Indexer buffers Task events and writes them in batches using the "_bulk" API, the batch is written when it
+// reaches the configured size or after the configured interval, whatever happens first.
+//
+// Events for the same task are coalesced, only the one with the highest version is written; events without
+// version, published before versions were introduced, replace the buffered one instead. Events are acknowledged,
+// in the order they were received, only after the batch including them is written successfully; failed batches
+// are kept and retried on the next flush. When all the events in a failed batch include a nack function, the batch is dropped
+// instead: events for the tasks that were not written are nacked and the rest of them are acknowledged, retrying
+// them is up to the caller.
+//
+// Each event has a span, child of the one in the context received by Index or Delete, that ends once the batch
+// including it is written; the span writing the batch links to all of them.
+type Indexer struct {
+	store    BulkStore
+	logger   *zap.Logger
+	size     int
+	interval time.Duration
+
+	mu      sync.Mutex
+	pending map[string]pendingTask
+	acks    []pendingAck
+	spans   []trace.Span
+
+	closeC chan struct{}
+	doneC  chan struct{}
+}
+
+type pendingTask struct {
+	task    internal.Task
+	deleted bool
+}
+
+type pendingAck struct {
+	id   string // empty for skipped events
+	ack  func()
+	nack func(err error)
+}
+
+//go:generate counterfeiter -generate
+
+//counterfeiter:generate -o elasticsearchtesting/bulk_store.gen.go . BulkStore
+
+// BulkStore defines the datastore used by the Indexer for writing tasks in batches, like Task.
+type BulkStore interface {
+	Bulk(ctx context.Context, tasks []internal.Task, deleted []internal.Task) error
+}
+
+// NewIndexer instantiates the Indexer.
+func NewIndexer(logger *zap.Logger, store BulkStore, size int, interval time.Duration) *Indexer {
+	return &Indexer{
+		store:    store,
+		logger:   logger,
+		size:     size,
+		interval: interval,
+		pending:  make(map[string]pendingTask),
+		closeC:   make(chan struct{}),
+		doneC:    make(chan struct{}),
+	}
+}
+
+// Start flushes the pending events periodically until Close is called.
+func (i *Indexer) Start() {
+	go func() {
+		ticker := time.NewTicker(i.interval)
+
+		defer func() {
+			ticker.Stop()
+			close(i.doneC)
+		}()
+
+		for {
+			select {
+			case <-i.closeC:
+				return
+			case <-ticker.C:
+				if err := i.Flush(context.Background()); err != nil {
+					i.logger.Error("Couldn't flush", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
+
+// Close stops flushing periodically and flushes the pending events.
+func (i *Indexer) Close(ctx context.Context) error {
+	close(i.closeC)
+
+	select {
+	case <-ctx.Done():
+		return internal.WrapErrorf(ctx.Err(), internal.ErrorCodeUnknown, "context.Done")
+	case <-i.doneC:
+	}
+
+	return i.Flush(ctx)
+}
+
+// Index buffers a created or updated task, ack is called after it's written and nack when writing it failed;
+// both can be nil.
+func (i *Indexer) Index(ctx context.Context, task internal.Task, ack func(), nack func(err error)) error {
+	return i.add(ctx, "Indexer.Index", pendingTask{task: task}, ack, nack)
+}
+
+// Delete buffers a deleted task, ack is called after it's written and nack when writing it failed; both can be
+// nil.
+func (i *Indexer) Delete(ctx context.Context, id string, version int64, ack func(), nack func(err error)) error {
+	return i.add(ctx, "Indexer.Delete", pendingTask{task: internal.Task{ID: id, Version: version}, deleted: true},
+		ack, nack)
+}
+
+// Skip buffers the acknowledgement of an event that is not indexed, for example an invalid one, so it's
+// acknowledged in order with the rest of the events.
+func (i *Indexer) Skip(ack func()) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.acks = append(i.acks, pendingAck{ack: ack})
+}
+
+// Flush writes the pending events.
+func (i *Indexer) Flush(ctx context.Context) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if len(i.pending) == 0 && len(i.acks) == 0 {
+		return nil
+	}
+
+	links := make([]trace.Link, len(i.spans))
+
+	for j, span := range i.spans {
+		links[j] = trace.Link{SpanContext: span.SpanContext()}
+	}
+
+	ctx, span := otel.Tracer(otelName).Start(ctx, "Indexer.Flush", trace.WithLinks(links...))
+	defer span.End()
+
+	span.SetAttributes(semconv.DBSystemElasticsearch)
+
+	//-
+
+	tasks := make([]internal.Task, 0, len(i.pending))
+	deleted := make([]internal.Task, 0)
+
+	for _, pending := range i.pending {
+		if pending.deleted {
+			deleted = append(deleted, pending.task)
+
+			continue
+		}
+
+		tasks = append(tasks, pending.task)
+	}
+
+	if err := i.store.Bulk(ctx, tasks, deleted); err != nil {
+		if i.nackable() {
+			i.nack(err)
+		}
+
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "store.Bulk")
+	}
+
+	for _, ack := range i.acks {
+		if ack.ack != nil {
+			ack.ack()
+		}
+	}
+
+	i.logger.Info("Flushed", zap.Int("indexed", len(tasks)), zap.Int("deleted", len(deleted)),
+		zap.Int("events", len(i.acks)))
+
+	i.reset()
+
+	return nil
+}
+
+// nackable indicates whether all the buffered events can be nacked, it must be called while holding the lock.
+func (i *Indexer) nackable() bool {
+	for _, ack := range i.acks {
+		if ack.id != "" && ack.nack == nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// nack nacks the events for the tasks that were not written, all of them unless err is a *BulkError, and
+// acknowledges the rest; it must be called while holding the lock.
+func (i *Indexer) nack(err error) {
+	var (
+		berr   *BulkError
+		failed map[string]struct{}
+	)
+
+	if errors.As(err, &berr) {
+		failed = make(map[string]struct{}, len(berr.IDs))
+
+		for _, id := range berr.IDs {
+			failed[id] = struct{}{}
+		}
+	}
+
+	for _, ack := range i.acks {
+		_, ok := failed[ack.id]
+
+		switch {
+		case ack.id != "" && (failed == nil || ok):
+			ack.nack(err)
+		case ack.ack != nil:
+			ack.ack()
+		}
+	}
+
+	i.logger.Info("Nacked", zap.Int("events", len(i.acks)), zap.Error(err))
+
+	i.reset()
+}
+
+// reset ends the spans of the written events and clears the batch, it must be called while holding the lock.
+func (i *Indexer) reset() {
+	for _, span := range i.spans {
+		span.End()
+	}
+
+	i.pending = make(map[string]pendingTask)
+	i.acks = nil
+	i.spans = nil
+}
+
+func (i *Indexer) add(ctx context.Context, spanName string, pending pendingTask, ack func(), nack func(err error)) error {
+	_, span := otel.Tracer(otelName).Start(ctx, spanName, trace.WithSpanKind(trace.SpanKindConsumer))
+
+	id := pending.task.ID
+
+	i.mu.Lock()
+
+	// Outdated events are still acknowledged, in order, when the batch is written.
+	if prev, ok := i.pending[id]; !ok || pending.task.Version == 0 || pending.task.Version > prev.task.Version {
+		i.pending[id] = pending
+	}
+
+	i.spans = append(i.spans, span)
+
+	i.acks = append(i.acks, pendingAck{id: id, ack: ack, nack: nack})
+
+	full := len(i.pending) >= i.size
+
+	i.mu.Unlock()
+
+	if !full {
+		return nil
+	}
+
+	// Flushing in the caller's goroutine blocks consuming new events until the batch is written.
+	if err := i.Flush(ctx); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "Flush")
+	}
+
+	return nil
+}
@@ -0,0 +1,324 @@
+package elasticsearch_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/elasticsearch"
+	"github.com/MarioCarrion/todo-api/internal/elasticsearch/elasticsearchtesting"
+)
+
+func TestIndexer_Flush(t *testing.T) {
+	t.Parallel()
+
+	type event struct {
+		id       string
+		version  int64
+		deleted  bool
+		skipped  bool
+		nackable bool
+	}
+
+	type bulk struct {
+		Tasks   []internal.Task
+		Deleted []internal.Task
+	}
+
+	type output struct {
+		Bulks  []bulk
+		Acks   []string
+		Errors []bool
+	}
+
+	bulkError := internal.WrapErrorf(&elasticsearch.BulkError{IDs: []string{"b"}}, internal.ErrorCodeUnknown, "failed")
+
+	tests := []struct {
+		name    string
+		setup   func(*elasticsearchtesting.FakeBulkStore)
+		events  []event
+		flushes int
+		output  output
+	}{
+		{
+			"OK: coalesced",
+			func(*elasticsearchtesting.FakeBulkStore) {},
+			[]event{
+				{id: "a", version: 1},
+				{id: "b", version: 1},
+				{id: "a", version: 2},
+				{skipped: true},
+				{id: "c", version: 3, deleted: true},
+			},
+			1,
+			output{
+				Bulks: []bulk{
+					{
+						Tasks:   []internal.Task{{ID: "a", Version: 2}, {ID: "b", Version: 1}},
+						Deleted: []internal.Task{{ID: "c", Version: 3}},
+					},
+				},
+				Acks:   []string{"ack 0", "ack 1", "ack 2", "ack 3", "ack 4"},
+				Errors: []bool{false},
+			},
+		},
+		{
+			"OK: outdated version",
+			func(*elasticsearchtesting.FakeBulkStore) {},
+			[]event{
+				{id: "a", version: 3},
+				{id: "a", version: 2, deleted: true},
+			},
+			1,
+			output{
+				Bulks:  []bulk{{Tasks: []internal.Task{{ID: "a", Version: 3}}, Deleted: []internal.Task{}}},
+				Acks:   []string{"ack 0", "ack 1"},
+				Errors: []bool{false},
+			},
+		},
+		{
+			"OK: without version",
+			func(*elasticsearchtesting.FakeBulkStore) {},
+			[]event{
+				{id: "a", version: 3},
+				{id: "a", deleted: true},
+			},
+			1,
+			output{
+				Bulks:  []bulk{{Tasks: []internal.Task{}, Deleted: []internal.Task{{ID: "a"}}}},
+				Acks:   []string{"ack 0", "ack 1"},
+				Errors: []bool{false},
+			},
+		},
+		{
+			"OK: nothing pending",
+			func(*elasticsearchtesting.FakeBulkStore) {},
+			nil,
+			1,
+			output{
+				Errors: []bool{false},
+			},
+		},
+		{
+			"ERR: batch kept",
+			func(store *elasticsearchtesting.FakeBulkStore) {
+				store.BulkReturnsOnCall(0, errors.New("failed"))
+			},
+			[]event{
+				{id: "a", version: 1},
+				{id: "b", version: 1, nackable: true},
+			},
+			2,
+			output{
+				Bulks: []bulk{
+					{Tasks: []internal.Task{{ID: "a", Version: 1}, {ID: "b", Version: 1}}, Deleted: []internal.Task{}},
+					{Tasks: []internal.Task{{ID: "a", Version: 1}, {ID: "b", Version: 1}}, Deleted: []internal.Task{}},
+				},
+				Acks:   []string{"ack 0", "ack 1"},
+				Errors: []bool{true, false},
+			},
+		},
+		{
+			"ERR: batch nacked",
+			func(store *elasticsearchtesting.FakeBulkStore) {
+				store.BulkReturns(errors.New("failed"))
+			},
+			[]event{
+				{id: "a", version: 1, nackable: true},
+				{skipped: true},
+				{id: "b", version: 1, nackable: true},
+			},
+			2,
+			output{
+				Bulks: []bulk{
+					{Tasks: []internal.Task{{ID: "a", Version: 1}, {ID: "b", Version: 1}}, Deleted: []internal.Task{}},
+				},
+				Acks:   []string{"nack 0", "ack 1", "nack 2"},
+				Errors: []bool{true, false},
+			},
+		},
+		{
+			"ERR: partially nacked",
+			func(store *elasticsearchtesting.FakeBulkStore) {
+				store.BulkReturns(bulkError)
+			},
+			[]event{
+				{id: "a", version: 1, nackable: true},
+				{id: "b", version: 1, nackable: true},
+				{skipped: true},
+				{id: "b", version: 2, nackable: true},
+				{id: "c", version: 1, deleted: true, nackable: true},
+			},
+			1,
+			output{
+				Bulks: []bulk{
+					{
+						Tasks:   []internal.Task{{ID: "a", Version: 1}, {ID: "b", Version: 2}},
+						Deleted: []internal.Task{{ID: "c", Version: 1}},
+					},
+				},
+				Acks:   []string{"ack 0", "nack 1", "ack 2", "nack 3", "ack 4"},
+				Errors: []bool{true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := &elasticsearchtesting.FakeBulkStore{}
+			tt.setup(store)
+
+			indexer := elasticsearch.NewIndexer(zap.NewNop(), store, 100, time.Hour)
+			recorder := &ackRecorder{}
+
+			for j, evt := range tt.events {
+				ack, nack := recorder.funcs(j)
+				if !evt.nackable {
+					nack = nil
+				}
+
+				var err error
+
+				switch {
+				case evt.skipped:
+					indexer.Skip(ack)
+				case evt.deleted:
+					err = indexer.Delete(context.Background(), evt.id, evt.version, ack, nack)
+				default:
+					err = indexer.Index(context.Background(), internal.Task{ID: evt.id, Version: evt.version}, ack, nack)
+				}
+
+				if err != nil {
+					t.Fatalf("expected no error, got %s", err)
+				}
+			}
+
+			actual := output{}
+
+			for j := 0; j < tt.flushes; j++ {
+				actual.Errors = append(actual.Errors, indexer.Flush(context.Background()) != nil)
+			}
+
+			for j := 0; j < store.BulkCallCount(); j++ {
+				_, tasks, deleted := store.BulkArgsForCall(j)
+
+				actual.Bulks = append(actual.Bulks, bulk{Tasks: sortTasks(tasks), Deleted: sortTasks(deleted)})
+			}
+
+			actual.Acks = recorder.acks
+
+			if !cmp.Equal(tt.output, actual) {
+				t.Fatalf("expected result does not match: %s", cmp.Diff(tt.output, actual))
+			}
+		})
+	}
+}
+
+func TestIndexer_Size(t *testing.T) {
+	t.Parallel()
+
+	store := &elasticsearchtesting.FakeBulkStore{}
+	indexer := elasticsearch.NewIndexer(zap.NewNop(), store, 2, time.Hour)
+	recorder := &ackRecorder{}
+
+	for j, id := range []string{"a", "a", "b"} {
+		ack, nack := recorder.funcs(j)
+
+		if err := indexer.Index(context.Background(), internal.Task{ID: id}, ack, nack); err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		// Events for the same task are coalesced, they don't fill the batch.
+		if expected := j / 2; store.BulkCallCount() != expected {
+			t.Fatalf("expected %d writes after %d events, got %d", expected, j+1, store.BulkCallCount())
+		}
+	}
+
+	if expected := []string{"ack 0", "ack 1", "ack 2"}; !cmp.Equal(expected, recorder.acks) {
+		t.Fatalf("expected acks do not match: %s", cmp.Diff(expected, recorder.acks))
+	}
+}
+
+func TestIndexer_Start(t *testing.T) {
+	t.Parallel()
+
+	store := &elasticsearchtesting.FakeBulkStore{}
+	indexer := elasticsearch.NewIndexer(zap.NewNop(), store, 100, 10*time.Millisecond)
+	indexer.Start()
+
+	acked := make(chan struct{})
+
+	if err := indexer.Index(context.Background(), internal.Task{ID: "a"}, func() { close(acked) }, nil); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	select {
+	case <-acked:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected event to be flushed after the interval")
+	}
+
+	if err := indexer.Close(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+}
+
+func TestIndexer_Close(t *testing.T) {
+	t.Parallel()
+
+	store := &elasticsearchtesting.FakeBulkStore{}
+	indexer := elasticsearch.NewIndexer(zap.NewNop(), store, 100, time.Hour)
+	indexer.Start()
+
+	recorder := &ackRecorder{}
+	ack, nack := recorder.funcs(0)
+
+	if err := indexer.Index(context.Background(), internal.Task{ID: "a"}, ack, nack); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if err := indexer.Close(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if store.BulkCallCount() != 1 {
+		t.Fatalf("expected pending events to be written, got %d writes", store.BulkCallCount())
+	}
+
+	if expected := []string{"ack 0"}; !cmp.Equal(expected, recorder.acks) {
+		t.Fatalf("expected acks do not match: %s", cmp.Diff(expected, recorder.acks))
+	}
+}
+
+// ackRecorder records the order events are acknowledged and nacked in.
+type ackRecorder struct {
+	mu   sync.Mutex
+	acks []string
+}
+
+func (r *ackRecorder) funcs(index int) (func(), func(error)) {
+	record := func(name string) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		r.acks = append(r.acks, fmt.Sprintf("%s %d", name, index))
+	}
+
+	return func() { record("ack") }, func(error) { record("nack") }
+}
+
+func sortTasks(tasks []internal.Task) []internal.Task {
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+
+	return tasks
+}
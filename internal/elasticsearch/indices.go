@@ -0,0 +1,364 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	esv7 "github.com/elastic/go-elasticsearch/v7"
+	esv7api "github.com/elastic/go-elasticsearch/v7/esapi"
+
+	"github.com/MarioCarrion/todo-api/internal"
+)
+
+const (
+	// ReadAlias is the alias used for searching Task records.
+	ReadAlias = "tasks"
+
+	// WriteAlias is the alias pointing to the index being populated during a migration, Task records are
+	// written to both aliases while it exists.
+	WriteAlias = "tasks_next"
+)
+
+// IndexName returns the name of the versioned index holding Task records.
+func IndexName(version int) string {
+	return fmt.Sprintf("%s_v%d", ReadAlias, version)
+}
+
+// Indices manages the lifecycle of the versioned indices holding Task records.
+type Indices struct {
+	client *esv7.Client
+}
+
+// NewIndices instantiates the Indices repository.
+func NewIndices(client *esv7.Client) *Indices {
+	return &Indices{
+		client: client,
+	}
+}
+
+// Create creates a new index using the explicit Task mapping.
+func (i *Indices) Create(ctx context.Context, name string) error {
+	defer newOTELSpan(ctx, "Indices.Create").End()
+
+	//-
+
+	return i.create(ctx, name, nil)
+}
+
+// Exists indicates whether an index or alias exists.
+func (i *Indices) Exists(ctx context.Context, name string) (bool, error) {
+	defer newOTELSpan(ctx, "Indices.Exists").End()
+
+	//-
+
+	req := esv7api.IndicesExistsRequest{
+		Index: []string{name},
+	}
+
+	resp, err := req.Do(ctx, i.client)
+	if err != nil {
+		return false, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "IndicesExistsRequest.Do")
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	}
+
+	return false, internal.NewErrorf(internal.ErrorCodeUnknown, "IndicesExistsRequest.Do %d", resp.StatusCode)
+}
+
+// Aliases returns the indices the aliases are pointing to.
+func (i *Indices) Aliases(ctx context.Context) (read []string, write []string, _ error) {
+	defer newOTELSpan(ctx, "Indices.Aliases").End()
+
+	//-
+
+	aliases, err := getAliases(ctx, i.client)
+	if err != nil {
+		return nil, nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "getAliases")
+	}
+
+	return aliases[ReadAlias], aliases[WriteAlias], nil
+}
+
+// StartMigration points the write alias to the received index, from this moment on Task records are written to
+// it as well.
+func (i *Indices) StartMigration(ctx context.Context, name string) error {
+	defer newOTELSpan(ctx, "Indices.StartMigration").End()
+
+	//-
+
+	actions := []interface{}{
+		map[string]interface{}{
+			"add": map[string]interface{}{
+				"index": name,
+				"alias": WriteAlias,
+			},
+		},
+	}
+
+	if err := i.updateAliases(ctx, actions); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "updateAliases")
+	}
+
+	return nil
+}
+
+// Switch atomically points the read alias to the received index and removes the write alias, when the read
+// alias is a concrete index, created before versioned indices were introduced, it is deleted as well.
+func (i *Indices) Switch(ctx context.Context, name string) error {
+	defer newOTELSpan(ctx, "Indices.Switch").End()
+
+	//-
+
+	aliases, err := getAliases(ctx, i.client)
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "getAliases")
+	}
+
+	actions := make([]interface{}, 0, 4)
+
+	for _, index := range aliases[ReadAlias] {
+		if index == name {
+			continue
+		}
+
+		actions = append(actions, map[string]interface{}{
+			"remove": map[string]interface{}{
+				"index": index,
+				"alias": ReadAlias,
+			},
+		})
+	}
+
+	for _, index := range aliases[WriteAlias] {
+		actions = append(actions, map[string]interface{}{
+			"remove": map[string]interface{}{
+				"index": index,
+				"alias": WriteAlias,
+			},
+		})
+	}
+
+	if len(aliases[ReadAlias]) == 0 {
+		exists, err := i.Exists(ctx, ReadAlias)
+		if err != nil {
+			return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "Exists")
+		}
+
+		if exists {
+			actions = append(actions, map[string]interface{}{
+				"remove_index": map[string]interface{}{
+					"index": ReadAlias,
+				},
+			})
+		}
+	}
+
+	actions = append(actions, map[string]interface{}{
+		"add": map[string]interface{}{
+			"index": name,
+			"alias": ReadAlias,
+		},
+	})
+
+	if err := i.updateAliases(ctx, actions); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "updateAliases")
+	}
+
+	return nil
+}
+
+// Delete removes an index.
+func (i *Indices) Delete(ctx context.Context, name string) error {
+	defer newOTELSpan(ctx, "Indices.Delete").End()
+
+	//-
+
+	req := esv7api.IndicesDeleteRequest{
+		Index: []string{name},
+	}
+
+	resp, err := req.Do(ctx, i.client)
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "IndicesDeleteRequest.Do")
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		return internal.NewErrorf(internal.ErrorCodeUnknown, "IndicesDeleteRequest.Do %d", resp.StatusCode)
+	}
+
+	io.Copy(io.Discard, resp.Body) //nolint: errcheck
+
+	return nil
+}
+
+// Bulk indexes and deletes tasks in the received index using one "_bulk" request, see Task.Bulk.
+func (i *Indices) Bulk(ctx context.Context, name string, tasks []internal.Task, deleted []internal.Task) error {
+	defer newOTELSpan(ctx, "Indices.Bulk").End()
+
+	//-
+
+	if len(tasks) == 0 && len(deleted) == 0 {
+		return nil
+	}
+
+	if err := bulk(ctx, i.client, name, tasks, deleted); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "bulk")
+	}
+
+	return nil
+}
+
+func (i *Indices) create(ctx context.Context, name string, aliases []string) error {
+	body := map[string]interface{}{
+		"mappings": mapping(),
+	}
+
+	if len(aliases) > 0 {
+		res := make(map[string]interface{}, len(aliases))
+
+		for _, alias := range aliases {
+			res[alias] = map[string]interface{}{}
+		}
+
+		body["aliases"] = res
+	}
+
+	var buf bytes.Buffer
+
+	if err := json.NewEncoder(&buf).Encode(body); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "json.NewEncoder.Encode")
+	}
+
+	req := esv7api.IndicesCreateRequest{
+		Index: name,
+		Body:  &buf,
+	}
+
+	resp, err := req.Do(ctx, i.client)
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "IndicesCreateRequest.Do")
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		return internal.NewErrorf(internal.ErrorCodeUnknown, "IndicesCreateRequest.Do %d", resp.StatusCode)
+	}
+
+	io.Copy(io.Discard, resp.Body) //nolint: errcheck
+
+	return nil
+}
+
+func (i *Indices) updateAliases(ctx context.Context, actions []interface{}) error {
+	var buf bytes.Buffer
+
+	if err := json.NewEncoder(&buf).Encode(map[string]interface{}{"actions": actions}); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "json.NewEncoder.Encode")
+	}
+
+	req := esv7api.IndicesUpdateAliasesRequest{
+		Body: &buf,
+	}
+
+	resp, err := req.Do(ctx, i.client)
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "IndicesUpdateAliasesRequest.Do")
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		return internal.NewErrorf(internal.ErrorCodeUnknown, "IndicesUpdateAliasesRequest.Do %d", resp.StatusCode)
+	}
+
+	io.Copy(io.Discard, resp.Body) //nolint: errcheck
+
+	return nil
+}
+
+//-
+
+// getAliases returns the indices each alias is pointing to.
+func getAliases(ctx context.Context, client *esv7.Client) (map[string][]string, error) {
+	req := esv7api.IndicesGetAliasRequest{
+		Name: []string{ReadAlias, WriteAlias},
+	}
+
+	resp, err := req.Do(ctx, client)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "IndicesGetAliasRequest.Do")
+	}
+	defer resp.Body.Close()
+
+	// "404 Not Found" is returned when any of the aliases is missing, the body still includes the ones found.
+	if resp.IsError() && resp.StatusCode != http.StatusNotFound {
+		return nil, internal.NewErrorf(internal.ErrorCodeUnknown, "IndicesGetAliasRequest.Do %d", resp.StatusCode)
+	}
+
+	var body map[string]json.RawMessage
+
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "json.NewDecoder.Decode")
+	}
+
+	res := make(map[string][]string)
+
+	for index, raw := range body {
+		var val struct {
+			Aliases map[string]json.RawMessage `json:"aliases"`
+		}
+
+		// Keys like "error" and "status" are not indices, those are ignored because they don't decode as such.
+		if err := json.Unmarshal(raw, &val); err != nil {
+			continue
+		}
+
+		for alias := range val.Aliases {
+			res[alias] = append(res[alias], index)
+		}
+	}
+
+	return res, nil
+}
+
+func mapping() map[string]interface{} {
+	return map[string]interface{}{
+		"dynamic": "strict",
+		"properties": map[string]interface{}{
+			"id": map[string]interface{}{
+				"type": "keyword",
+			},
+			"description": map[string]interface{}{
+				"type": "text",
+			},
+			"description_suggest": map[string]interface{}{
+				"type": "search_as_you_type",
+			},
+			"priority": map[string]interface{}{
+				"type": "byte",
+			},
+			"is_done": map[string]interface{}{
+				"type": "boolean",
+			},
+			"date_start": map[string]interface{}{
+				"type": "long",
+			},
+			"date_due": map[string]interface{}{
+				"type": "long",
+			},
+			"deleted": map[string]interface{}{
+				"type": "boolean",
+			},
+		},
+	}
+}
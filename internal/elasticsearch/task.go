@@ -5,6 +5,8 @@ import (
 	"context"
 	"encoding/json"
 	"io"
+	"slices"
+	"sync"
 	"time"
 
 	esv7 "github.com/elastic/go-elasticsearch/v7"
@@ -18,93 +20,94 @@ import (
 
 const otelName = "github.com/MarioCarrion/todo-api/internal/elasticsearch"
 
+// aliasesRefreshInterval indicates how often the indices behind the aliases are resolved again.
+const aliasesRefreshInterval = 5 * time.Second
+
 // Task represents the repository used for interacting with Task records.
 type Task struct {
-	client *esv7.Client
-	index  string
+	client  *esv7.Client
+	index   string
+	indices *Indices
+
+	mu          sync.Mutex
+	writes      []string
+	refreshedAt time.Time
 }
 
 //nolint:tagliatelle
 type indexedTask struct {
 	// XXX: `SubTasks` and `Categories` will be added in future episodes
-	ID          string            `json:"id"`
-	Description string            `json:"description"`
-	Priority    internal.Priority `json:"priority"`
-	IsDone      bool              `json:"is_done"`
-	DateStart   int64             `json:"date_start"`
-	DateDue     int64             `json:"date_due"`
+	ID                 string            `json:"id"`
+	Description        string            `json:"description"`
+	DescriptionSuggest string            `json:"description_suggest"`
+	Priority           internal.Priority `json:"priority"`
+	IsDone             bool              `json:"is_done"`
+	DateStart          int64             `json:"date_start"`
+	DateDue            int64             `json:"date_due"`
+	Deleted            bool              `json:"deleted,omitempty"`
 }
 
-// NewTask instantiates the Task repository.
+// NewTask instantiates the Task repository, records are read through the "tasks" alias and written to the
+// indices behind it as well as the ones being populated by a migration.
 func NewTask(client *esv7.Client) *Task {
 	return &Task{
-		client: client,
-		index:  "tasks",
+		client:  client,
+		index:   ReadAlias,
+		indices: NewIndices(client),
 	}
 }
 
-// Index creates or updates a task in an index.
-func (t *Task) Index(ctx context.Context, task internal.Task) error {
-	defer newOTELSpan(ctx, "Task.Index").End()
+// Init creates the first versioned index, and its alias, when missing; otherwise it defines the mapping of the
+// fields that can't be dynamically mapped, this is needed by indices created before versioning was introduced.
+func (t *Task) Init(ctx context.Context) error {
+	defer newOTELSpan(ctx, "Task.Init").End()
 
 	//-
 
-	body := indexedTask{
-		ID:          task.ID,
-		Description: task.Description,
-		Priority:    task.Priority,
-		IsDone:      task.IsDone,
-		DateStart:   task.Dates.Start.UnixNano(),
-		DateDue:     task.Dates.Due.UnixNano(),
+	exists, err := t.indices.Exists(ctx, t.index)
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "indices.Exists")
 	}
 
-	var buf bytes.Buffer
+	if !exists {
+		if err := t.indices.create(ctx, IndexName(1), []string{t.index}); err != nil {
+			return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "indices.create")
+		}
 
-	if err := json.NewEncoder(&buf).Encode(body); err != nil {
-		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "json.NewEncoder.Encode")
+		return nil
 	}
 
-	req := esv7api.IndexRequest{
-		Index:      t.index,
-		Body:       &buf,
-		DocumentID: task.ID,
-		Refresh:    "true",
+	// Existing indices only get the new fields, changing the type of existing ones is not supported.
+	body := map[string]interface{}{
+		"properties": map[string]interface{}{
+			"description_suggest": map[string]interface{}{
+				"type": "search_as_you_type",
+			},
+			"deleted": map[string]interface{}{
+				"type": "boolean",
+			},
+		},
 	}
 
-	resp, err := req.Do(ctx, t.client)
-	if err != nil {
-		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "IndexRequest.Do")
-	}
-	defer resp.Body.Close()
+	var buf bytes.Buffer
 
-	if resp.IsError() {
-		return internal.NewErrorf(internal.ErrorCodeUnknown, "IndexRequest.Do %d", resp.StatusCode)
+	if err := json.NewEncoder(&buf).Encode(body); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "json.NewEncoder.Encode")
 	}
 
-	io.Copy(io.Discard, resp.Body) //nolint: errcheck
-
-	return nil
-}
-
-// Delete removes a task from the index.
-func (t *Task) Delete(ctx context.Context, id string) error {
-	defer newOTELSpan(ctx, "Task.Delete").End()
-
-	//-
-
-	req := esv7api.DeleteRequest{
-		Index:      t.index,
-		DocumentID: id,
+	req := esv7api.IndicesPutMappingRequest{
+		Index: []string{t.index},
+		Body:  &buf,
 	}
 
 	resp, err := req.Do(ctx, t.client)
 	if err != nil {
-		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "DeleteRequest.Do")
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "IndicesPutMappingRequest.Do")
 	}
 	defer resp.Body.Close()
 
 	if resp.IsError() {
-		return internal.NewErrorf(internal.ErrorCodeUnknown, "DeleteRequest.Do %d", resp.StatusCode)
+		return internal.NewErrorf(internal.ErrorCodeUnknown, "IndicesPutMappingRequest.Do %d", resp.StatusCode)
 	}
 
 	io.Copy(io.Discard, resp.Body) //nolint: errcheck
@@ -150,20 +153,13 @@ func (t *Task) Search(ctx context.Context, args internal.SearchParams) (internal
 		})
 	}
 
-	var query map[string]interface{}
-
-	if len(should) > 1 {
-		query = map[string]interface{}{
-			"query": map[string]interface{}{
-				"bool": map[string]interface{}{
-					"should": should,
-				},
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"should":   should,
+				"must_not": notDeleted(),
 			},
-		}
-	} else {
-		query = map[string]interface{}{
-			"query": should[0],
-		}
+		},
 	}
 
 	query["sort"] = []interface{}{
@@ -227,8 +223,225 @@ func (t *Task) Search(ctx context.Context, args internal.SearchParams) (internal
 	}, nil
 }
 
+// ListAfter returns, sorted by id, up to size tasks with an id greater than the received one; use an empty id to
+// start from the first task.
+func (t *Task) ListAfter(ctx context.Context, id string, size int32) ([]internal.Task, error) {
+	defer newOTELSpan(ctx, "Task.ListAfter").End()
+
+	//-
+
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must_not": notDeleted(),
+			},
+		},
+		"sort": []interface{}{
+			map[string]interface{}{"id": "asc"},
+		},
+		"size":    size,
+		"version": true,
+	}
+
+	if id != "" {
+		query["search_after"] = []string{id}
+	}
+
+	var buf bytes.Buffer
+
+	if err := json.NewEncoder(&buf).Encode(query); err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "json.NewEncoder.Encode")
+	}
+
+	req := esv7api.SearchRequest{
+		Index:          []string{t.index},
+		Body:           &buf,
+		TrackTotalHits: false,
+	}
+
+	resp, err := req.Do(ctx, t.client)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "SearchRequest.Do")
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		return nil, internal.NewErrorf(internal.ErrorCodeUnknown, "SearchRequest.Do %d", resp.StatusCode)
+	}
+
+	//nolint: tagliatelle
+	var hits struct {
+		Hits struct {
+			Hits []struct {
+				Version int64       `json:"_version"`
+				Source  indexedTask `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&hits); err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "json.NewDecoder.Decode")
+	}
+
+	res := make([]internal.Task, len(hits.Hits.Hits))
+
+	for i, hit := range hits.Hits.Hits {
+		res[i] = hit.Source.task()
+		res[i].Version = hit.Version
+	}
+
+	return res, nil
+}
+
+// Suggest returns task descriptions matching the text typed so far, only the tasks visible to the caller are
+// suggested: the same ones returned by Search.
+func (t *Task) Suggest(ctx context.Context, args internal.SuggestParams) ([]internal.Suggestion, error) {
+	defer newOTELSpan(ctx, "Task.Suggest").End()
+
+	//-
+
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must": map[string]interface{}{
+					"multi_match": map[string]interface{}{
+						"query": args.Query,
+						"type":  "bool_prefix",
+						"fields": []string{
+							"description_suggest",
+							"description_suggest._2gram",
+							"description_suggest._3gram",
+						},
+					},
+				},
+				"must_not": notDeleted(),
+			},
+		},
+		"_source": []string{"id", "description"},
+		"size":    args.Size,
+	}
+
+	var buf bytes.Buffer
+
+	if err := json.NewEncoder(&buf).Encode(query); err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "json.NewEncoder.Encode")
+	}
+
+	req := esv7api.SearchRequest{
+		Index:          []string{t.index},
+		Body:           &buf,
+		TrackTotalHits: false,
+	}
+
+	resp, err := req.Do(ctx, t.client)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "SearchRequest.Do")
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		return nil, internal.NewErrorf(internal.ErrorCodeUnknown, "SearchRequest.Do %d", resp.StatusCode)
+	}
+
+	//nolint: tagliatelle
+	var hits struct {
+		Hits struct {
+			Hits []struct {
+				Source indexedTask `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&hits); err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "json.NewDecoder.Decode")
+	}
+
+	res := make([]internal.Suggestion, len(hits.Hits.Hits))
+
+	for i, hit := range hits.Hits.Hits {
+		res[i].ID = hit.Source.ID
+		res[i].Description = hit.Source.Description
+	}
+
+	return res, nil
+}
+
+// writeIndices returns the concrete indices behind the aliases, when the read alias does not exist the
+// configured index name is used instead.
+func (t *Task) writeIndices(ctx context.Context) ([]string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.writes != nil && time.Since(t.refreshedAt) < aliasesRefreshInterval {
+		return t.writes, nil
+	}
+
+	aliases, err := getAliases(ctx, t.client)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "getAliases")
+	}
+
+	res := aliases[ReadAlias]
+	if len(res) == 0 {
+		res = []string{t.index}
+	}
+
+	for _, index := range aliases[WriteAlias] {
+		if !slices.Contains(res, index) {
+			res = append(res, index)
+		}
+	}
+
+	t.writes = res
+	t.refreshedAt = time.Now()
+
+	return res, nil
+}
+
 //-
 
+func newIndexedTask(task internal.Task) indexedTask {
+	return indexedTask{
+		ID:                 task.ID,
+		Description:        task.Description,
+		DescriptionSuggest: task.Description,
+		Priority:           task.Priority,
+		IsDone:             task.IsDone,
+		DateStart:          task.Dates.Start.UnixNano(),
+		DateDue:            task.Dates.Due.UnixNano(),
+	}
+}
+
+// newTombstone returns the document kept for a deleted task, it prevents older events from indexing it again.
+func newTombstone(id string) indexedTask {
+	return indexedTask{
+		ID:      id,
+		Deleted: true,
+	}
+}
+
+func (i indexedTask) task() internal.Task {
+	return internal.Task{
+		ID:          i.ID,
+		Description: i.Description,
+		Priority:    i.Priority,
+		IsDone:      i.IsDone,
+		Dates: internal.Dates{
+			Start: time.Unix(0, i.DateStart).UTC(),
+			Due:   time.Unix(0, i.DateDue).UTC(),
+		},
+	}
+}
+
+// notDeleted returns the clause excluding tombstones, it's meant to be used in "must_not".
+func notDeleted() map[string]interface{} {
+	return map[string]interface{}{
+		"term": map[string]interface{}{
+			"deleted": true,
+		},
+	}
+}
+
 func newOTELSpan(ctx context.Context, name string) trace.Span {
 	_, span := otel.Tracer(otelName).Start(ctx, name)
 
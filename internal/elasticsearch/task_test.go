@@ -0,0 +1,176 @@
+package elasticsearch_test
+
+import (
+	"context"
+	"net"
+	"runtime"
+	"testing"
+	"time"
+
+	esv7 "github.com/elastic/go-elasticsearch/v7"
+	"github.com/google/uuid"
+	"github.com/ory/dockertest/v3"
+	"github.com/ory/dockertest/v3/docker"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/elasticsearch"
+	"github.com/MarioCarrion/todo-api/internal/service"
+	"github.com/MarioCarrion/todo-api/internal/service/servicetesting"
+)
+
+func TestTask(t *testing.T) {
+	t.Parallel()
+
+	servicetesting.TestTaskSearchRepository(t, func(t *testing.T, tasks []internal.Task) (service.TaskSearchRepository, []internal.Task) {
+		t.Helper()
+
+		client := newClient(t)
+		store := elasticsearch.NewTask(client)
+
+		if err := store.Init(context.Background()); err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		res := make([]internal.Task, len(tasks))
+
+		for i, task := range tasks {
+			task.ID = uuid.NewString()
+			res[i] = task
+		}
+
+		if err := store.Bulk(context.Background(), res, nil); err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		// Indexed tasks are searchable after the index is refreshed.
+		refresh, err := client.Indices.Refresh()
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		refresh.Body.Close()
+
+		return store, res
+	})
+}
+
+func TestTask_Bulk(t *testing.T) {
+	t.Parallel()
+
+	client := newClient(t)
+	store := elasticsearch.NewTask(client)
+
+	if err := store.Init(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	listAfter := func() []internal.Task {
+		t.Helper()
+
+		res, err := client.Indices.Refresh()
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		res.Body.Close()
+
+		tasks, err := store.ListAfter(context.Background(), "", 10)
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		return tasks
+	}
+
+	bulk := func(tasks []internal.Task, deleted []internal.Task) {
+		t.Helper()
+
+		if err := store.Bulk(context.Background(), tasks, deleted); err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+	}
+
+	task := internal.Task{ID: uuid.NewString(), Description: "updated", Priority: internal.PriorityLow, Version: 2}
+	outdated := internal.Task{ID: task.ID, Description: "created", Priority: internal.PriorityLow, Version: 1}
+
+	bulk([]internal.Task{task}, nil)
+	bulk([]internal.Task{outdated, task}, nil) // Outdated and redelivered writes are ignored.
+
+	tasks := listAfter()
+	if len(tasks) != 1 || tasks[0].Description != task.Description || tasks[0].Version != task.Version {
+		t.Fatalf("expected task with version %d, got %v", task.Version, tasks)
+	}
+
+	bulk(nil, []internal.Task{{ID: task.ID, Version: 3}})
+	bulk([]internal.Task{task}, nil) // The tombstone prevents indexing the deleted task again.
+
+	if tasks := listAfter(); len(tasks) != 0 {
+		t.Fatalf("expected no tasks, got %v", tasks)
+	}
+}
+
+func newClient(tb testing.TB) *esv7.Client {
+	tb.Helper()
+
+	pool, err := dockertest.NewPool("")
+	if err != nil {
+		tb.Fatalf("Couldn't connect to docker: %s", err)
+	}
+
+	pool.MaxWait = 2 * time.Minute
+
+	resource, err := pool.RunWithOptions(&dockertest.RunOptions{
+		Repository: "elasticsearch",
+		Tag:        "7.17.8",
+		Env: []string{
+			"discovery.type=single-node",
+			"ES_JAVA_OPTS=-Xms512m -Xmx512m",
+		},
+	}, func(config *docker.HostConfig) {
+		config.AutoRemove = true
+		config.RestartPolicy = docker.RestartPolicy{
+			Name: "no",
+		}
+	})
+	if err != nil {
+		tb.Fatalf("Couldn't start resource: %s", err)
+	}
+
+	_ = resource.Expire(180)
+
+	tb.Cleanup(func() {
+		if errC := pool.Purge(resource); errC != nil {
+			tb.Fatalf("Couldn't purge container: %v", errC)
+		}
+	})
+
+	host := resource.Container.NetworkSettings.IPAddress + ":9200"
+	if runtime.GOOS == "darwin" { // MacOS-specific
+		host = net.JoinHostPort(resource.GetBoundIP("9200/tcp"), resource.GetPort("9200/tcp"))
+	}
+
+	client, err := esv7.NewClient(esv7.Config{
+		Addresses: []string{"http://" + host},
+	})
+	if err != nil {
+		tb.Fatalf("Couldn't create client: %s", err)
+	}
+
+	if err = pool.Retry(func() error {
+		res, err := client.Cluster.Health(client.Cluster.Health.WithWaitForStatus("yellow"))
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+
+		if res.IsError() {
+			return internal.NewErrorf(internal.ErrorCodeUnknown, "cluster health %d", res.StatusCode)
+		}
+
+		return nil
+	}); err != nil {
+		tb.Fatalf("Couldn't connect to Elasticsearch: %s", err)
+	}
+
+	return client
+}
@@ -31,6 +31,15 @@ func Load(filename string) error {
 	return nil
 }
 
+// Reload reads the env filename again, unlike Load the values override the ones already in ENV for this process.
+func Reload(filename string) error {
+	if err := godotenv.Overload(filename); err != nil {
+		return internal.NewErrorf(internal.ErrorCodeUnknown, "reloading env var file")
+	}
+
+	return nil
+}
+
 // New ...
 func New(provider Provider) *Configuration {
 	return &Configuration{
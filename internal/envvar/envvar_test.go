@@ -2,6 +2,8 @@ package envvar_test
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -88,3 +90,29 @@ func TestConfiguration_Get(t *testing.T) {
 		})
 	}
 }
+
+func TestReload(t *testing.T) {
+	t.Setenv("ENVVAR_RELOAD", "old")
+
+	filename := filepath.Join(t.TempDir(), ".env")
+
+	if err := os.WriteFile(filename, []byte("ENVVAR_RELOAD=new\n"), 0o600); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if err := envvar.Load(filename); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if actual := os.Getenv("ENVVAR_RELOAD"); actual != "old" {
+		t.Fatalf("expected Load to keep the existing value, got %s", actual)
+	}
+
+	if err := envvar.Reload(filename); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if actual := os.Getenv("ENVVAR_RELOAD"); actual != "new" {
+		t.Fatalf("expected Reload to override the existing value, got %s", actual)
+	}
+}
@@ -0,0 +1,91 @@
+package events
+
+import (
+	"bytes"
+
+	"github.com/MarioCarrion/todo-api/internal"
+)
+
+const (
+	// ContentTypeJSON is the content type of events encoded using JSON.
+	ContentTypeJSON = "application/cloudevents+json"
+
+	// ContentTypeProtobuf is the content type of events encoded using Protocol Buffers.
+	ContentTypeProtobuf = "application/cloudevents+protobuf"
+
+	// ContentTypeGob is the content type of the version 0 events published to RabbitMQ.
+	ContentTypeGob = "application/x-encoding-gob"
+)
+
+// Codec encodes and decodes events.
+type Codec interface {
+	// ContentType returns the content type of the encoded events, brokers supporting it should send it together
+	// with the message.
+	ContentType() string
+	Marshal(evt Event) ([]byte, error)
+	Unmarshal(data []byte) (Event, error)
+}
+
+var (
+	// JSON implements Codec using the CloudEvents JSON format.
+	JSON Codec = jsonCodec{} //nolint: gochecknoglobals
+
+	// Protobuf implements Codec using the CloudEvents Protocol Buffers format.
+	Protobuf Codec = protobufCodec{} //nolint: gochecknoglobals
+)
+
+// NewCodec returns the Codec matching the name: "json" or "protobuf".
+func NewCodec(name string) (Codec, error) {
+	switch name {
+	case "json":
+		return JSON, nil
+	case "protobuf":
+		return Protobuf, nil
+	}
+
+	return nil, internal.NewErrorf(internal.ErrorCodeInvalidArgument, "unknown codec %q", name)
+}
+
+// Decode decodes an event encoded using any of the codecs, including version 0 events.
+//
+// contentType is the content type received with the message, it's empty when the broker doesn't support it and
+// the codec is detected using the data instead. typ is the event type indicated by the broker, for example the
+// RabbitMQ routing key or the Redis Stream entry type, it's only used for version 0 events because they don't
+// include it.
+func Decode(typ, contentType string, data []byte) (Event, error) {
+	var (
+		evt Event
+		err error
+	)
+
+	switch contentType {
+	case ContentTypeJSON:
+		evt, err = JSON.Unmarshal(data)
+	case ContentTypeProtobuf:
+		evt, err = Protobuf.Unmarshal(data)
+	case ContentTypeGob:
+		evt, err = decodeGob(typ, data)
+	case "":
+		if isJSON(data) {
+			evt, err = decodeJSON(typ, data)
+		} else {
+			evt, err = Protobuf.Unmarshal(data)
+		}
+	default:
+		return Event{}, internal.NewErrorf(internal.ErrorCodeInvalidArgument, "unknown content type %q", contentType)
+	}
+
+	if err != nil {
+		return Event{}, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "decode")
+	}
+
+	return evt, nil
+}
+
+// isJSON indicates whether data looks like a JSON object or string, encoded events using Protocol Buffers start
+// with the tag of one of the fields instead.
+func isJSON(data []byte) bool {
+	data = bytes.TrimLeft(data, " \t\r\n")
+
+	return len(data) > 0 && (data[0] == '{' || data[0] == '"')
+}
@@ -0,0 +1,74 @@
+// Package events defines the envelope used for publishing Task events to all the message brokers.
+//
+// The envelope is compatible with CloudEvents 1.0, https://cloudevents.io/, using the structured content mode:
+// the attributes and the payload are encoded together in the message body, using JSON or Protocol Buffers.
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/MarioCarrion/todo-api/internal"
+)
+
+const (
+	// SpecVersion is the version of the CloudEvents specification implemented by the envelope.
+	SpecVersion = "1.0"
+
+	// SchemaVersion is the version of the envelope and its payload, it must be incremented when they change in a
+	// way older consumers can't decode. Version 0 refers to the messages published before the envelope existed.
+	SchemaVersion = 1
+
+	// Source identifies the service publishing the events.
+	Source = "/todo-api/tasks"
+)
+
+const (
+	// TypeTaskCreated indicates a task was created.
+	TypeTaskCreated = "tasks.event.created"
+
+	// TypeTaskUpdated indicates a task was updated.
+	TypeTaskUpdated = "tasks.event.updated"
+
+	// TypeTaskDeleted indicates a task was deleted, only the ID and Version of the task are included.
+	TypeTaskDeleted = "tasks.event.deleted"
+
+	// TypeTaskDueSoon indicates a task is due within one of its reminders, the task is not changed.
+	TypeTaskDueSoon = "tasks.event.due_soon"
+
+	// TypeTaskOverdue indicates the due date of a task passed before it was done, the task is not changed.
+	TypeTaskOverdue = "tasks.event.overdue"
+)
+
+// Event is the envelope wrapping the published tasks.
+type Event struct {
+	// ID identifies the event, it's unique per Source.
+	ID string
+	// Type is one of the Type constants.
+	Type string
+	// Source identifies the service that published the event.
+	Source string
+	// Time indicates when the event happened, zero for version 0 events.
+	Time time.Time
+	// SchemaVersion is the version used when the event was published.
+	SchemaVersion int
+	// TraceParent and TraceState are the W3C Trace Context of the publisher, they are only set when the broker
+	// doesn't support headers, see Inject.
+	TraceParent string
+	TraceState  string
+	// Task is the payload.
+	Task internal.Task
+}
+
+// NewTaskEvent instantiates a new event using the current SchemaVersion.
+func NewTaskEvent(typ string, task internal.Task) Event {
+	return Event{
+		ID:            uuid.NewString(),
+		Type:          typ,
+		Source:        Source,
+		Time:          time.Now().UTC(),
+		SchemaVersion: SchemaVersion,
+		Task:          task,
+	}
+}
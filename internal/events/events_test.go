@@ -0,0 +1,343 @@
+package events_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/events"
+)
+
+func TestCodec(t *testing.T) {
+	t.Parallel()
+
+	evt := newEvent()
+	evt.TraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	evt.TraceState = "vendor=value"
+	evt.Task.Version = 3
+
+	for _, codec := range []events.Codec{events.JSON, events.Protobuf} {
+		codec := codec
+
+		t.Run(codec.ContentType(), func(t *testing.T) {
+			t.Parallel()
+
+			data, err := codec.Marshal(evt)
+			if err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			actual, err := events.Decode("", codec.ContentType(), data)
+			if err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			if !cmp.Equal(evt, actual) {
+				t.Fatalf("expected result does not match: %s", cmp.Diff(evt, actual))
+			}
+
+			// Brokers not supporting content types, like Redis, detect the codec using the data.
+
+			actual, err = events.Decode("", "", data)
+			if err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			if !cmp.Equal(evt, actual) {
+				t.Fatalf("expected result does not match: %s", cmp.Diff(evt, actual))
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	type input struct {
+		typ         string
+		contentType string
+		data        []byte
+	}
+
+	type output struct {
+		evt     events.Event
+		withErr bool
+	}
+
+	task := internal.Task{
+		ID:          "2f7a1de2-0e43-4a4b-93bb-5c1bc5df6a61",
+		Description: "buy milk",
+		Priority:    internal.PriorityHigh,
+		Dates: internal.Dates{
+			Start: time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC),
+		},
+	}
+
+	tests := []struct {
+		name   string
+		input  input
+		output output
+	}{
+		{
+			"OK: v1 JSON",
+			input{
+				contentType: events.ContentTypeJSON,
+				data:        readFile(t, "v1.json"),
+			},
+			output{
+				evt: newEvent(),
+			},
+		},
+		{
+			"OK: v1 Protobuf",
+			input{
+				contentType: events.ContentTypeProtobuf,
+				data:        readFile(t, "v1.pb"),
+			},
+			output{
+				evt: newEvent(),
+			},
+		},
+		{
+			"OK: v1 JSON, without content type",
+			input{
+				data: readFile(t, "v1.json"),
+			},
+			output{
+				evt: newEvent(),
+			},
+		},
+		{
+			"OK: v1 Protobuf, without content type",
+			input{
+				data: readFile(t, "v1.pb"),
+			},
+			output{
+				evt: newEvent(),
+			},
+		},
+		{
+			"OK: v0 RabbitMQ created",
+			input{
+				typ:         events.TypeTaskCreated,
+				contentType: events.ContentTypeGob,
+				data:        encodeGob(t, task),
+			},
+			output{
+				evt: events.Event{Type: events.TypeTaskCreated, Task: task},
+			},
+		},
+		{
+			"OK: v0 RabbitMQ deleted",
+			input{
+				typ:         events.TypeTaskDeleted,
+				contentType: events.ContentTypeGob,
+				data:        encodeGob(t, task.ID),
+			},
+			output{
+				evt: events.Event{Type: events.TypeTaskDeleted, Task: internal.Task{ID: task.ID}},
+			},
+		},
+		{
+			"OK: v0 Redis updated",
+			input{
+				typ:  events.TypeTaskUpdated,
+				data: []byte(`{"IsDone":false,"Priority":3,"ID":"2f7a1de2-0e43-4a4b-93bb-5c1bc5df6a61","Description":"buy milk","Dates":{"Start":"2024-03-01T10:00:00Z","Due":"0001-01-01T00:00:00Z"},"SubTasks":null,"Categories":null}` + "\n"), //nolint: lll
+			},
+			output{
+				evt: events.Event{Type: events.TypeTaskUpdated, Task: task},
+			},
+		},
+		{
+			"OK: v0 Redis deleted",
+			input{
+				typ:  events.TypeTaskDeleted,
+				data: []byte(`"2f7a1de2-0e43-4a4b-93bb-5c1bc5df6a61"` + "\n"),
+			},
+			output{
+				evt: events.Event{Type: events.TypeTaskDeleted, Task: internal.Task{ID: task.ID}},
+			},
+		},
+		{
+			"OK: v0 Kafka created",
+			input{
+				data: []byte(`{"Type":"tasks.event.created","Value":{"IsDone":false,"Priority":3,"ID":"2f7a1de2-0e43-4a4b-93bb-5c1bc5df6a61","Description":"buy milk","Dates":{"Start":"2024-03-01T10:00:00Z","Due":"0001-01-01T00:00:00Z"},"SubTasks":null,"Categories":null}}` + "\n"), //nolint: lll
+			},
+			output{
+				evt: events.Event{Type: events.TypeTaskCreated, Task: task},
+			},
+		},
+		{
+			"OK: v0 Kafka deleted",
+			input{
+				data: []byte(`{"Type":"tasks.event.deleted","Value":{"IsDone":false,"Priority":0,"ID":"2f7a1de2-0e43-4a4b-93bb-5c1bc5df6a61","Description":"","Dates":{"Start":"0001-01-01T00:00:00Z","Due":"0001-01-01T00:00:00Z"},"SubTasks":null,"Categories":null}}` + "\n"), //nolint: lll
+			},
+			output{
+				evt: events.Event{Type: events.TypeTaskDeleted, Task: internal.Task{ID: task.ID}},
+			},
+		},
+		{
+			"OK: v1 JSON with unknown attributes",
+			input{
+				contentType: events.ContentTypeJSON,
+				data:        []byte(`{"specversion":"1.0","id":"1","source":"/todo-api/tasks","type":"tasks.event.deleted","schemaversion":2,"traceparent":"00-1-2-01","partitionkey":"abc","data":{"id":"abc","owner":"me"}}`), //nolint: lll
+			},
+			output{
+				evt: events.Event{
+					ID:            "1",
+					Type:          events.TypeTaskDeleted,
+					Source:        events.Source,
+					SchemaVersion: 2,
+					TraceParent:   "00-1-2-01",
+					Task:          internal.Task{ID: "abc"},
+				},
+			},
+		},
+		{
+			"ERR: unknown content type",
+			input{
+				contentType: "text/plain",
+				data:        readFile(t, "v1.json"),
+			},
+			output{
+				withErr: true,
+			},
+		},
+		{
+			"ERR: unsupported spec version",
+			input{
+				contentType: events.ContentTypeJSON,
+				data:        []byte(`{"specversion":"0.3","type":"tasks.event.deleted"}`),
+			},
+			output{
+				withErr: true,
+			},
+		},
+		{
+			"ERR: v0 unknown type",
+			input{
+				typ:         "tasks.event.unknown",
+				contentType: events.ContentTypeGob,
+				data:        encodeGob(t, task),
+			},
+			output{
+				withErr: true,
+			},
+		},
+		{
+			"ERR: invalid protobuf",
+			input{
+				contentType: events.ContentTypeProtobuf,
+				data:        []byte{0x0a, 0xff},
+			},
+			output{
+				withErr: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := events.Decode(tt.input.typ, tt.input.contentType, tt.input.data)
+			if (err != nil) != tt.output.withErr {
+				t.Fatalf("expected error %t, got %s", tt.output.withErr, err)
+			}
+
+			if !cmp.Equal(tt.output.evt, actual) {
+				t.Fatalf("expected result does not match: %s", cmp.Diff(tt.output.evt, actual))
+			}
+		})
+	}
+}
+
+func TestNewCarrier(t *testing.T) {
+	t.Parallel()
+
+	// A local propagator instead of the global one, Inject and Extract use the same carrier.
+	propagator := propagation.TraceContext{}
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+
+	spanCtx := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	var evt events.Event
+
+	propagator.Inject(trace.ContextWithSpanContext(context.Background(), spanCtx), events.NewCarrier(&evt))
+
+	if expected := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"; evt.TraceParent != expected {
+		t.Fatalf("expected %s, got %s", expected, evt.TraceParent)
+	}
+
+	actual := trace.SpanContextFromContext(propagator.Extract(context.Background(), events.NewCarrier(&evt)))
+
+	if !actual.Equal(spanCtx.WithRemote(true)) {
+		t.Fatalf("expected span context %v, got %v", spanCtx, actual)
+	}
+}
+
+// newEvent returns the event encoded in the "testdata" files, those files must not be regenerated: they make sure
+// events published by older versions can still be decoded.
+func newEvent() events.Event {
+	return events.Event{
+		ID:            "9c1a4a3e-8f57-4e63-a1a4-0a3f8c2b6d10",
+		Type:          events.TypeTaskUpdated,
+		Source:        events.Source,
+		Time:          time.Date(2024, 3, 1, 10, 30, 15, 500, time.UTC),
+		SchemaVersion: 1,
+		Task: internal.Task{
+			ID:          "2f7a1de2-0e43-4a4b-93bb-5c1bc5df6a61",
+			Description: "buy groceries",
+			Priority:    internal.PriorityMedium,
+			IsDone:      true,
+			Dates: internal.Dates{
+				Start: time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC),
+				Due:   time.Date(2024, 3, 2, 18, 0, 0, 0, time.UTC),
+			},
+			SubTasks: []internal.Task{
+				{ID: "9e0b8a42-3f1b-4b2c-8f5e-2d4c6a7b8c9d", Description: "milk", Priority: internal.PriorityLow},
+			},
+			Categories: []internal.Category{"home", "errands"},
+		},
+	}
+}
+
+func readFile(t *testing.T, name string) []byte {
+	t.Helper()
+
+	res, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("couldn't read file: %s", err)
+	}
+
+	return res
+}
+
+func encodeGob(t *testing.T, v interface{}) []byte {
+	t.Helper()
+
+	var b bytes.Buffer
+
+	if err := gob.NewEncoder(&b).Encode(v); err != nil {
+		t.Fatalf("couldn't encode: %s", err)
+	}
+
+	return b.Bytes()
+}
@@ -0,0 +1,144 @@
+package events
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/MarioCarrion/todo-api/internal"
+)
+
+type jsonCodec struct{}
+
+// jsonEvent is the CloudEvents JSON format, "schemaversion" is an extension attribute and "traceparent" and
+// "tracestate" are defined by the Distributed Tracing extension.
+type jsonEvent struct {
+	SpecVersion     string    `json:"specversion"`
+	ID              string    `json:"id"`
+	Source          string    `json:"source"`
+	Type            string    `json:"type"`
+	Time            time.Time `json:"time"`
+	DataContentType string    `json:"datacontenttype"`
+	SchemaVersion   int       `json:"schemaversion"`
+	TraceParent     string    `json:"traceparent,omitempty"`
+	TraceState      string    `json:"tracestate,omitempty"`
+	Data            jsonTask  `json:"data"`
+}
+
+type jsonTask struct {
+	ID          string     `json:"id"`
+	Description string     `json:"description,omitempty"`
+	Priority    int8       `json:"priority,omitempty"`
+	StartDate   *time.Time `json:"start_date,omitempty"`
+	DueDate     *time.Time `json:"due_date,omitempty"`
+	IsDone      bool       `json:"is_done,omitempty"`
+	SubTasks    []jsonTask `json:"sub_tasks,omitempty"`
+	Categories  []string   `json:"categories,omitempty"`
+	Version     int64      `json:"version,omitempty"`
+}
+
+// ContentType ...
+func (jsonCodec) ContentType() string {
+	return ContentTypeJSON
+}
+
+// Marshal ...
+func (jsonCodec) Marshal(evt Event) ([]byte, error) {
+	res, err := json.Marshal(jsonEvent{
+		SpecVersion:     SpecVersion,
+		ID:              evt.ID,
+		Source:          evt.Source,
+		Type:            evt.Type,
+		Time:            evt.Time,
+		DataContentType: "application/json",
+		SchemaVersion:   evt.SchemaVersion,
+		TraceParent:     evt.TraceParent,
+		TraceState:      evt.TraceState,
+		Data:            newJSONTask(evt.Task),
+	})
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "json.Marshal")
+	}
+
+	return res, nil
+}
+
+// Unmarshal ...
+func (jsonCodec) Unmarshal(data []byte) (Event, error) {
+	var evt jsonEvent
+
+	if err := json.Unmarshal(data, &evt); err != nil {
+		return Event{}, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "json.Unmarshal")
+	}
+
+	if evt.SpecVersion != SpecVersion {
+		return Event{}, internal.NewErrorf(internal.ErrorCodeInvalidArgument, "unsupported spec version %q",
+			evt.SpecVersion)
+	}
+
+	return Event{
+		ID:            evt.ID,
+		Type:          evt.Type,
+		Source:        evt.Source,
+		Time:          evt.Time,
+		SchemaVersion: evt.SchemaVersion,
+		TraceParent:   evt.TraceParent,
+		TraceState:    evt.TraceState,
+		Task:          evt.Data.task(),
+	}, nil
+}
+
+func newJSONTask(task internal.Task) jsonTask {
+	res := jsonTask{
+		ID:          task.ID,
+		Description: task.Description,
+		Priority:    int8(task.Priority),
+		IsDone:      task.IsDone,
+		Version:     task.Version,
+	}
+
+	if !task.Dates.Start.IsZero() {
+		res.StartDate = &task.Dates.Start
+	}
+
+	if !task.Dates.Due.IsZero() {
+		res.DueDate = &task.Dates.Due
+	}
+
+	for _, sub := range task.SubTasks {
+		res.SubTasks = append(res.SubTasks, newJSONTask(sub))
+	}
+
+	for _, category := range task.Categories {
+		res.Categories = append(res.Categories, string(category))
+	}
+
+	return res
+}
+
+func (t jsonTask) task() internal.Task {
+	res := internal.Task{
+		ID:          t.ID,
+		Description: t.Description,
+		Priority:    internal.Priority(t.Priority),
+		IsDone:      t.IsDone,
+		Version:     t.Version,
+	}
+
+	if t.StartDate != nil {
+		res.Dates.Start = *t.StartDate
+	}
+
+	if t.DueDate != nil {
+		res.Dates.Due = *t.DueDate
+	}
+
+	for _, sub := range t.SubTasks {
+		res.SubTasks = append(res.SubTasks, sub.task())
+	}
+
+	for _, category := range t.Categories {
+		res.Categories = append(res.Categories, internal.Category(category))
+	}
+
+	return res
+}
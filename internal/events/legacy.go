@@ -0,0 +1,87 @@
+package events
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+
+	"github.com/MarioCarrion/todo-api/internal"
+)
+
+// Version 0 events were published before the envelope existed, each publisher used a different format:
+//
+//   - RabbitMQ: gob-encoded task, or task ID for deleted tasks; the type is the routing key.
+//   - Redis: JSON-encoded task, or task ID for deleted tasks; the type is the channel.
+//   - Kafka: JSON-encoded `{"Type": "<type>", "Value": <task>}`.
+
+// decodeGob decodes the version 0 events published to RabbitMQ.
+func decodeGob(typ string, data []byte) (Event, error) {
+	dec := gob.NewDecoder(bytes.NewReader(data))
+
+	evt := Event{
+		Type: typ,
+	}
+
+	switch typ {
+	case TypeTaskCreated, TypeTaskUpdated:
+		if err := dec.Decode(&evt.Task); err != nil {
+			return Event{}, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "gob.Decode")
+		}
+	case TypeTaskDeleted:
+		if err := dec.Decode(&evt.Task.ID); err != nil {
+			return Event{}, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "gob.Decode")
+		}
+	default:
+		return Event{}, internal.NewErrorf(internal.ErrorCodeInvalidArgument, "unknown type %q", typ)
+	}
+
+	return evt, nil
+}
+
+// decodeJSON decodes JSON-encoded events, using the envelope or the version 0 events published to Redis and
+// Kafka.
+func decodeJSON(typ string, data []byte) (Event, error) {
+	var envelope struct {
+		SpecVersion string `json:"specversion"`
+		Type        string
+		Value       json.RawMessage
+	}
+
+	// Deleted tasks published to Redis are a JSON string, decoding them fails and they are handled below.
+	if err := json.Unmarshal(data, &envelope); err == nil {
+		if envelope.SpecVersion != "" {
+			return JSON.Unmarshal(data)
+		}
+
+		if envelope.Type != "" && envelope.Value != nil {
+			evt := Event{
+				Type: envelope.Type,
+			}
+
+			if err := json.Unmarshal(envelope.Value, &evt.Task); err != nil {
+				return Event{}, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "json.Unmarshal")
+			}
+
+			return evt, nil
+		}
+	}
+
+	evt := Event{
+		Type: typ,
+	}
+
+	switch typ {
+	case TypeTaskCreated, TypeTaskUpdated:
+		if err := json.Unmarshal(data, &evt.Task); err != nil {
+			return Event{}, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "json.Unmarshal")
+		}
+	case TypeTaskDeleted:
+		if err := json.Unmarshal(data, &evt.Task.ID); err != nil {
+			return Event{}, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "json.Unmarshal")
+		}
+	default:
+		return Event{}, internal.NewErrorf(internal.ErrorCodeInvalidArgument, "unknown type %q", typ)
+	}
+
+	return evt, nil
+}
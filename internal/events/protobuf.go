@@ -0,0 +1,167 @@
+package events
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/events/eventspb"
+)
+
+//go:generate protoc --go_out=../.. --go_opt=module=github.com/MarioCarrion/todo-api events.proto
+
+type protobufCodec struct{}
+
+// ContentType ...
+func (protobufCodec) ContentType() string {
+	return ContentTypeProtobuf
+}
+
+// Marshal ...
+func (protobufCodec) Marshal(evt Event) ([]byte, error) {
+	task, err := proto.Marshal(newTaskMessage(evt.Task))
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "proto.Marshal")
+	}
+
+	attrs := map[string]*eventspb.CloudEventAttributeValue{
+		"datacontenttype": stringAttribute("application/protobuf"),
+		"schemaversion": {
+			Attr: &eventspb.CloudEventAttributeValue_CeInteger{CeInteger: int32(evt.SchemaVersion)}, //nolint: gosec
+		},
+	}
+
+	if !evt.Time.IsZero() {
+		attrs["time"] = &eventspb.CloudEventAttributeValue{
+			Attr: &eventspb.CloudEventAttributeValue_CeTimestamp{CeTimestamp: timestamppb.New(evt.Time)},
+		}
+	}
+
+	if evt.TraceParent != "" {
+		attrs["traceparent"] = stringAttribute(evt.TraceParent)
+	}
+
+	if evt.TraceState != "" {
+		attrs["tracestate"] = stringAttribute(evt.TraceState)
+	}
+
+	res, err := proto.Marshal(&eventspb.CloudEvent{
+		Id:          evt.ID,
+		Source:      evt.Source,
+		SpecVersion: SpecVersion,
+		Type:        evt.Type,
+		Attributes:  attrs,
+		Data:        &eventspb.CloudEvent_BinaryData{BinaryData: task},
+	})
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "proto.Marshal")
+	}
+
+	return res, nil
+}
+
+// Unmarshal ...
+func (protobufCodec) Unmarshal(data []byte) (Event, error) {
+	var msg eventspb.CloudEvent
+
+	if err := proto.Unmarshal(data, &msg); err != nil {
+		return Event{}, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "proto.Unmarshal")
+	}
+
+	if msg.GetSpecVersion() != SpecVersion {
+		return Event{}, internal.NewErrorf(internal.ErrorCodeInvalidArgument, "unsupported spec version %q",
+			msg.GetSpecVersion())
+	}
+
+	var task eventspb.Task
+
+	if err := proto.Unmarshal(msg.GetBinaryData(), &task); err != nil {
+		return Event{}, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "proto.Unmarshal")
+	}
+
+	// Unknown attributes are ignored.
+	attrs := msg.GetAttributes()
+
+	return Event{
+		ID:            msg.GetId(),
+		Source:        msg.GetSource(),
+		Type:          msg.GetType(),
+		Time:          newTime(attrs["time"].GetCeTimestamp()),
+		SchemaVersion: int(attrs["schemaversion"].GetCeInteger()),
+		TraceParent:   attrs["traceparent"].GetCeString(),
+		TraceState:    attrs["tracestate"].GetCeString(),
+		Task:          newTask(&task),
+	}, nil
+}
+
+func stringAttribute(v string) *eventspb.CloudEventAttributeValue {
+	return &eventspb.CloudEventAttributeValue{
+		Attr: &eventspb.CloudEventAttributeValue_CeString{CeString: v},
+	}
+}
+
+func newTaskMessage(task internal.Task) *eventspb.Task {
+	res := eventspb.Task{
+		Id:          task.ID,
+		Description: task.Description,
+		Priority:    int32(task.Priority),
+		StartDate:   newTimestamp(task.Dates.Start),
+		DueDate:     newTimestamp(task.Dates.Due),
+		IsDone:      task.IsDone,
+		Version:     task.Version,
+	}
+
+	for _, sub := range task.SubTasks {
+		res.SubTasks = append(res.SubTasks, newTaskMessage(sub))
+	}
+
+	for _, category := range task.Categories {
+		res.Categories = append(res.Categories, string(category))
+	}
+
+	return &res
+}
+
+func newTask(msg *eventspb.Task) internal.Task {
+	res := internal.Task{
+		ID:          msg.GetId(),
+		Description: msg.GetDescription(),
+		Priority:    internal.Priority(msg.GetPriority()),
+		Dates: internal.Dates{
+			Start: newTime(msg.GetStartDate()),
+			Due:   newTime(msg.GetDueDate()),
+		},
+		IsDone:  msg.GetIsDone(),
+		Version: msg.GetVersion(),
+	}
+
+	for _, sub := range msg.GetSubTasks() {
+		res.SubTasks = append(res.SubTasks, newTask(sub))
+	}
+
+	for _, category := range msg.GetCategories() {
+		res.Categories = append(res.Categories, internal.Category(category))
+	}
+
+	return res
+}
+
+// newTimestamp returns nil for zero values, so they are omitted.
+func newTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
+
+// newTime returns the zero value for missing timestamps.
+func newTime(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+
+	return ts.AsTime()
+}
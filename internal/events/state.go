@@ -0,0 +1,58 @@
+package events
+
+import (
+	"time"
+
+	"github.com/MarioCarrion/todo-api/internal"
+)
+
+// State is the state of a task rebuilt by folding its events, in the order they happened.
+type State struct {
+	// Task is the last created or updated task, only its ID and Version are set when it was deleted.
+	Task internal.Task
+	// Exists indicates the task was created, or updated, and not deleted afterwards.
+	Exists bool
+	// Events is the number of events folded.
+	Events int
+	// UpdatedAt is the time of the last event folded, zero for version 0 events.
+	UpdatedAt time.Time
+}
+
+// Apply returns the state after folding evt, events for other tasks are ignored once the state includes a task;
+// so are the ones with a version older than, or as old as, the task's.
+func (s State) Apply(evt Event) State {
+	if s.Task.ID != "" && evt.Task.ID != s.Task.ID {
+		return s
+	}
+
+	if evt.Task.Version != 0 && evt.Task.Version <= s.Task.Version {
+		return s
+	}
+
+	switch evt.Type {
+	case TypeTaskCreated, TypeTaskUpdated:
+		s.Task = evt.Task
+		s.Exists = true
+	case TypeTaskDeleted:
+		s.Task = internal.Task{ID: evt.Task.ID, Version: evt.Task.Version}
+		s.Exists = false
+	default:
+		return s
+	}
+
+	s.Events++
+	s.UpdatedAt = evt.Time
+
+	return s
+}
+
+// Fold folds the events, in the order they are received, into the state of the task.
+func Fold(evts []Event) State {
+	var res State
+
+	for _, evt := range evts {
+		res = res.Apply(evt)
+	}
+
+	return res
+}
@@ -0,0 +1,129 @@
+package events_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/events"
+)
+
+func TestFold(t *testing.T) {
+	t.Parallel()
+
+	created := events.Event{
+		Type: events.TypeTaskCreated,
+		Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Task: internal.Task{ID: "1", Description: "created"},
+	}
+
+	updated := events.Event{
+		Type: events.TypeTaskUpdated,
+		Time: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		Task: internal.Task{ID: "1", Description: "updated", IsDone: true},
+	}
+
+	deleted := events.Event{
+		Type: events.TypeTaskDeleted,
+		Time: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+		Task: internal.Task{ID: "1"},
+	}
+
+	versioned := func(evt events.Event, version int64) events.Event {
+		evt.Task.Version = version
+
+		return evt
+	}
+
+	other := events.Event{
+		Type: events.TypeTaskUpdated,
+		Time: time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC),
+		Task: internal.Task{ID: "2", Description: "other"},
+	}
+
+	tests := []struct {
+		name     string
+		input    []events.Event
+		expected events.State
+	}{
+		{
+			"OK: no events",
+			nil,
+			events.State{},
+		},
+		{
+			"OK: updated",
+			[]events.Event{created, updated},
+			events.State{
+				Task:      updated.Task,
+				Exists:    true,
+				Events:    2,
+				UpdatedAt: updated.Time,
+			},
+		},
+		{
+			"OK: deleted",
+			[]events.Event{created, updated, deleted},
+			events.State{
+				Task:      internal.Task{ID: "1"},
+				Events:    3,
+				UpdatedAt: deleted.Time,
+			},
+		},
+		{
+			"OK: outdated versions ignored",
+			[]events.Event{versioned(created, 1), versioned(deleted, 3), versioned(updated, 2)},
+			events.State{
+				Task:      internal.Task{ID: "1", Version: 3},
+				Events:    2,
+				UpdatedAt: deleted.Time,
+			},
+		},
+		{
+			"OK: redelivered versions ignored",
+			[]events.Event{versioned(created, 1), versioned(updated, 2), versioned(updated, 2)},
+			events.State{
+				Task:      versioned(updated, 2).Task,
+				Exists:    true,
+				Events:    2,
+				UpdatedAt: updated.Time,
+			},
+		},
+		{
+			"OK: other tasks ignored",
+			[]events.Event{created, other},
+			events.State{
+				Task:      created.Task,
+				Exists:    true,
+				Events:    1,
+				UpdatedAt: created.Time,
+			},
+		},
+		{
+			"OK: unknown types ignored",
+			[]events.Event{created, {Type: "tasks.event.unknown", Task: internal.Task{ID: "1"}}},
+			events.State{
+				Task:      created.Task,
+				Exists:    true,
+				Events:    1,
+				UpdatedAt: created.Time,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual := events.Fold(tt.input)
+
+			if !cmp.Equal(tt.expected, actual) {
+				t.Fatalf("expected result does not match: %s", cmp.Diff(tt.expected, actual))
+			}
+		})
+	}
+}
@@ -0,0 +1,7 @@
+
+$9c1a4a3e-8f57-4e63-a1a4-0a3f8c2b6d10/todo-api/tasks1.0"tasks.event.updated*)
+datacontenttypeapplication/protobuf*
+schemaversion*
+time:	܆2
+$2f7a1de2-0e43-4a4b-93bb-5c1bc5df6a61buy groceries"*ҍ0:.
+$9e0b8a42-3f1b-4b2c-8f5e-2d4c6a7b8c9dmilkBhomeBerrands
\ No newline at end of file
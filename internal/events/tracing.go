@@ -0,0 +1,54 @@
+package events
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// Inject sets the W3C Trace Context in ctx to the event, using the "traceparent" and "tracestate" attributes
+// defined by the CloudEvents Distributed Tracing extension. It's meant for brokers that don't support headers,
+// like Redis Streams, the rest of them should use headers instead.
+func Inject(ctx context.Context, evt *Event) {
+	otel.GetTextMapPropagator().Inject(ctx, NewCarrier(evt))
+}
+
+// Extract returns a copy of ctx including the W3C Trace Context in the event.
+func Extract(ctx context.Context, evt Event) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, NewCarrier(&evt))
+}
+
+// NewCarrier returns the carrier used by Inject and Extract for reading and writing the Trace Context of the event.
+func NewCarrier(evt *Event) propagation.TextMapCarrier {
+	return carrier{evt: evt}
+}
+
+// carrier implements "propagation.TextMapCarrier", keys other than the ones used by Trace Context are ignored.
+type carrier struct {
+	evt *Event
+}
+
+func (c carrier) Get(key string) string {
+	switch key {
+	case "traceparent":
+		return c.evt.TraceParent
+	case "tracestate":
+		return c.evt.TraceState
+	}
+
+	return ""
+}
+
+func (c carrier) Set(key, value string) {
+	switch key {
+	case "traceparent":
+		c.evt.TraceParent = value
+	case "tracestate":
+		c.evt.TraceState = value
+	}
+}
+
+func (c carrier) Keys() []string {
+	return []string{"traceparent", "tracestate"}
+}
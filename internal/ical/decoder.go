@@ -0,0 +1,254 @@
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MarioCarrion/todo-api/internal"
+)
+
+// Decode reads the to-dos, VTODO, and events, VEVENT, of the iCalendar object as the parameters used for importing
+// Tasks:
+//
+//   - The description is the summary.
+//   - The start date is "DTSTART", the due date is "DUE" for to-dos and "DTEND" for events.
+//   - The priority uses the same scale used by Encode: 1 to 4 are "high", 5 is "medium" and 6 to 9 are "low"; 0 or
+//     undefined are "none".
+//   - To-dos with the "COMPLETED" status are done.
+//
+// Dates without time zone are read using UTC. The parameters are not validated.
+func Decode(r io.Reader) ([]internal.ImportParams, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		res   []internal.ImportParams
+		stack []string
+		cur   *internal.ImportParams
+	)
+
+	for i, line := range lines {
+		prop, err := parseLine(line)
+		if err != nil {
+			return nil, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "line %d", i+1)
+		}
+
+		switch prop.name {
+		case "BEGIN":
+			component := strings.ToUpper(prop.value)
+
+			if len(stack) == 0 && component != "VCALENDAR" {
+				return nil, internal.NewErrorf(internal.ErrorCodeInvalidArgument, "line %d: expected VCALENDAR", i+1)
+			}
+
+			stack = append(stack, component)
+
+			if len(stack) == 2 && (component == string(ComponentTodo) || component == string(ComponentEvent)) {
+				cur = &internal.ImportParams{}
+			}
+
+			continue
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1] != strings.ToUpper(prop.value) {
+				return nil, internal.NewErrorf(internal.ErrorCodeInvalidArgument, "line %d: unexpected END", i+1)
+			}
+
+			if len(stack) == 2 && cur != nil {
+				res = append(res, *cur)
+				cur = nil
+			}
+
+			stack = stack[:len(stack)-1]
+
+			continue
+		}
+
+		// Properties of nested components, like alarms, are ignored.
+		if cur == nil || len(stack) != 2 {
+			continue
+		}
+
+		if err := setProperty(cur, Component(stack[1]), prop); err != nil {
+			return nil, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "line %d", i+1)
+		}
+	}
+
+	if len(stack) != 0 {
+		return nil, internal.NewErrorf(internal.ErrorCodeInvalidArgument, "missing END:%s", stack[len(stack)-1])
+	}
+
+	return res, nil
+}
+
+func setProperty(params *internal.ImportParams, component Component, prop property) error {
+	var err error
+
+	switch prop.name {
+	case "SUMMARY":
+		params.Description = unescapeText(prop.value)
+	case "DTSTART":
+		params.Dates.Start, err = parseDate(prop)
+	case "DUE":
+		if component == ComponentTodo {
+			params.Dates.Due, err = parseDate(prop)
+		}
+	case "DTEND":
+		if component == ComponentEvent {
+			params.Dates.Due, err = parseDate(prop)
+		}
+	case "PRIORITY":
+		params.Priority, err = parsePriority(prop.value)
+	case "STATUS":
+		params.IsDone = component == ComponentTodo && strings.EqualFold(prop.value, "COMPLETED")
+	}
+
+	return err
+}
+
+// property is a content line: name *(";" param) ":" value.
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// parseLine parses the unfolded content line, parameter values may be quoted and include ":" and ";".
+func parseLine(line string) (property, error) {
+	var (
+		parts  []string
+		start  int
+		quoted bool
+		end    = -1
+	)
+
+	for i := 0; i < len(line) && end == -1; i++ {
+		switch line[i] {
+		case '"':
+			quoted = !quoted
+		case ';':
+			if !quoted {
+				parts = append(parts, line[start:i])
+				start = i + 1
+			}
+		case ':':
+			if !quoted {
+				parts = append(parts, line[start:i])
+				end = i
+			}
+		}
+	}
+
+	if end == -1 || parts[0] == "" {
+		return property{}, internal.NewErrorf(internal.ErrorCodeInvalidArgument, "invalid content line")
+	}
+
+	prop := property{
+		name:   strings.ToUpper(parts[0]),
+		params: make(map[string]string, len(parts)-1),
+		value:  line[end+1:],
+	}
+
+	for _, param := range parts[1:] {
+		key, val, ok := strings.Cut(param, "=")
+		if !ok {
+			return property{}, internal.NewErrorf(internal.ErrorCodeInvalidArgument, "invalid parameter %q", param)
+		}
+
+		prop.params[strings.ToUpper(key)] = strings.Trim(val, `"`)
+	}
+
+	return prop, nil
+}
+
+// unfold returns the content lines, joining the ones folded using a leading space or tab; empty lines are skipped.
+func unfold(r io.Reader) ([]string, error) {
+	var res []string
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(res) > 0 {
+			res[len(res)-1] += line[1:]
+
+			continue
+		}
+
+		if line != "" {
+			res = append(res, line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "scanner.Scan")
+	}
+
+	return res, nil
+}
+
+// parseDate parses DATE and DATE-TIME values, using the location indicated by "TZID" when the value is not UTC.
+func parseDate(prop property) (time.Time, error) {
+	loc := time.UTC
+
+	if tzid, ok := prop.params["TZID"]; ok {
+		val, err := time.LoadLocation(strings.TrimPrefix(tzid, "/"))
+		if err != nil {
+			return time.Time{}, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "unknown TZID %q", tzid)
+		}
+
+		loc = val
+	}
+
+	layout := "20060102T150405"
+
+	switch {
+	case strings.EqualFold(prop.params["VALUE"], "DATE") || len(prop.value) == len(dateLayout):
+		layout = dateLayout
+	case strings.HasSuffix(prop.value, "Z"):
+		layout = dateTimeLayout
+		loc = time.UTC
+	}
+
+	res, err := time.ParseInLocation(layout, prop.value, loc)
+	if err != nil {
+		return time.Time{}, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "invalid %s", prop.name)
+	}
+
+	return res, nil
+}
+
+func parsePriority(val string) (internal.Priority, error) {
+	priority, err := strconv.Atoi(val)
+	if err != nil || priority < 0 || priority > 9 {
+		return internal.PriorityNone, internal.NewErrorf(internal.ErrorCodeInvalidArgument, "invalid PRIORITY")
+	}
+
+	switch {
+	case priority == 0:
+		return internal.PriorityNone, nil
+	case priority <= 4:
+		return internal.PriorityHigh, nil
+	case priority == 5:
+		return internal.PriorityMedium, nil
+	}
+
+	return internal.PriorityLow, nil
+}
+
+// unescapeText reverts escapeText, both "\n" and "\N" are line breaks.
+func unescapeText(s string) string {
+	return strings.NewReplacer(
+		`\\`, `\`,
+		`\;`, ";",
+		`\,`, ",",
+		`\n`, "\n",
+		`\N`, "\n",
+	).Replace(s)
+}
@@ -0,0 +1,155 @@
+package ical_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/ical"
+)
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("couldn't load location %s", err)
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected []internal.ImportParams
+		withErr  bool
+	}{
+		{
+			"OK",
+			strings.Join([]string{
+				"BEGIN:VCALENDAR",
+				"VERSION:2.0",
+				"BEGIN:VTIMEZONE",
+				"TZID:America/New_York",
+				"END:VTIMEZONE",
+				"BEGIN:VTODO",
+				"UID:1@example.com",
+				`SUMMARY:Pay rent\; then\, call\nlandlord`,
+				"DTSTART:20261002T070000Z",
+				"DUE;TZID=America/New_York:20261003T090000",
+				"PRIORITY:3",
+				"STATUS:COMPLETED",
+				"BEGIN:VALARM",
+				"SUMMARY:Alarm",
+				"END:VALARM",
+				"END:VTODO",
+				"BEGIN:VEVENT",
+				"UID:2@example.com",
+				"SUMMARY:All",
+				"  day",
+				"DTSTART;VALUE=DATE:20261005",
+				"DTEND;VALUE=DATE:20261006",
+				"PRIORITY:5",
+				"STATUS:CONFIRMED",
+				"END:VEVENT",
+				"BEGIN:VEVENT",
+				"SUMMARY:No priority",
+				"DTSTART:20261007T100000",
+				"END:VEVENT",
+				"END:VCALENDAR",
+			}, "\r\n"),
+			[]internal.ImportParams{
+				{
+					CreateParams: internal.CreateParams{
+						Description: "Pay rent; then, call\nlandlord",
+						Priority:    internal.PriorityHigh,
+						Dates: internal.Dates{
+							Start: time.Date(2026, 10, 2, 7, 0, 0, 0, time.UTC),
+							Due:   time.Date(2026, 10, 3, 9, 0, 0, 0, newYork),
+						},
+					},
+					IsDone: true,
+				},
+				{
+					CreateParams: internal.CreateParams{
+						Description: "All day",
+						Priority:    internal.PriorityMedium,
+						Dates: internal.Dates{
+							Start: time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC),
+							Due:   time.Date(2026, 10, 6, 0, 0, 0, 0, time.UTC),
+						},
+					},
+				},
+				{
+					CreateParams: internal.CreateParams{
+						Description: "No priority",
+						Dates: internal.Dates{
+							Start: time.Date(2026, 10, 7, 10, 0, 0, 0, time.UTC),
+						},
+					},
+				},
+			},
+			false,
+		},
+		{
+			"ERR: not a calendar",
+			"BEGIN:VTODO\nEND:VTODO\n",
+			nil,
+			true,
+		},
+		{
+			"ERR: missing END",
+			"BEGIN:VCALENDAR\nBEGIN:VTODO\nSUMMARY:Open\nEND:VCALENDAR\n",
+			nil,
+			true,
+		},
+		{
+			"ERR: invalid content line",
+			"BEGIN:VCALENDAR\nBEGIN:VTODO\nSUMMARY\nEND:VTODO\nEND:VCALENDAR\n",
+			nil,
+			true,
+		},
+		{
+			"ERR: invalid date",
+			"BEGIN:VCALENDAR\nBEGIN:VTODO\nDUE:2026-10-03\nEND:VTODO\nEND:VCALENDAR\n",
+			nil,
+			true,
+		},
+		{
+			"ERR: unknown TZID",
+			"BEGIN:VCALENDAR\nBEGIN:VTODO\nDUE;TZID=Mars/Olympus:20261003T090000\nEND:VTODO\nEND:VCALENDAR\n",
+			nil,
+			true,
+		},
+		{
+			"ERR: invalid priority",
+			"BEGIN:VCALENDAR\nBEGIN:VTODO\nPRIORITY:10\nEND:VTODO\nEND:VCALENDAR\n",
+			nil,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := ical.Decode(strings.NewReader(tt.input))
+			if (err != nil) != tt.withErr {
+				t.Fatalf("expected error %t, got %s", tt.withErr, err)
+			}
+
+			var ierr *internal.Error
+			if tt.withErr && (!errors.As(err, &ierr) || ierr.Code() != internal.ErrorCodeInvalidArgument) {
+				t.Fatalf("expected invalid argument error, got %s", err)
+			}
+
+			if !cmp.Equal(tt.expected, actual) {
+				t.Fatalf("expected result does not match: %s", cmp.Diff(tt.expected, actual))
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+// Package ical implements encoding and decoding Tasks using iCalendar, RFC 5545.
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/MarioCarrion/todo-api/internal"
+)
+
+const (
+	// ContentType is the media type of iCalendar objects.
+	ContentType = "text/calendar; charset=utf-8"
+
+	// dateTimeLayout is the DATE-TIME form using UTC time, for example "20060102T150405Z".
+	dateTimeLayout = "20060102T150405Z"
+
+	// dateLayout is the DATE value type, for example "20060102".
+	dateLayout = "20060102"
+
+	// maxLineLength is the maximum number of octets of each line, excluding the line break; longer lines are folded.
+	maxLineLength = 75
+
+	// uidDomain is the right-hand side of the UIDs of the encoded Tasks.
+	uidDomain = "todo-api"
+)
+
+// Component indicates the iCalendar component used for encoding Tasks.
+type Component string
+
+const (
+	// ComponentTodo encodes Tasks as to-dos, VTODO, including their completion status.
+	ComponentTodo Component = "VTODO"
+
+	// ComponentEvent encodes Tasks as events, VEVENT, for calendar applications not supporting to-dos.
+	ComponentEvent Component = "VEVENT"
+)
+
+// Validate ...
+func (c Component) Validate() error {
+	switch c {
+	case ComponentTodo, ComponentEvent:
+		return nil
+	}
+
+	return internal.NewErrorf(internal.ErrorCodeInvalidArgument, "unknown component")
+}
+
+// Encoder writes Tasks as iCalendar components, Close must be called after encoding all of them.
+type Encoder struct {
+	w         *bufio.Writer
+	component Component
+	now       time.Time
+	err       error
+}
+
+// NewEncoder writes the beginning of the calendar named name, now is used as the time the components are created.
+func NewEncoder(w io.Writer, name string, component Component, now time.Time) *Encoder {
+	enc := &Encoder{
+		w:         bufio.NewWriter(w),
+		component: component,
+		now:       now,
+	}
+
+	enc.writeLine("BEGIN", "VCALENDAR")
+	enc.writeLine("VERSION", "2.0")
+	enc.writeLine("PRODID", "-//todo-api//Tasks//EN")
+	enc.writeLine("CALSCALE", "GREGORIAN")
+	enc.writeLine("METHOD", "PUBLISH")
+	enc.writeLine("X-WR-CALNAME", escapeText(name))
+
+	return enc
+}
+
+// Encode writes the Task, tasks without start nor due dates are skipped.
+//
+// Priorities use the iCalendar scale where 1 is the highest: "high" is 1, "medium" is 5 and "low" is 9; "none" is
+// not written, it means undefined.
+func (e *Encoder) Encode(task internal.Task) error {
+	if task.Dates.Start.IsZero() && task.Dates.Due.IsZero() {
+		return e.err
+	}
+
+	e.writeLine("BEGIN", string(e.component))
+	e.writeLine("UID", task.ID+"@"+uidDomain)
+	e.writeLine("DTSTAMP", formatDateTime(e.now))
+
+	// SEQUENCE starts at 0, versions start at 1.
+	e.writeLine("SEQUENCE", strconv.FormatInt(max(task.Version-1, 0), 10))
+
+	if e.component == ComponentTodo {
+		e.writeTodo(task)
+	} else {
+		e.writeEvent(task)
+	}
+
+	if priority := newPriority(task.Priority); priority != 0 {
+		e.writeLine("PRIORITY", strconv.Itoa(priority))
+	}
+
+	e.writeLine("END", string(e.component))
+
+	return e.err
+}
+
+// Close writes the end of the calendar and flushes the buffered data.
+func (e *Encoder) Close() error {
+	e.writeLine("END", "VCALENDAR")
+
+	if e.err != nil {
+		return e.err
+	}
+
+	if err := e.w.Flush(); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "w.Flush")
+	}
+
+	return nil
+}
+
+func (e *Encoder) writeTodo(task internal.Task) {
+	e.writeLine("SUMMARY", escapeText(task.Description))
+
+	// "DUE" must be later than "DTSTART".
+	if !task.Dates.Start.IsZero() && (task.Dates.Due.IsZero() || task.Dates.Start.Before(task.Dates.Due)) {
+		e.writeLine("DTSTART", formatDateTime(task.Dates.Start))
+	}
+
+	if !task.Dates.Due.IsZero() {
+		e.writeLine("DUE", formatDateTime(task.Dates.Due))
+	}
+
+	if task.IsDone {
+		e.writeLine("STATUS", "COMPLETED")
+		e.writeLine("PERCENT-COMPLETE", "100")
+	} else {
+		e.writeLine("STATUS", "NEEDS-ACTION")
+	}
+}
+
+// writeEvent writes the task spanning from the start to the due date, tasks with only one of them are instant
+// events; events don't have a completion status so completed tasks are marked in the summary and don't block
+// time.
+func (e *Encoder) writeEvent(task internal.Task) {
+	summary := task.Description
+	transp := "OPAQUE"
+
+	if task.IsDone {
+		summary = "✔ " + summary
+		transp = "TRANSPARENT"
+	}
+
+	e.writeLine("SUMMARY", escapeText(summary))
+
+	start := task.Dates.Start
+	if start.IsZero() {
+		start = task.Dates.Due
+	}
+
+	e.writeLine("DTSTART", formatDateTime(start))
+
+	if task.Dates.Due.After(start) {
+		e.writeLine("DTEND", formatDateTime(task.Dates.Due))
+	}
+
+	e.writeLine("STATUS", "CONFIRMED")
+	e.writeLine("TRANSP", transp)
+}
+
+// writeLine writes the content line, folding it when it's longer than 75 octets without splitting UTF-8
+// characters.
+func (e *Encoder) writeLine(name, value string) {
+	if e.err != nil {
+		return
+	}
+
+	line := name + ":" + value
+
+	var b strings.Builder
+
+	for size := maxLineLength; len(line) > size; size = maxLineLength - 1 {
+		i := size
+
+		for i > 0 && !utf8.RuneStart(line[i]) {
+			i--
+		}
+
+		b.WriteString(line[:i])
+		b.WriteString("\r\n ")
+
+		line = line[i:]
+	}
+
+	b.WriteString(line)
+	b.WriteString("\r\n")
+
+	if _, err := e.w.WriteString(b.String()); err != nil {
+		e.err = internal.WrapErrorf(err, internal.ErrorCodeUnknown, "w.WriteString")
+	}
+}
+
+func formatDateTime(t time.Time) string {
+	return t.UTC().Format(dateTimeLayout)
+}
+
+// escapeText escapes the TEXT values, line breaks are written as "\n".
+func escapeText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", "",
+	).Replace(s)
+}
+
+func newPriority(p internal.Priority) int {
+	switch p {
+	case internal.PriorityNone:
+		return 0
+	case internal.PriorityHigh:
+		return 1
+	case internal.PriorityMedium:
+		return 5
+	case internal.PriorityLow:
+		return 9
+	}
+
+	return 0
+}
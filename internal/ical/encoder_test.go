@@ -0,0 +1,194 @@
+package ical_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/ical"
+)
+
+func TestEncoder_Encode(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	start := time.Date(2026, 10, 2, 9, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	due := time.Date(2026, 10, 3, 17, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		component ical.Component
+		input     internal.Task
+		expected  []string
+	}{
+		{
+			"OK: todo",
+			ical.ComponentTodo,
+			internal.Task{
+				ID:          "1",
+				Description: "Pay rent; then, call\nlandlord",
+				Priority:    internal.PriorityHigh,
+				Dates:       internal.Dates{Start: start, Due: due},
+				Version:     3,
+			},
+			[]string{
+				"BEGIN:VTODO",
+				"UID:1@todo-api",
+				"DTSTAMP:20261001T080000Z",
+				"SEQUENCE:2",
+				`SUMMARY:Pay rent\; then\, call\nlandlord`,
+				"DTSTART:20261002T070000Z",
+				"DUE:20261003T173000Z",
+				"STATUS:NEEDS-ACTION",
+				"PRIORITY:1",
+				"END:VTODO",
+			},
+		},
+		{
+			"OK: todo done",
+			ical.ComponentTodo,
+			internal.Task{
+				ID:          "2",
+				Description: "Done",
+				Priority:    internal.PriorityLow,
+				Dates:       internal.Dates{Due: due},
+				IsDone:      true,
+				Version:     1,
+			},
+			[]string{
+				"BEGIN:VTODO",
+				"UID:2@todo-api",
+				"DTSTAMP:20261001T080000Z",
+				"SEQUENCE:0",
+				"SUMMARY:Done",
+				"DUE:20261003T173000Z",
+				"STATUS:COMPLETED",
+				"PERCENT-COMPLETE:100",
+				"PRIORITY:9",
+				"END:VTODO",
+			},
+		},
+		{
+			"OK: event",
+			ical.ComponentEvent,
+			internal.Task{
+				ID:          "3",
+				Description: "Meeting",
+				Priority:    internal.PriorityMedium,
+				Dates:       internal.Dates{Start: start, Due: due},
+				IsDone:      true,
+				Version:     1,
+			},
+			[]string{
+				"BEGIN:VEVENT",
+				"UID:3@todo-api",
+				"DTSTAMP:20261001T080000Z",
+				"SEQUENCE:0",
+				"SUMMARY:✔ Meeting",
+				"DTSTART:20261002T070000Z",
+				"DTEND:20261003T173000Z",
+				"STATUS:CONFIRMED",
+				"TRANSP:TRANSPARENT",
+				"PRIORITY:5",
+				"END:VEVENT",
+			},
+		},
+		{
+			"OK: event without start",
+			ical.ComponentEvent,
+			internal.Task{
+				ID:          "4",
+				Description: "Deadline",
+				Dates:       internal.Dates{Due: due},
+				Version:     1,
+			},
+			[]string{
+				"BEGIN:VEVENT",
+				"UID:4@todo-api",
+				"DTSTAMP:20261001T080000Z",
+				"SEQUENCE:0",
+				"SUMMARY:Deadline",
+				"DTSTART:20261003T173000Z",
+				"STATUS:CONFIRMED",
+				"TRANSP:OPAQUE",
+				"END:VEVENT",
+			},
+		},
+		{
+			"OK: skipped without dates",
+			ical.ComponentTodo,
+			internal.Task{
+				ID:          "5",
+				Description: "Someday",
+				Priority:    internal.PriorityLow,
+			},
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+
+			enc := ical.NewEncoder(&buf, "Tasks", tt.component, now)
+
+			if err := enc.Encode(tt.input); err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			if err := enc.Close(); err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			header := []string{
+				"BEGIN:VCALENDAR",
+				"VERSION:2.0",
+				"PRODID:-//todo-api//Tasks//EN",
+				"CALSCALE:GREGORIAN",
+				"METHOD:PUBLISH",
+				"X-WR-CALNAME:Tasks",
+			}
+
+			expected := append(append(header, tt.expected...), "END:VCALENDAR", "")
+
+			if actual := strings.Split(buf.String(), "\r\n"); !cmp.Equal(expected, actual) {
+				t.Fatalf("expected lines don't match: %s", cmp.Diff(expected, actual))
+			}
+		})
+	}
+}
+
+func TestEncoder_Encode_Folding(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	description := strings.Repeat("á", 100)
+
+	enc := ical.NewEncoder(&buf, "Tasks", ical.ComponentTodo, time.Now())
+	_ = enc.Encode(internal.Task{ID: "1", Description: description, Dates: internal.Dates{Due: time.Now()}})
+	_ = enc.Close()
+
+	for _, line := range strings.Split(buf.String(), "\r\n") {
+		if len(line) > 75 {
+			t.Fatalf("expected lines up to 75 octets, got %d: %s", len(line), line)
+		}
+	}
+
+	params, err := ical.Decode(&buf)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if len(params) != 1 || params[0].Description != description {
+		t.Fatalf("expected folded summary to be decoded, got %v", params)
+	}
+}
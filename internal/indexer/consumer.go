@@ -0,0 +1,176 @@
+// Package indexer implements the consumer indexing the Task events published to any of the supported message
+// brokers, each of them implements Source.
+package indexer
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/events"
+	"github.com/MarioCarrion/todo-api/internal/worker"
+)
+
+const otelName = "github.com/MarioCarrion/todo-api/internal/indexer"
+
+// BatchIndexer defines the indexer writing the events in batches, like "elasticsearch.Indexer".
+type BatchIndexer interface {
+	Start()
+	Close(ctx context.Context) error
+	Index(ctx context.Context, task internal.Task, ack func(), nack func(err error)) error
+	Delete(ctx context.Context, id string, version int64, ack func(), nack func(err error)) error
+	Skip(ack func())
+}
+
+// Consumer indexes the Task events received from a Source, all the sources share the same semantics:
+//
+//   - Events are acknowledged after the batch including them is indexed,
+//   - Events failing to be indexed are retried by the Source, or indexed again in the next flush when the Source
+//     can't redeliver individual messages,
+//   - Poison messages, the ones that can't be decoded or that have an unknown type, are dead-lettered and then
+//     acknowledged in order with the rest of the events.
+type Consumer struct {
+	logger  *zap.Logger
+	name    string
+	source  Source
+	indexer BatchIndexer
+	attrs   metric.MeasurementOption
+
+	received     metric.Int64Counter
+	acked        metric.Int64Counter
+	retried      metric.Int64Counter
+	deadLettered metric.Int64Counter
+
+	lifecycle *worker.Lifecycle
+}
+
+// NewConsumer instantiates the Consumer, name identifies the Source in the metrics.
+func NewConsumer(logger *zap.Logger, name string, source Source, indexer BatchIndexer) *Consumer {
+	meter := otel.Meter(otelName)
+
+	return &Consumer{
+		logger:       logger,
+		name:         name,
+		source:       source,
+		indexer:      indexer,
+		attrs:        metric.WithAttributes(attribute.String("source", name)),
+		received:     worker.NewCounter(meter, "indexer.received", "Messages received"),
+		acked:        worker.NewCounter(meter, "indexer.acked", "Messages acknowledged"),
+		retried:      worker.NewCounter(meter, "indexer.retried", "Messages failing to be indexed"),
+		deadLettered: worker.NewCounter(meter, "indexer.dead_lettered", "Messages that can't be indexed"),
+		lifecycle:    worker.NewLifecycle(),
+	}
+}
+
+// ListenAndServe consumes the Source until Shutdown is called.
+func (c *Consumer) ListenAndServe() error {
+	defer c.lifecycle.Start()()
+
+	c.indexer.Start()
+
+	if err := c.source.Consume(c.lifecycle.Context(), c.handle); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "source.Consume")
+	}
+
+	c.logger.Info("No more messages to consume. Exiting.")
+
+	return nil
+}
+
+// Shutdown stops consuming the Source, and indexes the events already received.
+func (c *Consumer) Shutdown(ctx context.Context) error {
+	c.logger.Info("Shutting down consumer")
+
+	// Not consuming yet, the indexer was not started either.
+	running := c.lifecycle.Running()
+
+	if err := c.lifecycle.Stop(ctx); err != nil || !running {
+		return err
+	}
+
+	if err := c.indexer.Close(ctx); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "indexer.Close")
+	}
+
+	return nil
+}
+
+// Health indicates whether the Consumer is consuming the Source.
+func (c *Consumer) Health(ctx context.Context) error {
+	if err := c.lifecycle.Health(); err != nil {
+		return err
+	}
+
+	if err := c.source.Ping(ctx); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "source.Ping")
+	}
+
+	return nil
+}
+
+func (c *Consumer) handle(ctx context.Context, msg Message) {
+	c.received.Add(ctx, 1, c.attrs)
+
+	c.logger.Info("Received message", zap.String("id", msg.ID), zap.String("type", msg.Type))
+
+	ack := func() {
+		c.acked.Add(context.Background(), 1, c.attrs)
+
+		msg.Ack()
+	}
+
+	var nack func(err error)
+
+	if msg.Retry != nil {
+		nack = func(err error) {
+			c.retried.Add(context.Background(), 1, c.attrs)
+
+			msg.Retry(err)
+		}
+	}
+
+	evt, err := events.Decode(msg.Type, msg.ContentType, msg.Data)
+	if err != nil {
+		c.deadLetter(ctx, msg, ack, err)
+
+		return
+	}
+
+	// The trace started by the request publishing the event continues while indexing it, sources not supporting
+	// headers include it in the event instead.
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		ctx = events.Extract(ctx, evt)
+	}
+
+	switch evt.Type {
+	case events.TypeTaskUpdated, events.TypeTaskCreated:
+		err = c.indexer.Index(ctx, evt.Task, ack, nack)
+	case events.TypeTaskDeleted:
+		err = c.indexer.Delete(ctx, evt.Task.ID, evt.Task.Version, ack, nack)
+	case events.TypeTaskDueSoon, events.TypeTaskOverdue:
+		// Reminders don't change the task, there's nothing to index.
+		c.indexer.Skip(ack)
+	default:
+		c.deadLetter(ctx, msg, ack, internal.NewErrorf(internal.ErrorCodeInvalidArgument,
+			"unknown event type %q", evt.Type))
+
+		return
+	}
+
+	if err != nil {
+		// The events in the batch were retried, or kept for the next flush.
+		c.logger.Error("Couldn't index batch", zap.Error(err))
+	}
+}
+
+// deadLetter dead-letters the message, it's acknowledged in order with the rest of the events.
+func (c *Consumer) deadLetter(ctx context.Context, msg Message, ack func(), cause error) {
+	if DeadLetter(ctx, c.logger, c.deadLettered, msg, cause, c.attrs) {
+		c.indexer.Skip(ack)
+	}
+}
@@ -0,0 +1,352 @@
+package indexer_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/events"
+	"github.com/MarioCarrion/todo-api/internal/indexer"
+)
+
+func TestConsumer(t *testing.T) {
+	t.Parallel()
+
+	created := newMessage(t, events.TypeTaskCreated, internal.Task{ID: "1", Description: "created"})
+	deleted := newMessage(t, events.TypeTaskDeleted, internal.Task{ID: "2"})
+	unknown := newMessage(t, "tasks.event.unknown", internal.Task{ID: "3"})
+	dueSoon := newMessage(t, events.TypeTaskDueSoon, internal.Task{ID: "4"})
+
+	invalid := indexer.Message{ID: "invalid", ContentType: events.ContentTypeJSON, Data: []byte("{")}
+
+	tests := []struct {
+		name             string
+		msgs             []indexer.Message
+		deadLetterErr    error
+		withoutRetry     bool
+		expected         []string
+		expectedAcked    []string
+		expectedRetried  []string
+		expectedDeadLets []string
+	}{
+		{
+			"OK",
+			[]indexer.Message{created, deleted},
+			nil,
+			false,
+			[]string{"index 1", "delete 2"},
+			[]string{created.ID, deleted.ID},
+			nil,
+			nil,
+		},
+		{
+			"OK: poison messages",
+			[]indexer.Message{invalid, created, unknown},
+			nil,
+			false,
+			[]string{"skip", "index 1", "skip"},
+			[]string{invalid.ID, created.ID, unknown.ID},
+			nil,
+			[]string{invalid.ID, unknown.ID},
+		},
+		{
+			"OK: reminders",
+			[]indexer.Message{dueSoon, created},
+			nil,
+			false,
+			[]string{"skip", "index 1"},
+			[]string{dueSoon.ID, created.ID},
+			nil,
+			nil,
+		},
+		{
+			"OK: retried",
+			[]indexer.Message{created, deleted},
+			nil,
+			false,
+			[]string{"index 1", "delete 2"},
+			[]string{created.ID},
+			[]string{deleted.ID},
+			nil,
+		},
+		{
+			"OK: retry not supported",
+			[]indexer.Message{deleted},
+			nil,
+			true,
+			[]string{"delete 2"},
+			[]string{deleted.ID},
+			nil,
+			nil,
+		},
+		{
+			"ERR: dead-lettering",
+			[]indexer.Message{invalid},
+			errors.New("failed"),
+			false,
+			nil,
+			nil,
+			nil,
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := &recorder{deadLetterErr: tt.deadLetterErr}
+
+			source := newFakeSource(rec.wrap(tt.msgs, tt.withoutRetry))
+			batch := &fakeIndexer{failed: tt.expectedRetried}
+
+			consumer := indexer.NewConsumer(zap.NewNop(), "fake", source, batch)
+
+			errC := make(chan error, 1)
+
+			go func() {
+				errC <- consumer.ListenAndServe()
+			}()
+
+			<-source.consumed
+
+			if err := consumer.Shutdown(context.Background()); err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			if err := <-errC; err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			if !batch.closed {
+				t.Fatalf("expected indexer to be closed")
+			}
+
+			if !cmp.Equal(tt.expected, batch.calls) {
+				t.Fatalf("expected calls do not match: %s", cmp.Diff(tt.expected, batch.calls))
+			}
+
+			if !cmp.Equal(tt.expectedAcked, rec.acked) {
+				t.Fatalf("expected acked do not match: %s", cmp.Diff(tt.expectedAcked, rec.acked))
+			}
+
+			if !cmp.Equal(tt.expectedRetried, rec.retried) {
+				t.Fatalf("expected retried do not match: %s", cmp.Diff(tt.expectedRetried, rec.retried))
+			}
+
+			if !cmp.Equal(tt.expectedDeadLets, rec.deadLettered) {
+				t.Fatalf("expected dead-lettered do not match: %s", cmp.Diff(tt.expectedDeadLets, rec.deadLettered))
+			}
+		})
+	}
+}
+
+func TestHealthHandler(t *testing.T) {
+	t.Parallel()
+
+	source := newFakeSource(nil)
+
+	consumer := indexer.NewConsumer(zap.NewNop(), "fake", source, &fakeIndexer{})
+
+	handler := indexer.HealthHandler(consumer)
+
+	assertStatus := func(t *testing.T, expected int) {
+		t.Helper()
+
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+		if rr.Code != expected {
+			t.Fatalf("expected status %d, got %d: %s", expected, rr.Code, rr.Body.String())
+		}
+	}
+
+	assertStatus(t, http.StatusServiceUnavailable)
+
+	go func() {
+		_ = consumer.ListenAndServe()
+	}()
+
+	<-source.consumed
+
+	assertStatus(t, http.StatusOK)
+
+	source.setPingErr(errors.New("disconnected"))
+
+	assertStatus(t, http.StatusServiceUnavailable)
+
+	source.setPingErr(nil)
+
+	if err := consumer.Shutdown(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	assertStatus(t, http.StatusServiceUnavailable)
+}
+
+func newMessage(t *testing.T, typ string, task internal.Task) indexer.Message {
+	t.Helper()
+
+	evt := events.NewTaskEvent(typ, task)
+
+	b, err := events.JSON.Marshal(evt)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	return indexer.Message{
+		ID:          task.ID,
+		ContentType: events.JSON.ContentType(),
+		Data:        b,
+	}
+}
+
+// recorder records the messages acknowledged, retried and dead-lettered.
+type recorder struct {
+	mu            sync.Mutex
+	deadLetterErr error
+	acked         []string
+	retried       []string
+	deadLettered  []string
+}
+
+func (r *recorder) wrap(msgs []indexer.Message, withoutRetry bool) []indexer.Message {
+	res := make([]indexer.Message, len(msgs))
+
+	for i, msg := range msgs {
+		id := msg.ID
+
+		msg.Ack = func() { r.record(&r.acked, id) }
+
+		if !withoutRetry {
+			msg.Retry = func(error) { r.record(&r.retried, id) }
+		}
+
+		msg.DeadLetter = func(error) error {
+			if r.deadLetterErr != nil {
+				return r.deadLetterErr
+			}
+
+			r.record(&r.deadLettered, id)
+
+			return nil
+		}
+
+		res[i] = msg
+	}
+
+	return res
+}
+
+func (r *recorder) record(ids *[]string, id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	*ids = append(*ids, id)
+}
+
+func newFakeSource(msgs []indexer.Message) *fakeSource {
+	return &fakeSource{
+		msgs:     msgs,
+		consumed: make(chan struct{}),
+	}
+}
+
+// fakeSource closes consumed after handling the messages.
+type fakeSource struct {
+	msgs     []indexer.Message
+	consumed chan struct{}
+
+	mu      sync.Mutex
+	pingErr error
+}
+
+func (s *fakeSource) Consume(ctx context.Context, handler indexer.Handler) error {
+	for _, msg := range s.msgs {
+		handler(context.Background(), msg)
+	}
+
+	close(s.consumed)
+
+	<-ctx.Done()
+
+	return nil
+}
+
+func (s *fakeSource) Ping(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.pingErr
+}
+
+func (s *fakeSource) setPingErr(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pingErr = err
+}
+
+// fakeIndexer acknowledges the events when closed, the ones for the tasks in failed are nacked instead, or
+// acknowledged when they can't be nacked.
+type fakeIndexer struct {
+	failed []string
+	calls  []string
+	acks   []func()
+	closed bool
+}
+
+func (f *fakeIndexer) Start() {}
+
+func (f *fakeIndexer) Close(_ context.Context) error {
+	for _, ack := range f.acks {
+		ack()
+	}
+
+	f.closed = true
+
+	return nil
+}
+
+func (f *fakeIndexer) Index(_ context.Context, task internal.Task, ack func(), nack func(err error)) error {
+	f.calls = append(f.calls, "index "+task.ID)
+
+	f.add(task.ID, ack, nack)
+
+	return nil
+}
+
+func (f *fakeIndexer) Delete(_ context.Context, id string, _ int64, ack func(), nack func(err error)) error {
+	f.calls = append(f.calls, "delete "+id)
+
+	f.add(id, ack, nack)
+
+	return nil
+}
+
+func (f *fakeIndexer) Skip(ack func()) {
+	f.calls = append(f.calls, "skip")
+	f.acks = append(f.acks, ack)
+}
+
+func (f *fakeIndexer) add(id string, ack func(), nack func(err error)) {
+	for _, failed := range f.failed {
+		if failed == id && nack != nil {
+			f.acks = append(f.acks, func() { nack(errors.New("failed")) })
+
+			return
+		}
+	}
+
+	f.acks = append(f.acks, ack)
+}
@@ -0,0 +1,39 @@
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// HealthResponse is the response of HealthHandler.
+type HealthResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// HealthChecker defines the services reporting their health, like Consumer.
+type HealthChecker interface {
+	Health(ctx context.Context) error
+}
+
+// HealthHandler returns the handler responding with "200 OK" when the service is healthy, and
+// "503 Service Unavailable" otherwise.
+func HealthHandler(c HealthChecker) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
+		defer cancel()
+
+		res, code := HealthResponse{Status: "ok"}, http.StatusOK
+
+		if err := c.Health(ctx); err != nil {
+			res, code = HealthResponse{Status: "unavailable", Error: err.Error()}, http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+
+		_ = json.NewEncoder(w).Encode(res)
+	})
+}
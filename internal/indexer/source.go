@@ -0,0 +1,72 @@
+package indexer
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+)
+
+// Message is a Task event received from a Source.
+type Message struct {
+	// ID identifies the message in the Source, it's only used for logging.
+	ID string
+	// Type is the event type indicated by the Source, for example the RabbitMQ routing key, it's empty when the
+	// Source doesn't include it; see "events.Decode".
+	Type string
+	// ContentType is the content type received with the message, it's empty when the Source doesn't include it.
+	ContentType string
+	// Data is the encoded event.
+	Data []byte
+
+	// Ack acknowledges the message, it's called once the batch including the event is indexed, or after the
+	// message is dead-lettered; messages are acknowledged in the order they were received.
+	Ack func()
+	// Retry delivers the message again later, it's called instead of Ack when indexing the event failed. nil
+	// indicates the Source can't redeliver individual messages, the failed batch is kept and indexed again in the
+	// next flush instead.
+	Retry func(err error)
+	// DeadLetter keeps the message, for example in a dead letter queue, it's called before Ack when the message
+	// can't be indexed regardless of the number of attempts, like the ones that can't be decoded. nil indicates the
+	// Source doesn't support it, the message is only acknowledged.
+	DeadLetter func(err error) error
+}
+
+// Handler handles the messages received by a Source, ctx includes the W3C Trace Context received with the
+// message when the Source supports headers.
+type Handler func(ctx context.Context, msg Message)
+
+// Source defines the message broker the Task events are consumed from.
+type Source interface {
+	// Consume calls handler, sequentially, for each message received until ctx is done; the messages already
+	// received are handled before returning.
+	Consume(ctx context.Context, handler Handler) error
+	// Ping indicates whether the Source is connected to the message broker.
+	Ping(ctx context.Context) error
+}
+
+// DeadLetter dead-letters the message using its Source, it's meant for messages that will never be handled
+// regardless of the number of attempts, like the ones that can't be decoded; counter counts them.
+//
+// It returns false when the message couldn't be dead-lettered, it must not be acknowledged then: sources
+// redelivering messages deliver it again, and sources committing offsets, like Kafka, keep retrying instead and
+// only fail when shutting down.
+func DeadLetter(ctx context.Context, logger *zap.Logger, counter metric.Int64Counter, msg Message, cause error,
+	opts ...metric.AddOption,
+) bool {
+	counter.Add(ctx, 1, opts...)
+
+	logger.Info("Dead-lettering message", zap.String("id", msg.ID), zap.Error(cause))
+
+	if msg.DeadLetter == nil {
+		return true
+	}
+
+	if err := msg.DeadLetter(cause); err != nil {
+		logger.Error("Couldn't dead-letter message", zap.Error(err))
+
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,75 @@
+package indexer_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/metric/noop"
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api/internal/indexer"
+)
+
+func TestDeadLetter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		deadLetter    func(error) error
+		expected      bool
+		deadLettered  bool
+		withoutSource bool
+	}{
+		{
+			"OK",
+			func(error) error { return nil },
+			true,
+			true,
+			false,
+		},
+		{
+			"OK: not supported by the source",
+			nil,
+			true,
+			false,
+			true,
+		},
+		{
+			"ERR: not dead-lettered",
+			func(error) error { return errors.New("unavailable") },
+			false,
+			true,
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var cause error
+
+			msg := indexer.Message{ID: "1"}
+
+			if !tt.withoutSource {
+				msg.DeadLetter = func(err error) error {
+					cause = err
+
+					return tt.deadLetter(err)
+				}
+			}
+
+			counter, _ := noop.NewMeterProvider().Meter("test").Int64Counter("dead_lettered")
+
+			actual := indexer.DeadLetter(context.Background(), zap.NewNop(), counter, msg, errors.New("poison"))
+			if actual != tt.expected {
+				t.Fatalf("expected %t, got %t", tt.expected, actual)
+			}
+
+			if (cause != nil) != tt.deadLettered {
+				t.Fatalf("expected dead-lettered %t, got %v", tt.deadLettered, cause)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"go.opentelemetry.io/otel"
+)
+
+// Extract returns a copy of ctx including the W3C Trace Context in the message headers.
+func Extract(ctx context.Context, headers []kafka.Header) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, &headersCarrier{headers: headers})
+}
+
+// inject sets the W3C Trace Context in ctx to the message headers.
+func inject(ctx context.Context, msg *kafka.Message) {
+	carrier := headersCarrier{headers: msg.Headers}
+
+	otel.GetTextMapPropagator().Inject(ctx, &carrier)
+
+	msg.Headers = carrier.headers
+}
+
+// headersCarrier implements "propagation.TextMapCarrier" using the message headers.
+type headersCarrier struct {
+	headers []kafka.Header
+}
+
+func (c *headersCarrier) Get(key string) string {
+	for _, header := range c.headers {
+		if header.Key == key {
+			return string(header.Value)
+		}
+	}
+
+	return ""
+}
+
+func (c *headersCarrier) Set(key, value string) {
+	for i, header := range c.headers {
+		if header.Key == key {
+			c.headers[i].Value = []byte(value)
+
+			return
+		}
+	}
+
+	c.headers = append(c.headers, kafka.Header{Key: key, Value: []byte(value)})
+}
+
+func (c *headersCarrier) Keys() []string {
+	res := make([]string, len(c.headers))
+
+	for i, header := range c.headers {
+		res[i] = header.Key
+	}
+
+	return res
+}
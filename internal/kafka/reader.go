@@ -0,0 +1,197 @@
+package kafka
+
+import (
+	"context"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/events"
+)
+
+// Record is a Task event read from the topic.
+type Record struct {
+	Partition int32
+	Offset    int64
+	Timestamp time.Time
+	Key       string
+	Event     events.Event
+	// Err indicates the event couldn't be decoded, Event is zero in that case.
+	Err error
+}
+
+// ReadOptions defines the messages read by Reader, see NewReadOptions.
+type ReadOptions struct {
+	// Partition limits reading to one partition when it's not negative.
+	Partition int32
+	// From is the time of the first message read, FromOffset is used instead when it's zero and FromOffset is not
+	// negative; otherwise messages are read from the beginning, or from the end when following.
+	From       time.Time
+	FromOffset int64
+	// To is the time of the last message read, ToOffset is the last offset read when it's not negative; otherwise
+	// messages are read until the end, or until ctx is done when following.
+	To       time.Time
+	ToOffset int64
+	// Follow keeps reading the messages published after Read is called.
+	Follow bool
+}
+
+// NewReadOptions returns the options for reading all the messages in the topic.
+func NewReadOptions() ReadOptions {
+	return ReadOptions{
+		Partition:  -1,
+		FromOffset: -1,
+		ToOffset:   -1,
+	}
+}
+
+// Reader reads the Task events in a topic without being part of a consumer group, offsets are never committed.
+type Reader struct {
+	consumer *kafka.Consumer
+	topic    string
+}
+
+// NewReader instantiates the Reader, the consumer must not be subscribed to any topic.
+func NewReader(consumer *kafka.Consumer, topic string) *Reader {
+	return &Reader{
+		consumer: consumer,
+		topic:    topic,
+	}
+}
+
+// Read calls fn for each message, in the order they were published to each partition, until the messages
+// defined by opts are read or ctx is done; errors returned by fn stop reading.
+//
+// XXX: Messages are only ordered within a partition, events published before messages were keyed by task ID may
+// be in different partitions.
+//
+// nolint: cyclop
+func (r *Reader) Read(ctx context.Context, opts ReadOptions, fn func(Record) error) error {
+	partitions, err := r.assign(opts)
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "assign")
+	}
+
+	defer func() {
+		_ = r.consumer.Unassign()
+	}()
+
+	for len(partitions) > 0 && ctx.Err() == nil {
+		switch evt := r.consumer.Poll(100).(type) {
+		case *kafka.Message:
+			if err := evt.TopicPartition.Error; err != nil {
+				return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "consumer.Poll")
+			}
+
+			end, ok := partitions[evt.TopicPartition.Partition]
+			if !ok {
+				continue
+			}
+
+			offset := int64(evt.TopicPartition.Offset)
+
+			// XXX: Timestamps are set by the producer, messages published after a later one may be skipped.
+			if (opts.ToOffset >= 0 && offset > opts.ToOffset) || (!opts.To.IsZero() && evt.Timestamp.After(opts.To)) {
+				delete(partitions, evt.TopicPartition.Partition)
+
+				continue
+			}
+
+			record := Record{
+				Partition: evt.TopicPartition.Partition,
+				Offset:    offset,
+				Timestamp: evt.Timestamp,
+				Key:       string(evt.Key),
+			}
+
+			record.Event, record.Err = events.Decode("", header(evt, "content-type"), evt.Value)
+
+			if err := fn(record); err != nil {
+				return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "fn")
+			}
+
+			if !opts.Follow && offset >= end-1 {
+				delete(partitions, evt.TopicPartition.Partition)
+			}
+		case kafka.Error:
+			if evt.IsFatal() {
+				return internal.WrapErrorf(evt, internal.ErrorCodeUnknown, "consumer.Poll")
+			}
+		}
+	}
+
+	return nil
+}
+
+// assign assigns the partitions starting at the offsets defined by opts, it returns the partitions with messages
+// to read and their end offsets, the offsets after the last message when assigning them.
+func (r *Reader) assign(opts ReadOptions) (map[int32]int64, error) {
+	const timeout = 5_000
+
+	metadata, err := r.consumer.GetMetadata(&r.topic, false, timeout)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "consumer.GetMetadata")
+	}
+
+	var assigned []kafka.TopicPartition
+
+	res := make(map[int32]int64)
+
+	for _, partition := range metadata.Topics[r.topic].Partitions {
+		if opts.Partition >= 0 && partition.ID != opts.Partition {
+			continue
+		}
+
+		low, high, err := r.consumer.QueryWatermarkOffsets(r.topic, partition.ID, timeout)
+		if err != nil {
+			return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "consumer.QueryWatermarkOffsets")
+		}
+
+		start := low
+
+		switch {
+		case !opts.From.IsZero():
+			offsets, err := r.consumer.OffsetsForTimes([]kafka.TopicPartition{{
+				Topic:     &r.topic,
+				Partition: partition.ID,
+				Offset:    kafka.Offset(opts.From.UnixMilli()),
+			}}, timeout)
+			if err != nil {
+				return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "consumer.OffsetsForTimes")
+			}
+
+			// Negative when there are no messages after From.
+			start = high
+			if len(offsets) == 1 && offsets[0].Offset >= 0 {
+				start = int64(offsets[0].Offset)
+			}
+		case opts.FromOffset >= 0:
+			start = max(opts.FromOffset, low)
+		case opts.Follow:
+			start = high
+		}
+
+		if !opts.Follow && start >= high {
+			continue
+		}
+
+		assigned = append(assigned, kafka.TopicPartition{
+			Topic:     &r.topic,
+			Partition: partition.ID,
+			Offset:    kafka.Offset(start),
+		})
+
+		res[partition.ID] = high
+	}
+
+	if len(assigned) == 0 {
+		return res, nil
+	}
+
+	if err := r.consumer.Assign(assigned); err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "consumer.Assign")
+	}
+
+	return res, nil
+}
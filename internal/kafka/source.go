@@ -0,0 +1,155 @@
+package kafka
+
+import (
+	"context"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/indexer"
+)
+
+// HeaderError is the header of the dead-lettered messages indicating the error.
+const HeaderError = "x-error"
+
+// DeadLetterTopic returns the name of the dead letter topic of topic.
+func DeadLetterTopic(topic string) string {
+	return topic + ".dlq"
+}
+
+// Source implements "indexer.Source" using a consumer subscribed to topic, offsets are committed in order once
+// the batch including the message is indexed.
+//
+// Kafka can't redeliver individual messages, messages failing to be indexed are indexed again in the next flush;
+// poison messages are published to the dead letter topic. Publishing them is retried until it succeeds, consuming
+// stops meanwhile, because committing the offsets of the following messages would skip them.
+type Source struct {
+	logger   *zap.Logger
+	consumer *kafka.Consumer
+	producer *kafka.Producer
+	topic    string
+}
+
+// NewSource instantiates the Source, the consumer must be subscribed to topic and not commit offsets
+// automatically; producer is used for publishing to the dead letter topic.
+func NewSource(logger *zap.Logger, consumer *kafka.Consumer, producer *kafka.Producer, topic string) *Source {
+	return &Source{
+		logger:   logger,
+		consumer: consumer,
+		producer: producer,
+		topic:    topic,
+	}
+}
+
+// Consume calls handler for each message until ctx is done, or a fatal error happens; the rest of the errors are
+// logged because the client recovers from them.
+func (s *Source) Consume(ctx context.Context, handler indexer.Handler) error {
+	for ctx.Err() == nil {
+		switch evt := s.consumer.Poll(150).(type) {
+		case *kafka.Message:
+			// The trace started by the request publishing the event continues while indexing it.
+			handler(Extract(context.Background(), evt.Headers), s.message(ctx, evt))
+		case kafka.Error:
+			if evt.IsFatal() {
+				return internal.WrapErrorf(evt, internal.ErrorCodeUnknown, "consumer.Poll")
+			}
+
+			s.logger.Warn("Couldn't consume", zap.Error(evt))
+		}
+	}
+
+	return nil
+}
+
+// Ping requests the metadata of the topic.
+func (s *Source) Ping(_ context.Context) error {
+	if _, err := s.consumer.GetMetadata(&s.topic, false, 1000); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "consumer.GetMetadata")
+	}
+
+	return nil
+}
+
+func (s *Source) message(ctx context.Context, msg *kafka.Message) indexer.Message {
+	return indexer.Message{
+		ID:          msg.TopicPartition.String(),
+		ContentType: header(msg, "content-type"),
+		Data:        msg.Value,
+		Ack: func() {
+			if _, err := s.consumer.CommitMessage(msg); err != nil {
+				s.logger.Error("Couldn't commit message", zap.Error(err))
+			}
+		},
+		DeadLetter: func(cause error) error {
+			return s.deadLetter(ctx, msg, cause)
+		},
+	}
+}
+
+// deadLetter publishes the message to the dead letter topic, retrying until it succeeds or ctx is done.
+func (s *Source) deadLetter(ctx context.Context, msg *kafka.Message, cause error) error {
+	backoff := time.Second
+
+	for {
+		err := s.publishDeadLetter(msg, cause)
+		if err == nil {
+			return nil
+		}
+
+		s.logger.Error("Couldn't dead-letter message, retrying", zap.Duration("backoff", backoff), zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "publishDeadLetter")
+		case <-time.After(backoff):
+		}
+
+		backoff = min(2*backoff, 30*time.Second)
+	}
+}
+
+// publishDeadLetter publishes the message to the dead letter topic, and waits for the delivery report.
+func (s *Source) publishDeadLetter(msg *kafka.Message, cause error) error {
+	topic := DeadLetterTopic(s.topic)
+
+	dlq := kafka.Message{
+		TopicPartition: kafka.TopicPartition{
+			Topic:     &topic,
+			Partition: kafka.PartitionAny,
+		},
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: append(append([]kafka.Header{}, msg.Headers...), kafka.Header{Key: HeaderError, Value: []byte(cause.Error())}),
+	}
+
+	deliveryC := make(chan kafka.Event, 1)
+
+	if err := s.producer.Produce(&dlq, deliveryC); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "producer.Produce")
+	}
+
+	report, ok := (<-deliveryC).(*kafka.Message)
+	if !ok {
+		return internal.NewErrorf(internal.ErrorCodeUnknown, "unexpected delivery report")
+	}
+
+	if err := report.TopicPartition.Error; err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "delivery report")
+	}
+
+	return nil
+}
+
+// header returns the value of the header, messages published before the events envelope existed don't include
+// "content-type".
+func header(msg *kafka.Message, key string) string {
+	for _, header := range msg.Headers {
+		if header.Key == key {
+			return string(header.Value)
+		}
+	}
+
+	return ""
+}
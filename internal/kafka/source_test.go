@@ -0,0 +1,135 @@
+package kafka_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api/internal/indexer"
+	ikafka "github.com/MarioCarrion/todo-api/internal/kafka"
+)
+
+func TestSource_DeadLetter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		producer func(cluster *kafka.MockCluster) kafka.ConfigMap
+		shutdown time.Duration
+		withErr  bool
+	}{
+		{
+			"OK",
+			func(cluster *kafka.MockCluster) kafka.ConfigMap {
+				return kafka.ConfigMap{"bootstrap.servers": cluster.BootstrapServers()}
+			},
+			10 * time.Second,
+			false,
+		},
+		{
+			"ERR: retried until shutdown",
+			func(*kafka.MockCluster) kafka.ConfigMap {
+				return kafka.ConfigMap{
+					"bootstrap.servers":  unreachable,
+					"message.timeout.ms": 100,
+					"log_level":          0,
+				}
+			},
+			1500 * time.Millisecond,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cluster, err := kafka.NewMockCluster(1)
+			if err != nil {
+				t.Fatalf("Couldn't create mock cluster: %s", err)
+			}
+
+			t.Cleanup(cluster.Close)
+
+			publish(t, cluster)
+
+			consumer, err := kafka.NewConsumer(&kafka.ConfigMap{
+				"bootstrap.servers":  cluster.BootstrapServers(),
+				"group.id":           "source_test",
+				"auto.offset.reset":  "earliest",
+				"enable.auto.commit": false,
+			})
+			if err != nil {
+				t.Fatalf("Couldn't create consumer: %s", err)
+			}
+
+			t.Cleanup(func() { _ = consumer.Close() })
+
+			if err := consumer.Subscribe(topic, nil); err != nil {
+				t.Fatalf("Couldn't subscribe: %s", err)
+			}
+
+			source := ikafka.NewSource(zap.NewNop(), consumer, newProducer(t, tt.producer(cluster)), topic)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			var (
+				handled bool
+				dlqErr  error
+				elapsed time.Duration
+			)
+
+			_ = source.Consume(ctx, func(_ context.Context, msg indexer.Message) {
+				// Shutting down while dead-lettering.
+				time.AfterFunc(tt.shutdown, cancel)
+
+				start := time.Now()
+				dlqErr = msg.DeadLetter(errors.New("poison"))
+				elapsed = time.Since(start)
+				handled = true
+
+				cancel()
+			})
+
+			if !handled {
+				t.Fatalf("expected message to be consumed")
+			}
+
+			if (dlqErr != nil) != tt.withErr {
+				t.Fatalf("expected error %t, got %v", tt.withErr, dlqErr)
+			}
+
+			// Publishing fails after 100ms, waiting for the first retry takes 1s.
+			if tt.withErr && elapsed < time.Second {
+				t.Fatalf("expected dead-lettering to be retried until shutdown, failed after %s", elapsed)
+			}
+		})
+	}
+}
+
+// publish publishes a message to the topic of the cluster.
+func publish(t *testing.T, cluster *kafka.MockCluster) {
+	t.Helper()
+
+	producer := newProducer(t, kafka.ConfigMap{"bootstrap.servers": cluster.BootstrapServers()})
+
+	topicName := topic
+
+	deliveryC := make(chan kafka.Event, 1)
+
+	if err := producer.Produce(&kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &topicName, Partition: kafka.PartitionAny},
+		Value:          []byte("poison"),
+	}, deliveryC); err != nil {
+		t.Fatalf("Couldn't produce: %s", err)
+	}
+
+	if report, _ := (<-deliveryC).(*kafka.Message); report == nil || report.TopicPartition.Error != nil {
+		t.Fatalf("Couldn't deliver message: %v", report)
+	}
+}
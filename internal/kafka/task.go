@@ -1,16 +1,16 @@
 package kafka
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 
 	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/events"
 )
 
 const otelName = "github.com/MarioCarrion/todo-api/internal/kafka"
@@ -19,38 +19,46 @@ const otelName = "github.com/MarioCarrion/todo-api/internal/kafka"
 type Task struct {
 	producer  *kafka.Producer
 	topicName string
+	codec     events.Codec
 }
 
-type event struct {
-	Type  string
-	Value internal.Task
-}
-
-// NewTask instantiates the Task repository.
-func NewTask(producer *kafka.Producer, topicName string) *Task {
+// NewTask instantiates the Task repository, the producer is expected to be idempotent: messages are retried
+// without being duplicated or reordered.
+func NewTask(producer *kafka.Producer, topicName string, codec events.Codec) *Task {
 	return &Task{
 		topicName: topicName,
 		producer:  producer,
+		codec:     codec,
 	}
 }
 
 // Created publishes a message indicating a task was created.
 func (t *Task) Created(ctx context.Context, task internal.Task) error {
-	return t.publish(ctx, "Task.Created", "tasks.event.created", task)
+	return t.publish(ctx, "Task.Created", events.TypeTaskCreated, task)
 }
 
 // Deleted publishes a message indicating a task was deleted.
-func (t *Task) Deleted(ctx context.Context, id string) error {
-	return t.publish(ctx, "Task.Deleted", "tasks.event.deleted", internal.Task{ID: id})
+func (t *Task) Deleted(ctx context.Context, id string, version int64) error {
+	return t.publish(ctx, "Task.Deleted", events.TypeTaskDeleted, internal.Task{ID: id, Version: version})
 }
 
 // Updated publishes a message indicating a task was updated.
 func (t *Task) Updated(ctx context.Context, task internal.Task) error {
-	return t.publish(ctx, "Task.Updated", "tasks.event.updated", task)
+	return t.publish(ctx, "Task.Updated", events.TypeTaskUpdated, task)
+}
+
+// DueSoon publishes a message indicating a task is due soon.
+func (t *Task) DueSoon(ctx context.Context, task internal.Task) error {
+	return t.publish(ctx, "Task.DueSoon", events.TypeTaskDueSoon, task)
+}
+
+// Overdue publishes a message indicating a task is overdue.
+func (t *Task) Overdue(ctx context.Context, task internal.Task) error {
+	return t.publish(ctx, "Task.Overdue", events.TypeTaskOverdue, task)
 }
 
 func (t *Task) publish(ctx context.Context, spanName, msgType string, task internal.Task) error {
-	_, span := otel.Tracer(otelName).Start(ctx, spanName)
+	ctx, span := otel.Tracer(otelName).Start(ctx, spanName, trace.WithSpanKind(trace.SpanKindProducer))
 	defer span.End()
 
 	span.SetAttributes(
@@ -66,25 +74,50 @@ func (t *Task) publish(ctx context.Context, spanName, msgType string, task inter
 
 	//-
 
-	var b bytes.Buffer
-
-	evt := event{
-		Type:  msgType,
-		Value: task,
-	}
-
-	if err := json.NewEncoder(&b).Encode(evt); err != nil {
-		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "json.Encode")
+	b, err := t.codec.Marshal(events.NewTaskEvent(msgType, task))
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "codec.Marshal")
 	}
 
-	if err := t.producer.Produce(&kafka.Message{
+	// The task ID is the key so all the events of a task are published to the same partition, and indexed in the
+	// same order they were published.
+	msg := kafka.Message{
 		TopicPartition: kafka.TopicPartition{
 			Topic:     &t.topicName,
 			Partition: kafka.PartitionAny,
 		},
-		Value: b.Bytes(),
-	}, nil); err != nil {
-		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "product.Producer")
+		Key:   []byte(task.ID),
+		Value: b,
+		Headers: []kafka.Header{
+			{
+				Key:   "content-type",
+				Value: []byte(t.codec.ContentType()),
+			},
+		},
+	}
+
+	inject(ctx, &msg)
+
+	// Buffered, so the delivery report doesn't block the producer when ctx is done before receiving it.
+	deliveryC := make(chan kafka.Event, 1)
+
+	if err := t.producer.Produce(&msg, deliveryC); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "producer.Produce")
+	}
+
+	// XXX: The message may still be delivered after ctx is done, the caller would consider it failed.
+	select {
+	case <-ctx.Done():
+		return internal.WrapErrorf(ctx.Err(), internal.ErrorCodeUnknown, "ctx.Done")
+	case evt := <-deliveryC:
+		report, ok := evt.(*kafka.Message)
+		if !ok {
+			return internal.NewErrorf(internal.ErrorCodeUnknown, "unexpected delivery report %s", evt)
+		}
+
+		if err := report.TopicPartition.Error; err != nil {
+			return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "delivery report")
+		}
 	}
 
 	return nil
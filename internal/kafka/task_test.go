@@ -0,0 +1,136 @@
+package kafka_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/events"
+	ikafka "github.com/MarioCarrion/todo-api/internal/kafka"
+)
+
+const (
+	topic = "tasks"
+
+	// unreachable is a broker address refusing connections, messages are never delivered.
+	unreachable = "127.0.0.1:1"
+)
+
+func TestTask_Created(t *testing.T) {
+	t.Parallel()
+
+	cluster, err := kafka.NewMockCluster(1)
+	if err != nil {
+		t.Fatalf("Couldn't create mock cluster: %s", err)
+	}
+
+	t.Cleanup(cluster.Close)
+
+	tests := []struct {
+		name    string
+		config  kafka.ConfigMap
+		timeout time.Duration
+		withErr bool
+	}{
+		{
+			"OK",
+			kafka.ConfigMap{"bootstrap.servers": cluster.BootstrapServers()},
+			10 * time.Second,
+			false,
+		},
+		{
+			"ERR: delivery report",
+			kafka.ConfigMap{
+				"bootstrap.servers":  unreachable,
+				"message.timeout.ms": 100,
+				"log_level":          0,
+			},
+			10 * time.Second,
+			true,
+		},
+		{
+			"ERR: context done",
+			kafka.ConfigMap{
+				"bootstrap.servers":  unreachable,
+				"message.timeout.ms": 10_000,
+				"log_level":          0,
+			},
+			100 * time.Millisecond,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			producer := newProducer(t, tt.config)
+
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+
+			err := ikafka.NewTask(producer, topic, events.JSON).
+				Created(ctx, internal.Task{ID: "a-b-c", Description: "new task", Version: 1})
+			if (err != nil) != tt.withErr {
+				t.Fatalf("expected error %t, got %v", tt.withErr, err)
+			}
+
+			if !tt.withErr {
+				assertDelivered(t, cluster)
+			}
+		})
+	}
+}
+
+// assertDelivered reads the message published to the cluster, it must use the task ID as key and include the codec
+// content type.
+func assertDelivered(t *testing.T, cluster *kafka.MockCluster) {
+	t.Helper()
+
+	consumer, err := kafka.NewConsumer(&kafka.ConfigMap{
+		"bootstrap.servers": cluster.BootstrapServers(),
+		"group.id":          "task_test",
+		"auto.offset.reset": "earliest",
+	})
+	if err != nil {
+		t.Fatalf("Couldn't create consumer: %s", err)
+	}
+
+	defer consumer.Close()
+
+	if err := consumer.Subscribe(topic, nil); err != nil {
+		t.Fatalf("Couldn't subscribe: %s", err)
+	}
+
+	msg, err := consumer.ReadMessage(10 * time.Second)
+	if err != nil {
+		t.Fatalf("Couldn't read message: %s", err)
+	}
+
+	if string(msg.Key) != "a-b-c" {
+		t.Fatalf("expected a-b-c key, got %s", msg.Key)
+	}
+
+	if len(msg.Headers) == 0 || string(msg.Headers[0].Value) != events.JSON.ContentType() {
+		t.Fatalf("expected content type header, got %v", msg.Headers)
+	}
+}
+
+func newProducer(t *testing.T, config kafka.ConfigMap) *kafka.Producer {
+	t.Helper()
+
+	producer, err := kafka.NewProducer(&config)
+	if err != nil {
+		t.Fatalf("Couldn't create producer: %s", err)
+	}
+
+	t.Cleanup(func() {
+		_ = producer.Purge(kafka.PurgeQueue | kafka.PurgeInFlight)
+		producer.Close()
+	})
+
+	return producer
+}
@@ -0,0 +1,115 @@
+// Package lru implements an in-process Least Recently Used cache.
+package lru
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/MarioCarrion/todo-api/internal"
+)
+
+// Cache represents an in-process cache holding up to a fixed number of values, the least recently used ones are
+// evicted first; it implements "memcached.Cache".
+type Cache struct {
+	mu    sync.Mutex
+	size  int
+	ll    *list.List
+	items map[string]*list.Element
+}
+
+type entry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewCache instantiates the Cache, size is the maximum number of values.
+func NewCache(size int) *Cache {
+	return &Cache{
+		size:  size,
+		ll:    list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+// Get returns the value, or an error with the code internal.ErrorCodeNotFound when missing or expired.
+func (c *Cache) Get(_ context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, internal.NewErrorf(internal.ErrorCodeNotFound, "key not found")
+	}
+
+	e := el.Value.(*entry) //nolint: forcetypeassert
+
+	if !e.expiresAt.IsZero() && !time.Now().Before(e.expiresAt) {
+		c.remove(el)
+
+		return nil, internal.NewErrorf(internal.ErrorCodeNotFound, "key expired")
+	}
+
+	c.ll.MoveToFront(el)
+
+	return e.value, nil
+}
+
+// Set stores a copy of the value, a zero TTL means the value does not expire.
+func (c *Cache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	e := entry{
+		key:   key,
+		value: append([]byte(nil), value...),
+	}
+
+	if ttl > 0 {
+		e.expiresAt = time.Now().Add(ttl)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		el.Value = &e
+
+		c.ll.MoveToFront(el)
+
+		return nil
+	}
+
+	c.items[key] = c.ll.PushFront(&e)
+
+	for c.ll.Len() > c.size {
+		c.remove(c.ll.Back())
+	}
+
+	return nil
+}
+
+// Delete ...
+func (c *Cache) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		c.remove(el)
+	}
+
+	return nil
+}
+
+// Len returns the number of values, including the expired ones not evicted yet.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.ll.Len()
+}
+
+func (c *Cache) remove(el *list.Element) {
+	c.ll.Remove(el)
+
+	delete(c.items, el.Value.(*entry).key) //nolint: forcetypeassert
+}
@@ -0,0 +1,97 @@
+package lru_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/lru"
+)
+
+func TestCache(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Get: OK", func(t *testing.T) {
+		t.Parallel()
+
+		cache := lru.NewCache(2)
+
+		_ = cache.Set(context.Background(), "key", []byte("value"), 0)
+
+		val, err := cache.Get(context.Background(), "key")
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		if string(val) != "value" {
+			t.Fatalf("expected value, got %s", val)
+		}
+	})
+
+	t.Run("Get: ERR not found", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := lru.NewCache(2).Get(context.Background(), "key")
+		assertNotFound(t, err)
+	})
+
+	t.Run("Get: ERR expired", func(t *testing.T) {
+		t.Parallel()
+
+		cache := lru.NewCache(2)
+
+		_ = cache.Set(context.Background(), "key", []byte("value"), time.Millisecond)
+
+		time.Sleep(5 * time.Millisecond)
+
+		_, err := cache.Get(context.Background(), "key")
+		assertNotFound(t, err)
+
+		if cache.Len() != 0 {
+			t.Fatalf("expected expired value to be evicted, got %d values", cache.Len())
+		}
+	})
+
+	t.Run("Set: evicts least recently used", func(t *testing.T) {
+		t.Parallel()
+
+		cache := lru.NewCache(2)
+
+		_ = cache.Set(context.Background(), "one", []byte("1"), 0)
+		_ = cache.Set(context.Background(), "two", []byte("2"), 0)
+		_, _ = cache.Get(context.Background(), "one")
+		_ = cache.Set(context.Background(), "three", []byte("3"), 0)
+
+		_, err := cache.Get(context.Background(), "two")
+		assertNotFound(t, err)
+
+		for _, key := range []string{"one", "three"} {
+			if _, err := cache.Get(context.Background(), key); err != nil {
+				t.Fatalf("expected %s, got error %s", key, err)
+			}
+		}
+	})
+
+	t.Run("Delete: OK", func(t *testing.T) {
+		t.Parallel()
+
+		cache := lru.NewCache(2)
+
+		_ = cache.Set(context.Background(), "key", []byte("value"), 0)
+		_ = cache.Delete(context.Background(), "key")
+
+		_, err := cache.Get(context.Background(), "key")
+		assertNotFound(t, err)
+	})
+}
+
+func assertNotFound(t *testing.T, err error) {
+	t.Helper()
+
+	var ierr *internal.Error
+	if !errors.As(err, &ierr) || ierr.Code() != internal.ErrorCodeNotFound {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
@@ -0,0 +1,185 @@
+package memcached
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bradfitz/gomemcache/memcache"
+
+	"github.com/MarioCarrion/todo-api/internal"
+)
+
+//go:generate counterfeiter -generate
+
+//counterfeiter:generate -o memcachedtesting/cache.gen.go . Cache
+
+// Cache defines the datastore used for caching values, Get must return an error with the code
+// internal.ErrorCodeNotFound when the key is missing. A zero TTL means the value does not expire.
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, key string) error
+}
+
+// Client implements Cache using Memcached.
+type Client struct {
+	client *memcache.Client
+	ring   *Ring
+}
+
+// NewClient instantiates the Client.
+func NewClient(client *memcache.Client) *Client {
+	return &Client{
+		client: client,
+	}
+}
+
+// NewRingClient instantiates the Client using multiple nodes.
+func NewRingClient(ring *Ring) *Client {
+	return &Client{
+		ring: ring,
+	}
+}
+
+// Get ...
+func (c *Client) Get(ctx context.Context, key string) ([]byte, error) {
+	defer newOTELSpan(ctx, "Client.Get").End()
+
+	//-
+
+	var item *memcache.Item
+
+	err := c.do(ctx, key, func(client *memcache.Client) (err error) {
+		item, err = client.Get(key)
+
+		return err //nolint: wrapcheck
+	})
+	if err != nil {
+		if errors.Is(err, memcache.ErrCacheMiss) {
+			return nil, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "client.Get")
+		}
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "client.Get")
+	}
+
+	return item.Value, nil
+}
+
+// Set ...
+func (c *Client) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	defer newOTELSpan(ctx, "Client.Set").End()
+
+	//-
+
+	item := memcache.Item{
+		Key:   key,
+		Value: value,
+	}
+
+	if ttl > 0 {
+		item.Expiration = int32(time.Now().Add(ttl).Unix()) //nolint: gosec
+	}
+
+	if err := c.do(ctx, key, func(client *memcache.Client) error { return client.Set(&item) }); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "client.Set")
+	}
+
+	return nil
+}
+
+// Delete ...
+func (c *Client) Delete(ctx context.Context, key string) error {
+	defer newOTELSpan(ctx, "Client.Delete").End()
+
+	//-
+
+	err := c.do(ctx, key, func(client *memcache.Client) error { return client.Delete(key) })
+	if err != nil && !errors.Is(err, memcache.ErrCacheMiss) {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "client.Delete")
+	}
+
+	return nil
+}
+
+// do calls fn using the client of the node owning the key.
+func (c *Client) do(ctx context.Context, key string, fn func(client *memcache.Client) error) error {
+	if c.ring == nil {
+		return fn(c.client)
+	}
+
+	return c.ring.do(ctx, key, fn)
+}
+
+//-
+
+// TwoTierCache implements Cache using a local cache, usually in-process, in front of a remote one shared by
+// all the instances. Values are kept in the local cache for a short time because changes made by other instances
+// are not propagated to it.
+type TwoTierCache struct {
+	local    Cache
+	remote   Cache
+	localTTL time.Duration
+}
+
+// NewTwoTierCache instantiates the TwoTierCache.
+func NewTwoTierCache(local, remote Cache, localTTL time.Duration) *TwoTierCache {
+	return &TwoTierCache{
+		local:    local,
+		remote:   remote,
+		localTTL: localTTL,
+	}
+}
+
+// Get ...
+func (c *TwoTierCache) Get(ctx context.Context, key string) ([]byte, error) {
+	if val, err := c.local.Get(ctx, key); err == nil {
+		return val, nil
+	}
+
+	val, err := c.remote.Get(ctx, key)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, errorCode(err), "remote.Get")
+	}
+
+	_ = c.local.Set(ctx, key, val, c.localTTL)
+
+	return val, nil
+}
+
+// Set ...
+func (c *TwoTierCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if err := c.remote.Set(ctx, key, value, ttl); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "remote.Set")
+	}
+
+	localTTL := c.localTTL
+	if ttl > 0 && ttl < localTTL {
+		localTTL = ttl
+	}
+
+	_ = c.local.Set(ctx, key, value, localTTL)
+
+	return nil
+}
+
+// Delete ...
+func (c *TwoTierCache) Delete(ctx context.Context, key string) error {
+	_ = c.local.Delete(ctx, key)
+
+	if err := c.remote.Delete(ctx, key); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "remote.Delete")
+	}
+
+	return nil
+}
+
+// errorCode returns the code of the error, internal.ErrorCodeUnknown if it's not an *internal.Error.
+func errorCode(err error) internal.ErrorCode {
+	var ierr *internal.Error
+	if errors.As(err, &ierr) {
+		return ierr.Code()
+	}
+
+	return internal.ErrorCodeUnknown
+}
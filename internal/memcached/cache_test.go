@@ -0,0 +1,216 @@
+package memcached_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/lru"
+	"github.com/MarioCarrion/todo-api/internal/memcached"
+	"github.com/MarioCarrion/todo-api/internal/memcached/memcachedtesting"
+)
+
+func TestTwoTierCache_Get(t *testing.T) {
+	t.Parallel()
+
+	type output struct {
+		value       string
+		code        internal.ErrorCode
+		withErr     bool
+		remoteCalls int
+	}
+
+	tests := []struct {
+		name   string
+		setup  func(local *lru.Cache, remote *memcachedtesting.FakeCache)
+		output output
+	}{
+		{
+			"OK: local",
+			func(local *lru.Cache, _ *memcachedtesting.FakeCache) {
+				_ = local.Set(context.Background(), "key", []byte("local"), time.Minute)
+			},
+			output{
+				value: "local",
+			},
+		},
+		{
+			"OK: remote",
+			func(_ *lru.Cache, remote *memcachedtesting.FakeCache) {
+				remote.GetReturns([]byte("remote"), nil)
+			},
+			output{
+				value:       "remote",
+				remoteCalls: 1,
+			},
+		},
+		{
+			"ERR: not found",
+			func(_ *lru.Cache, remote *memcachedtesting.FakeCache) {
+				remote.GetReturns(nil, internal.NewErrorf(internal.ErrorCodeNotFound, "missing"))
+			},
+			output{
+				code:        internal.ErrorCodeNotFound,
+				withErr:     true,
+				remoteCalls: 2,
+			},
+		},
+		{
+			"ERR: remote",
+			func(_ *lru.Cache, remote *memcachedtesting.FakeCache) {
+				remote.GetReturns(nil, errors.New("unavailable"))
+			},
+			output{
+				code:        internal.ErrorCodeUnknown,
+				withErr:     true,
+				remoteCalls: 2,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			local, remote := lru.NewCache(10), &memcachedtesting.FakeCache{}
+			tt.setup(local, remote)
+
+			cache := memcached.NewTwoTierCache(local, remote, time.Minute)
+
+			// Values read from the remote cache are kept in the local one, the second call doesn't read it.
+			for i := 0; i < 2; i++ {
+				val, err := cache.Get(context.Background(), "key")
+				if (err != nil) != tt.output.withErr {
+					t.Fatalf("expected error %t, got %v", tt.output.withErr, err)
+				}
+
+				var ierr *internal.Error
+				if err != nil && (!errors.As(err, &ierr) || ierr.Code() != tt.output.code) {
+					t.Fatalf("expected error code %d, got %v", tt.output.code, err)
+				}
+
+				if string(val) != tt.output.value {
+					t.Fatalf("expected %q, got %q", tt.output.value, val)
+				}
+			}
+
+			if remote.GetCallCount() != tt.output.remoteCalls {
+				t.Fatalf("expected %d remote calls, got %d", tt.output.remoteCalls, remote.GetCallCount())
+			}
+		})
+	}
+}
+
+func TestTwoTierCache_Set(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		ttl      time.Duration
+		localTTL time.Duration
+		err      error
+		withErr  bool
+	}{
+		{
+			"OK: local TTL",
+			time.Hour,
+			50 * time.Millisecond,
+			nil,
+			false,
+		},
+		{
+			"OK: shorter TTL",
+			50 * time.Millisecond,
+			time.Hour,
+			nil,
+			false,
+		},
+		{
+			"ERR: remote",
+			time.Hour,
+			time.Hour,
+			errors.New("unavailable"),
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			local, remote := lru.NewCache(10), &memcachedtesting.FakeCache{}
+			remote.SetReturns(tt.err)
+
+			cache := memcached.NewTwoTierCache(local, remote, tt.localTTL)
+
+			err := cache.Set(context.Background(), "key", []byte("value"), tt.ttl)
+			if (err != nil) != tt.withErr {
+				t.Fatalf("expected error %t, got %v", tt.withErr, err)
+			}
+
+			if _, _, _, ttl := remote.SetArgsForCall(0); ttl != tt.ttl {
+				t.Fatalf("expected remote TTL %s, got %s", tt.ttl, ttl)
+			}
+
+			_, err = local.Get(context.Background(), "key")
+
+			// Values not written to the remote cache are not kept in the local one either.
+			if tt.withErr {
+				if err == nil {
+					t.Fatalf("expected value not to be cached locally")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("expected value to be cached locally, got %s", err)
+			}
+
+			// The value expires from the local cache using the shortest TTL.
+			time.Sleep(100 * time.Millisecond)
+
+			if _, err := local.Get(context.Background(), "key"); err == nil {
+				t.Fatalf("expected value to expire from the local cache")
+			}
+		})
+	}
+}
+
+func TestTwoTierCache_Delete(t *testing.T) {
+	t.Parallel()
+
+	local, remote := lru.NewCache(10), &memcachedtesting.FakeCache{}
+	cache := memcached.NewTwoTierCache(local, remote, time.Minute)
+
+	if err := cache.Set(context.Background(), "key", []byte("value"), 0); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if err := cache.Delete(context.Background(), "key"); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if _, err := local.Get(context.Background(), "key"); err == nil {
+		t.Fatalf("expected value to be deleted from the local cache")
+	}
+
+	if remote.DeleteCallCount() != 1 {
+		t.Fatalf("expected value to be deleted from the remote cache")
+	}
+
+	// Values are deleted from the local cache even if deleting them from the remote one fails.
+	remote.DeleteReturns(errors.New("unavailable"))
+
+	_ = local.Set(context.Background(), "key", []byte("value"), time.Minute)
+
+	if err := cache.Delete(context.Background(), "key"); err == nil {
+		t.Fatalf("expected error")
+	}
+
+	if _, err := local.Get(context.Background(), "key"); err == nil {
+		t.Fatalf("expected value to be deleted from the local cache")
+	}
+}
@@ -0,0 +1,52 @@
+package memcached
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/MarioCarrion/todo-api/internal"
+)
+
+// keyVersion is part of all the keys, it must be incremented when the format of the cached values changes so
+// values cached by previous versions are ignored.
+const keyVersion = 1
+
+// newKey returns a key identified by the received values. Values are hashed to comply with the Memcached rules:
+// keys are up to 250 bytes long and can't include whitespace or control characters.
+func newKey(kind string, values ...interface{}) string {
+	h := sha256.New()
+
+	for _, val := range values {
+		_, _ = fmt.Fprintf(h, "%v\x00", val)
+	}
+
+	return fmt.Sprintf("todo_v%d_%s_%x", keyVersion, kind, h.Sum(nil))
+}
+
+func newTaskKey(id string) string {
+	return newKey("task", id)
+}
+
+func newSearchableKey(generation uint64, args internal.SearchParams) string {
+	values := []interface{}{generation, args.From, args.Size}
+
+	// Pointers are dereferenced, and missing values are differentiated from zero values.
+
+	if args.Description != nil {
+		values = append(values, "description", *args.Description)
+	}
+
+	if args.Priority != nil {
+		values = append(values, "priority", int8(*args.Priority))
+	}
+
+	if args.IsDone != nil {
+		values = append(values, "is_done", *args.IsDone)
+	}
+
+	return newKey("search", values...)
+}
+
+func newSuggestKey(generation uint64, args internal.SuggestParams) string {
+	return newKey("suggest", generation, args.Query, args.Size)
+}
@@ -0,0 +1,110 @@
+package memcached_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/memcached"
+	"github.com/MarioCarrion/todo-api/internal/memcached/memcachedtesting"
+)
+
+func TestKeys(t *testing.T) {
+	t.Parallel()
+
+	find := func(id string) func(context.Context, memcached.Cache) {
+		return func(ctx context.Context, cache memcached.Cache) {
+			_, _ = memcached.NewTask(cache, &memcachedtesting.FakeTaskStore{}, zap.NewNop()).Find(ctx, id)
+		}
+	}
+
+	search := func(args internal.SearchParams) func(context.Context, memcached.Cache) {
+		return func(ctx context.Context, cache memcached.Cache) {
+			_, _ = newSearchableTask(cache, &memcachedtesting.FakeSearchableTaskStore{}).Search(ctx, args)
+		}
+	}
+
+	suggest := func(args internal.SuggestParams) func(context.Context, memcached.Cache) {
+		return func(ctx context.Context, cache memcached.Cache) {
+			_, _ = newSearchableTask(cache, &memcachedtesting.FakeSearchableTaskStore{}).Suggest(ctx, args)
+		}
+	}
+
+	description := "buy milk"
+	long := strings.Repeat("milk ", 200)
+
+	tests := []struct {
+		name   string
+		load   func(context.Context, memcached.Cache)
+		prefix string
+	}{
+		{
+			"OK: task",
+			find("aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee"),
+			"todo_v1_task_",
+		},
+		{
+			"OK: task with spaces",
+			find("a b\tc\n"),
+			"todo_v1_task_",
+		},
+		{
+			"OK: task too long",
+			find(long),
+			"todo_v1_task_",
+		},
+		{
+			"OK: search",
+			search(internal.SearchParams{Description: &description, Size: 10}),
+			"todo_v1_search_",
+		},
+		{
+			"OK: search too long",
+			search(internal.SearchParams{Description: &long, Size: 10}),
+			"todo_v1_search_",
+		},
+		{
+			"OK: suggest with spaces",
+			suggest(internal.SuggestParams{Query: "buy mi", Size: 5}),
+			"todo_v1_suggest_",
+		},
+		{
+			"OK: suggest too long",
+			suggest(internal.SuggestParams{Query: long, Size: 5}),
+			"todo_v1_suggest_",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cache := &memcachedtesting.FakeCache{}
+			cache.GetReturns(nil, internal.NewErrorf(internal.ErrorCodeNotFound, "missing"))
+
+			tt.load(context.Background(), cache)
+
+			// The last key read is the one of the cached value, searches read the generation first.
+			_, key := cache.GetArgsForCall(cache.GetCallCount() - 1)
+
+			if !strings.HasPrefix(key, tt.prefix) {
+				t.Fatalf("expected %s prefix, got %s", tt.prefix, key)
+			}
+
+			if len(key) > 250 {
+				t.Fatalf("expected key up to 250 bytes, got %d", len(key))
+			}
+
+			if strings.IndexFunc(key, func(r rune) bool { return r <= ' ' || r == 0x7f }) != -1 {
+				t.Fatalf("expected key without whitespace or control characters, got %q", key)
+			}
+		})
+	}
+}
+
+func newSearchableTask(cache memcached.Cache, orig memcached.SearchableTaskStore) *memcached.SearchableTask {
+	return memcached.NewSearchableTask(cache, memcached.NewSearchGeneration(cache, zap.NewNop()), orig, zap.NewNop())
+}
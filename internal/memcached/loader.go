@@ -0,0 +1,175 @@
+package memcached
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/MarioCarrion/todo-api/internal"
+)
+
+// cachedValue wraps cached values with the time they become stale. Stale values are still returned while they
+// are refreshed in the background, they expire after staying stale as long as they were fresh.
+type cachedValue[T any] struct {
+	Value   T
+	StaleAt time.Time
+	// NotFound indicates the original datastore returned an internal.ErrorCodeNotFound error.
+	NotFound bool
+}
+
+// loader implements Cache-Aside caching with protection against cache stampedes:
+//
+//   - Concurrent requests for the same missing key are coalesced, only one of them reads the original datastore,
+//   - Stale values are served while one goroutine refreshes them,
+//   - Expirations are jittered so keys cached at the same time don't expire at the same time.
+type loader struct {
+	cache       Cache
+	logger      *zap.Logger
+	negativeTTL time.Duration
+	group       singleflight.Group
+	attrs       metric.MeasurementOption
+
+	hits      metric.Int64Counter
+	misses    metric.Int64Counter
+	coalesced metric.Int64Counter
+	stale     metric.Int64Counter
+}
+
+// newLoader instantiates the loader, name identifies the cached values in the metrics; those are named after the
+// cache because any of the backends can be used. Not found errors are cached for negativeTTL, 0 disables caching
+// them.
+func newLoader(cache Cache, logger *zap.Logger, name string, negativeTTL time.Duration) *loader {
+	meter := otel.Meter(otelName)
+
+	newCounter := func(name, description string) metric.Int64Counter {
+		counter, err := meter.Int64Counter(name, metric.WithDescription(description))
+		if err != nil {
+			otel.Handle(err)
+		}
+
+		return counter
+	}
+
+	return &loader{
+		cache:       cache,
+		logger:      logger,
+		negativeTTL: negativeTTL,
+		attrs:       metric.WithAttributes(attribute.String("cache", name)),
+		hits:        newCounter("cache.hits", "Values found in the cache"),
+		misses:      newCounter("cache.misses", "Values not found in the cache"),
+		coalesced:   newCounter("cache.coalesced", "Requests waiting for another one reading the same value"),
+		stale:       newCounter("cache.stale", "Stale values returned while being refreshed"),
+	}
+}
+
+// load returns the cached value, or the one read using orig when missing.
+func load[T any](ctx context.Context, l *loader, key string, ttl time.Duration,
+	orig func(ctx context.Context) (T, error),
+) (T, error) {
+	var cached cachedValue[T]
+
+	// XXX: Any error, including values that can't be decoded, is considered a miss.
+
+	if err := getTask(ctx, l.cache, key, &cached); err == nil {
+		if time.Now().Before(cached.StaleAt) {
+			l.hits.Add(ctx, 1, l.attrs)
+
+			return cached.result()
+		}
+
+		l.stale.Add(ctx, 1, l.attrs)
+
+		// The refresh must outlive the request that triggered it.
+		ctxRefresh := context.WithoutCancel(ctx)
+
+		_ = l.group.DoChan(key, func() (interface{}, error) {
+			return refresh(ctxRefresh, l, key, ttl, orig)
+		})
+
+		return cached.result()
+	}
+
+	l.misses.Add(ctx, 1, l.attrs)
+
+	res, err, shared := l.group.Do(key, func() (interface{}, error) {
+		return refresh(ctx, l, key, ttl, orig)
+	})
+	if shared {
+		l.coalesced.Add(ctx, 1, l.attrs)
+	}
+
+	if err != nil {
+		var zero T
+
+		return zero, err //nolint: wrapcheck
+	}
+
+	return res.(T), nil //nolint: forcetypeassert
+}
+
+// store caches the value, used for Write-Through caching.
+func store[T any](ctx context.Context, l *loader, key string, value T, ttl time.Duration) {
+	set(ctx, l, key, &cachedValue[T]{Value: value}, ttl)
+}
+
+// remove deletes the cached value.
+func remove(ctx context.Context, l *loader, key string) {
+	if err := deleteTask(ctx, l.cache, key); err != nil {
+		l.logger.Error("Couldn't delete cached value", zap.String("key", key), zap.Error(err))
+	}
+}
+
+func set[T any](ctx context.Context, l *loader, key string, value *cachedValue[T], ttl time.Duration) {
+	ttl = jitter(ttl)
+
+	value.StaleAt = time.Now().Add(ttl)
+
+	if err := setTask(ctx, l.cache, key, value, 2*ttl); err != nil {
+		l.logger.Error("Couldn't set cached value", zap.String("key", key), zap.Error(err))
+	}
+}
+
+func refresh[T any](ctx context.Context, l *loader, key string, ttl time.Duration,
+	orig func(ctx context.Context) (T, error),
+) (interface{}, error) {
+	res, err := orig(ctx)
+	if err != nil {
+		var ierr *internal.Error
+		if l.negativeTTL > 0 && errors.As(err, &ierr) && ierr.Code() == internal.ErrorCodeNotFound {
+			set(ctx, l, key, &cachedValue[T]{NotFound: true}, l.negativeTTL)
+		}
+
+		return nil, err
+	}
+
+	store(ctx, l, key, res, ttl)
+
+	return res, nil
+}
+
+func (c cachedValue[T]) result() (T, error) {
+	if c.NotFound {
+		var zero T
+
+		return zero, internal.NewErrorf(internal.ErrorCodeNotFound, "not found, cached")
+	}
+
+	return c.Value, nil
+}
+
+// jitter randomly changes the duration up to 10% in either direction.
+func jitter(d time.Duration) time.Duration {
+	delta := int64(d) / 10
+	if delta <= 0 {
+		return d
+	}
+
+	return d + time.Duration(rand.Int64N(2*delta+1)-delta) //nolint: gosec
+}
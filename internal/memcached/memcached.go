@@ -6,7 +6,6 @@ import (
 	"encoding/gob"
 	"time"
 
-	"github.com/bradfitz/gomemcache/memcache"
 	"go.opentelemetry.io/otel"
 	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
 	"go.opentelemetry.io/otel/trace"
@@ -16,38 +15,36 @@ import (
 
 const otelName = "github.com/MarioCarrion/todo-api/internal/memcached"
 
-// XXX: "delete" and "set" intentionally ignore errors, a better approach
-// would be to implement an unexported "client" type defining all the three
-// methods defined in this file that includes a Circuit Breaker for logging
-// errors and retry as needed.
-// See https://youtu.be/UnL2iGcD7vE for more details about that pattern.
-
-func deleteTask(ctx context.Context, client *memcache.Client, key string) {
+func deleteTask(ctx context.Context, cache Cache, key string) error {
 	defer newOTELSpan(ctx, "deleteTask").End()
 
 	//-
 
-	_ = client.Delete(key)
+	if err := cache.Delete(ctx, key); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "cache.Delete")
+	}
+
+	return nil
 }
 
-func getTask(ctx context.Context, client *memcache.Client, key string, target interface{}) error {
+func getTask(ctx context.Context, cache Cache, key string, target interface{}) error {
 	defer newOTELSpan(ctx, "getTask").End()
 
 	//-
 
-	item, err := client.Get(key)
+	val, err := cache.Get(ctx, key)
 	if err != nil {
-		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "client.Get")
+		return internal.WrapErrorf(err, errorCode(err), "cache.Get")
 	}
 
-	if err := gob.NewDecoder(bytes.NewReader(item.Value)).Decode(target); err != nil {
+	if err := gob.NewDecoder(bytes.NewReader(val)).Decode(target); err != nil {
 		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "gob.NewDecoder")
 	}
 
 	return nil
 }
 
-func setTask(ctx context.Context, client *memcache.Client, key string, value interface{}, expiration time.Duration) {
+func setTask(ctx context.Context, cache Cache, key string, value interface{}, expiration time.Duration) error {
 	defer newOTELSpan(ctx, "setTask").End()
 
 	//-
@@ -55,14 +52,14 @@ func setTask(ctx context.Context, client *memcache.Client, key string, value int
 	var b bytes.Buffer
 
 	if err := gob.NewEncoder(&b).Encode(value); err != nil {
-		return
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "gob.NewEncoder")
 	}
 
-	_ = client.Set(&memcache.Item{
-		Key:        key,
-		Value:      b.Bytes(),
-		Expiration: int32(time.Now().Add(expiration).Unix()),
-	})
+	if err := cache.Set(ctx, key, b.Bytes(), expiration); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "cache.Set")
+	}
+
+	return nil
 }
 
 //-
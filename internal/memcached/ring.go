@@ -0,0 +1,250 @@
+package memcached
+
+import (
+	"context"
+	"errors"
+	"hash/crc32"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/bradfitz/gomemcache/memcache"
+	"github.com/mercari/go-circuitbreaker"
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api/internal"
+)
+
+// ringReplicas is the number of points each node has in the ring, more points distribute keys more evenly.
+const ringReplicas = 160
+
+// Ring distributes keys across multiple Memcached nodes using consistent hashing, adding or removing a node only
+// moves the keys owned by that node.
+//
+// Each node has a circuit breaker, nodes are removed from the ring when theirs opens and their keys are moved
+// to the rest of the nodes. Once the breaker becomes half-open the node is pinged, if it's healthy again it's
+// flushed, to drop values that changed while it was removed, and added back to the ring.
+type Ring struct {
+	logger       *zap.Logger
+	timeout      time.Duration
+	maxIdleConns int
+	openTimeout  time.Duration
+
+	mu     sync.RWMutex
+	nodes  map[string]*ringNode
+	points []ringPoint // sorted by hash, only includes healthy nodes
+}
+
+type ringNode struct {
+	addr    string
+	client  *memcache.Client
+	cb      *circuitbreaker.CircuitBreaker
+	healthy bool // guarded by Ring.mu
+}
+
+type ringPoint struct {
+	hash uint32
+	node *ringNode
+}
+
+// NewRing instantiates the Ring, timeout and maxIdleConns configure the clients connecting to each node;
+// openTimeout is the time unhealthy nodes are removed for before pinging them again.
+func NewRing(logger *zap.Logger, timeout time.Duration, maxIdleConns int, openTimeout time.Duration) *Ring {
+	return &Ring{
+		logger:       logger,
+		timeout:      timeout,
+		maxIdleConns: maxIdleConns,
+		openTimeout:  openTimeout,
+		nodes:        make(map[string]*ringNode),
+	}
+}
+
+// SetServers replaces the nodes in the ring, it's safe to call it while the ring is used. Nodes already in the
+// ring keep their state, new ones are pinged and only added to the ring if they are healthy.
+func (r *Ring) SetServers(servers ...string) error {
+	if len(servers) == 0 {
+		return internal.NewErrorf(internal.ErrorCodeInvalidArgument, "no servers")
+	}
+
+	r.mu.RLock()
+	current := r.nodes
+	r.mu.RUnlock()
+
+	nodes := make(map[string]*ringNode, len(servers))
+
+	for _, addr := range servers {
+		if node, ok := current[addr]; ok {
+			nodes[addr] = node
+
+			continue
+		}
+
+		// Pinging the new nodes happens without holding the lock, the breakers call back into the ring when
+		// their state changes.
+		nodes[addr] = r.newNode(addr)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nodes = nodes
+	r.rebuild()
+
+	return nil
+}
+
+// Healthy returns the addresses of the nodes currently in the ring.
+func (r *Ring) Healthy() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	res := make([]string, 0, len(r.nodes))
+
+	for addr, node := range r.nodes {
+		if node.healthy {
+			res = append(res, addr)
+		}
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+// do calls fn using the client of the node owning the key, the result is recorded by the node's circuit breaker.
+func (r *Ring) do(ctx context.Context, key string, fn func(client *memcache.Client) error) error {
+	node, err := r.pick(key)
+	if err != nil {
+		return err
+	}
+
+	err = fn(node.client)
+
+	// Misses and rejected writes are answered by the node, only the rest of the errors indicate it's unhealthy.
+	if err == nil || errors.Is(err, memcache.ErrCacheMiss) || errors.Is(err, memcache.ErrNotStored) ||
+		errors.Is(err, memcache.ErrCASConflict) || errors.Is(err, memcache.ErrMalformedKey) {
+		node.cb.Success()
+
+		return err
+	}
+
+	node.cb.FailWithContext(ctx)
+
+	return err
+}
+
+func (r *Ring) pick(key string) (*ringNode, error) {
+	hash := crc32.ChecksumIEEE([]byte(key))
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(r.points) == 0 {
+		return nil, memcache.ErrNoServers
+	}
+
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i].hash >= hash })
+	if i == len(r.points) {
+		i = 0
+	}
+
+	return r.points[i].node, nil
+}
+
+func (r *Ring) newNode(addr string) *ringNode {
+	client := memcache.New(addr)
+	client.Timeout = r.timeout
+	client.MaxIdleConns = r.maxIdleConns
+
+	node := ringNode{
+		addr:    addr,
+		client:  client,
+		healthy: true,
+	}
+
+	node.cb = circuitbreaker.New(
+		circuitbreaker.WithOpenTimeout(r.openTimeout),
+		circuitbreaker.WithTripFunc(circuitbreaker.NewTripFuncConsecutiveFailures(3)),
+		circuitbreaker.WithOnStateChangeHookFn(func(oldState, newState circuitbreaker.State) {
+			r.logger.Info("state changed",
+				zap.String("node", addr),
+				zap.String("old", string(oldState)),
+				zap.String("new", string(newState)),
+			)
+
+			// The hook is called while the breaker is locked, so it must not call the breaker back directly.
+			switch newState {
+			case circuitbreaker.StateOpen:
+				r.setHealthy(&node, false)
+			case circuitbreaker.StateHalfOpen:
+				go r.check(&node)
+			case circuitbreaker.StateClosed:
+				r.setHealthy(&node, true)
+			}
+		}),
+	)
+
+	if err := client.Ping(); err != nil {
+		r.logger.Error("Node is not healthy", zap.String("node", addr), zap.Error(err))
+
+		node.healthy = false
+		node.cb.SetState(circuitbreaker.StateOpen)
+	}
+
+	return &node
+}
+
+// check pings a node with a half-open breaker, healthy nodes are flushed and added back to the ring.
+func (r *Ring) check(node *ringNode) {
+	if err := node.client.Ping(); err != nil {
+		node.cb.Fail()
+
+		return
+	}
+
+	// XXX: Values written to other nodes while this one was removed are not copied back, flushing it avoids
+	// returning the values cached before it was removed; those keys are read from the original datastore again.
+	if err := node.client.FlushAll(); err != nil {
+		node.cb.Fail()
+
+		return
+	}
+
+	node.cb.Reset()
+}
+
+func (r *Ring) setHealthy(node *ringNode, healthy bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Nodes removed by SetServers keep changing their state until their breakers stop, those are ignored.
+	if r.nodes[node.addr] != node || node.healthy == healthy {
+		return
+	}
+
+	node.healthy = healthy
+	r.rebuild()
+}
+
+// rebuild recalculates the points in the ring, it must be called while holding the lock.
+func (r *Ring) rebuild() {
+	points := make([]ringPoint, 0, len(r.nodes)*ringReplicas)
+
+	for addr, node := range r.nodes {
+		if !node.healthy {
+			continue
+		}
+
+		for i := range ringReplicas {
+			points = append(points, ringPoint{
+				hash: crc32.ChecksumIEEE([]byte(addr + "-" + strconv.Itoa(i))),
+				node: node,
+			})
+		}
+	}
+
+	sort.Slice(points, func(i, j int) bool { return points[i].hash < points[j].hash })
+
+	r.points = points
+}
@@ -0,0 +1,453 @@
+package memcached_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/memcached"
+)
+
+func TestRing_SetServers(t *testing.T) {
+	t.Parallel()
+
+	first, second, down := newFakeServer(t), newFakeServer(t), newFakeServer(t)
+	down.stop()
+
+	ring := newRing(t, time.Hour)
+
+	if err := ring.SetServers(); err == nil {
+		t.Fatalf("expected error without servers")
+	}
+
+	// Unhealthy nodes are not added to the ring.
+	if err := ring.SetServers(first.addr, second.addr, down.addr); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	assertHealthy(t, ring, first.addr, second.addr)
+
+	// Reloading keeps the existing nodes and removes the missing ones.
+	if err := ring.SetServers(second.addr); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	assertHealthy(t, ring, second.addr)
+
+	client := memcached.NewRingClient(ring)
+
+	if err := client.Set(context.Background(), "key", []byte("value"), 0); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if first.len() != 0 || second.len() != 1 {
+		t.Fatalf("expected key to be written to the remaining node, got %d and %d", first.len(), second.len())
+	}
+}
+
+func TestRing_Distribution(t *testing.T) {
+	t.Parallel()
+
+	const keys = 3000
+
+	servers := []*fakeServer{newFakeServer(t), newFakeServer(t), newFakeServer(t)}
+	ring := newRing(t, time.Hour, servers...)
+	client := memcached.NewRingClient(ring)
+
+	for i := 0; i < keys; i++ {
+		if err := client.Set(context.Background(), fmt.Sprintf("key-%d", i), []byte("value"), 0); err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+	}
+
+	// Each node owns roughly a third of the keys.
+	for _, server := range servers {
+		if count := server.len(); count < keys/5 || count > keys/2 {
+			t.Fatalf("expected %s to own about %d keys, got %d", server.addr, keys/3, count)
+		}
+	}
+}
+
+func TestRing_Stability(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		before []int
+		after  []int
+	}{
+		{
+			"OK: node added",
+			[]int{0, 1, 2},
+			[]int{0, 1, 2, 3},
+		},
+		{
+			"OK: node removed",
+			[]int{0, 1, 2, 3},
+			[]int{0, 1, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			const keys = 1000
+
+			servers := []*fakeServer{newFakeServer(t), newFakeServer(t), newFakeServer(t), newFakeServer(t)}
+
+			owners := func(indexes []int) map[string]string {
+				addrs := make([]string, len(indexes))
+
+				for i, index := range indexes {
+					addrs[i] = servers[index].addr
+				}
+
+				ring := newRing(t, time.Hour)
+				if err := ring.SetServers(addrs...); err != nil {
+					t.Fatalf("expected no error, got %s", err)
+				}
+
+				res := make(map[string]string, keys)
+
+				for i := 0; i < keys; i++ {
+					res[fmt.Sprintf("key-%d", i)] = owner(t, ring, servers, fmt.Sprintf("key-%d", i))
+				}
+
+				return res
+			}
+
+			before, after := owners(tt.before), owners(tt.after)
+
+			var moved int
+
+			for key, addr := range before {
+				if after[key] == addr {
+					continue
+				}
+
+				moved++
+
+				// Only the keys of the removed node, or the ones taken by the added node, are moved.
+				if !contains(servers, tt.after, addr) || !contains(servers, tt.before, after[key]) {
+					continue
+				}
+
+				t.Fatalf("expected %s to stay in %s, moved to %s", key, addr, after[key])
+			}
+
+			if moved == 0 || moved > keys/2 {
+				t.Fatalf("expected about a quarter of the keys to move, %d moved", moved)
+			}
+		})
+	}
+}
+
+func TestRing_Unhealthy(t *testing.T) {
+	t.Parallel()
+
+	first, second := newFakeServer(t), newFakeServer(t)
+	ring := newRing(t, 100*time.Millisecond, first, second)
+	client := memcached.NewRingClient(ring)
+
+	var key string
+
+	for i := 0; key == ""; i++ {
+		if owner(t, ring, []*fakeServer{first, second}, fmt.Sprintf("key-%d", i)) == second.addr {
+			key = fmt.Sprintf("key-%d", i)
+		}
+	}
+
+	second.stop()
+
+	// The node is removed after 3 consecutive failures.
+	for i := 0; i < 3; i++ {
+		assertErrorCode(t, client, key, internal.ErrorCodeUnknown)
+	}
+
+	assertHealthy(t, ring, first.addr)
+
+	// Its keys are moved to the rest of the nodes.
+	assertErrorCode(t, client, key, internal.ErrorCodeNotFound)
+
+	// Once it's healthy again it's flushed, values cached before it was removed may be outdated.
+	second.set(key, []byte("outdated"))
+	second.start(t)
+
+	deadline := time.Now().Add(5 * time.Second)
+
+	for len(ring.Healthy()) != 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected node to be added back, healthy %v", ring.Healthy())
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if second.flushes() != 1 || second.len() != 0 {
+		t.Fatalf("expected node to be flushed once, got %d flushes and %d keys", second.flushes(), second.len())
+	}
+
+	assertErrorCode(t, client, key, internal.ErrorCodeNotFound)
+
+	if owner(t, ring, []*fakeServer{first, second}, key) != second.addr {
+		t.Fatalf("expected key to be owned by the node again")
+	}
+}
+
+func assertErrorCode(t *testing.T, client *memcached.Client, key string, code internal.ErrorCode) {
+	t.Helper()
+
+	_, err := client.Get(context.Background(), key)
+
+	var ierr *internal.Error
+	if !errors.As(err, &ierr) || ierr.Code() != code {
+		t.Fatalf("expected error code %d, got %v", code, err)
+	}
+}
+
+func assertHealthy(t *testing.T, ring *memcached.Ring, expected ...string) {
+	t.Helper()
+
+	sort.Strings(expected)
+
+	if actual := ring.Healthy(); !cmp.Equal(expected, actual) {
+		t.Fatalf("expected healthy nodes do not match: %s", cmp.Diff(expected, actual))
+	}
+}
+
+// owner writes the key and returns the address of the server it was written to.
+func owner(t *testing.T, ring *memcached.Ring, servers []*fakeServer, key string) string {
+	t.Helper()
+
+	if err := memcached.NewRingClient(ring).Set(context.Background(), key, []byte("value"), 0); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	for _, server := range servers {
+		if server.delete(key) {
+			return server.addr
+		}
+	}
+
+	t.Fatalf("expected %s to be written", key)
+
+	return ""
+}
+
+func contains(servers []*fakeServer, indexes []int, addr string) bool {
+	for _, index := range indexes {
+		if servers[index].addr == addr {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newRing(t *testing.T, openTimeout time.Duration, servers ...*fakeServer) *memcached.Ring {
+	t.Helper()
+
+	ring := memcached.NewRing(zap.NewNop(), time.Second, 2, openTimeout)
+
+	if len(servers) == 0 {
+		return ring
+	}
+
+	addrs := make([]string, len(servers))
+
+	for i, server := range servers {
+		addrs[i] = server.addr
+	}
+
+	if err := ring.SetServers(addrs...); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	return ring
+}
+
+//-
+
+// fakeServer implements the subset of the Memcached text protocol used by the Ring.
+type fakeServer struct {
+	addr string
+
+	mu      sync.Mutex
+	items   map[string][]byte
+	flushed int
+	ln      net.Listener
+	conns   map[net.Conn]struct{}
+}
+
+func newFakeServer(t *testing.T) *fakeServer {
+	t.Helper()
+
+	server := fakeServer{
+		addr:  "127.0.0.1:0",
+		items: make(map[string][]byte),
+	}
+
+	server.start(t)
+
+	t.Cleanup(server.stop)
+
+	return &server
+}
+
+// start listens using the server's address, the same one is used after stopping it.
+func (s *fakeServer) start(t *testing.T) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		t.Fatalf("Couldn't listen: %s", err)
+	}
+
+	s.mu.Lock()
+	s.addr = ln.Addr().String()
+	s.ln = ln
+	s.conns = make(map[net.Conn]struct{})
+	s.mu.Unlock()
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+
+			s.mu.Lock()
+			s.conns[conn] = struct{}{}
+			s.mu.Unlock()
+
+			go s.serve(conn)
+		}
+	}()
+}
+
+// stop closes the listener and the open connections, connecting to the server fails until it's started again.
+func (s *fakeServer) stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ln == nil {
+		return
+	}
+
+	_ = s.ln.Close()
+	s.ln = nil
+
+	for conn := range s.conns {
+		_ = conn.Close()
+	}
+}
+
+func (s *fakeServer) serve(conn net.Conn) {
+	defer conn.Close()
+
+	rw := bufio.NewReadWriter(bufio.NewReader(conn), bufio.NewWriter(conn))
+
+	for {
+		line, err := rw.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			return
+		}
+
+		switch fields[0] {
+		case "version":
+			_, _ = rw.WriteString("VERSION 1.6.0\r\n")
+		case "flush_all":
+			s.mu.Lock()
+			s.items = make(map[string][]byte)
+			s.flushed++
+			s.mu.Unlock()
+
+			_, _ = rw.WriteString("OK\r\n")
+		case "gets":
+			for _, key := range fields[1:] {
+				s.mu.Lock()
+				val, ok := s.items[key]
+				s.mu.Unlock()
+
+				if ok {
+					_, _ = fmt.Fprintf(rw, "VALUE %s 0 %d 1\r\n%s\r\n", key, len(val), val)
+				}
+			}
+
+			_, _ = rw.WriteString("END\r\n")
+		case "set":
+			size, _ := strconv.Atoi(fields[4])
+
+			val := make([]byte, size+2) // Includes "\r\n"
+			if _, err := io.ReadFull(rw, val); err != nil {
+				return
+			}
+
+			s.set(fields[1], val[:size])
+
+			_, _ = rw.WriteString("STORED\r\n")
+		case "delete":
+			if s.delete(fields[1]) {
+				_, _ = rw.WriteString("DELETED\r\n")
+			} else {
+				_, _ = rw.WriteString("NOT_FOUND\r\n")
+			}
+		default:
+			_, _ = rw.WriteString("ERROR\r\n")
+		}
+
+		if err := rw.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func (s *fakeServer) set(key string, val []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.items[key] = val
+}
+
+func (s *fakeServer) delete(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.items[key]
+	delete(s.items, key)
+
+	return ok
+}
+
+func (s *fakeServer) flushes() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.flushed
+}
+
+func (s *fakeServer) len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.items)
+}
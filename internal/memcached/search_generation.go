@@ -0,0 +1,76 @@
+package memcached
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api/internal"
+)
+
+// searchGenerationKey is the key holding the current generation of cached search results.
+const searchGenerationKey = "tasks_search_generation"
+
+// SearchGeneration namespaces the cached search results, all search keys include the current generation so
+// bumping it drops all of them at once without enumerating keys; the previous ones simply expire.
+type SearchGeneration struct {
+	cache  Cache
+	logger *zap.Logger
+}
+
+// NewSearchGeneration instantiates the SearchGeneration, cache must be shared by all the instances.
+func NewSearchGeneration(cache Cache, logger *zap.Logger) *SearchGeneration {
+	return &SearchGeneration{
+		cache:  cache,
+		logger: logger,
+	}
+}
+
+// Current returns the current generation, a new one is created when missing.
+func (g *SearchGeneration) Current(ctx context.Context) (uint64, error) {
+	defer newOTELSpan(ctx, "SearchGeneration.Current").End()
+
+	//-
+
+	val, err := g.cache.Get(ctx, searchGenerationKey)
+	if err != nil {
+		var ierr *internal.Error
+		if errors.As(err, &ierr) && ierr.Code() == internal.ErrorCodeNotFound {
+			return g.next(ctx), nil
+		}
+
+		return 0, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "cache.Get")
+	}
+
+	res, err := strconv.ParseUint(string(val), 10, 64)
+	if err != nil {
+		return 0, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "strconv.ParseUint")
+	}
+
+	return res, nil
+}
+
+// Bump moves to the next generation, it must be called after a task is written.
+func (g *SearchGeneration) Bump(ctx context.Context) {
+	defer newOTELSpan(ctx, "SearchGeneration.Bump").End()
+
+	//-
+
+	_ = g.next(ctx)
+}
+
+// next stores a new generation using the current time, that way the generation always moves forward, even if
+// the key is evicted, instead of starting again and reusing the keys of old search results that didn't expire yet.
+func (g *SearchGeneration) next(ctx context.Context) uint64 {
+	val := uint64(time.Now().UnixNano()) //nolint: gosec
+
+	// Failing to store it means stale search results could be returned until they expire.
+	if err := g.cache.Set(ctx, searchGenerationKey, []byte(strconv.FormatUint(val, 10)), 0); err != nil {
+		g.logger.Error("Couldn't set search generation", zap.Error(err))
+	}
+
+	return val
+}
@@ -0,0 +1,118 @@
+package memcached_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/lru"
+	"github.com/MarioCarrion/todo-api/internal/memcached"
+	"github.com/MarioCarrion/todo-api/internal/memcached/memcachedtesting"
+	"github.com/MarioCarrion/todo-api/internal/memory"
+)
+
+func TestSearchGeneration(t *testing.T) {
+	t.Parallel()
+
+	generation := memcached.NewSearchGeneration(lru.NewCache(10), zap.NewNop())
+
+	first, err := generation.Current(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if current, _ := generation.Current(context.Background()); current != first {
+		t.Fatalf("expected generation %d to be kept, got %d", first, current)
+	}
+
+	generation.Bump(context.Background())
+
+	if current, _ := generation.Current(context.Background()); current <= first {
+		t.Fatalf("expected generation after %d, got %d", first, current)
+	}
+}
+
+func TestSearchableTask_Search(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		write func(context.Context, *memcached.BulkSearchableTask) error
+		calls int
+	}{
+		{
+			"OK: cached",
+			func(context.Context, *memcached.BulkSearchableTask) error { return nil },
+			1,
+		},
+		{
+			"OK: indexed",
+			func(ctx context.Context, bulk *memcached.BulkSearchableTask) error {
+				return bulk.Bulk(ctx, []internal.Task{{ID: "a-b-c"}}, nil)
+			},
+			2,
+		},
+		{
+			"OK: deleted",
+			func(ctx context.Context, bulk *memcached.BulkSearchableTask) error {
+				return bulk.Bulk(ctx, nil, []internal.Task{{ID: "a-b-c"}})
+			},
+			2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cache := lru.NewCache(10)
+			orig := &memcachedtesting.FakeSearchableTaskStore{}
+			orig.SearchReturns(internal.SearchResults{Total: 1}, nil)
+
+			generation := memcached.NewSearchGeneration(cache, zap.NewNop())
+			task := memcached.NewSearchableTask(cache, generation, orig, zap.NewNop())
+			bulk := memcached.NewBulkSearchableTask(generation, memory.NewSearchableTask())
+			description := "milk"
+
+			search := func() {
+				if _, err := task.Search(context.Background(), internal.SearchParams{Description: &description}); err != nil {
+					t.Fatalf("expected no error, got %s", err)
+				}
+			}
+
+			search()
+
+			// Writing tasks bumps the generation, so searching again uses a new key.
+			if err := tt.write(context.Background(), bulk); err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			search()
+
+			if orig.SearchCallCount() != tt.calls {
+				t.Fatalf("expected %d searches, got %d", tt.calls, orig.SearchCallCount())
+			}
+		})
+	}
+}
+
+func TestSearchableTask_Generation(t *testing.T) {
+	t.Parallel()
+
+	cache := &memcachedtesting.FakeCache{}
+	cache.GetReturns(nil, errors.New("unavailable"))
+
+	orig := &memcachedtesting.FakeSearchableTaskStore{}
+
+	// Results can't be cached without knowing the current generation.
+	if _, err := newSearchableTask(cache, orig).Search(context.Background(), internal.SearchParams{}); err == nil {
+		t.Fatalf("expected error")
+	}
+
+	if orig.SearchCallCount() != 0 {
+		t.Fatalf("expected no searches, got %d", orig.SearchCallCount())
+	}
+}
@@ -2,107 +2,116 @@ package memcached
 
 import (
 	"context"
-	"errors"
-	"fmt"
 	"time"
 
-	"github.com/bradfitz/gomemcache/memcache"
+	"go.uber.org/zap"
 
 	"github.com/MarioCarrion/todo-api/internal"
 )
 
 // SearchableTask ...
 type SearchableTask struct {
-	client *memcache.Client
-	orig   SearchableTaskStore
+	search     *loader
+	suggest    *loader
+	orig       SearchableTaskStore
+	generation *SearchGeneration
 }
 
+//counterfeiter:generate -o memcachedtesting/searchable_task_store.gen.go . SearchableTaskStore
+
 type SearchableTaskStore interface {
-	Delete(ctx context.Context, id string) error
-	Index(ctx context.Context, task internal.Task) error
 	Search(ctx context.Context, args internal.SearchParams) (internal.SearchResults, error)
+	Suggest(ctx context.Context, args internal.SuggestParams) ([]internal.Suggestion, error)
 }
 
 // NewSearchableTask instantiates the Task repository.
-func NewSearchableTask(client *memcache.Client, orig SearchableTaskStore) *SearchableTask {
+func NewSearchableTask(cache Cache, generation *SearchGeneration, orig SearchableTaskStore, logger *zap.Logger) *SearchableTask {
 	return &SearchableTask{
-		client: client,
-		orig:   orig,
+		search:     newLoader(cache, logger, "search", 0),
+		suggest:    newLoader(cache, logger, "suggest", 0),
+		orig:       orig,
+		generation: generation,
 	}
 }
 
-// Index ...
-func (t *SearchableTask) Index(ctx context.Context, task internal.Task) error {
-	defer newOTELSpan(ctx, "SearchableTask.Index").End()
+// Search ...
+func (t *SearchableTask) Search(ctx context.Context, args internal.SearchParams) (internal.SearchResults, error) {
+	defer newOTELSpan(ctx, "SearchableTask.Search").End()
 
 	//-
 
-	if err := t.orig.Index(ctx, task); err != nil {
-		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "orig.Index")
+	generation, err := t.generation.Current(ctx)
+	if err != nil {
+		return internal.SearchResults{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "generation.Current")
 	}
 
-	return nil
-}
-
-// Delete ...
-func (t *SearchableTask) Delete(ctx context.Context, id string) error {
-	defer newOTELSpan(ctx, "SearchableTask.Delete").End()
+	key := newSearchableKey(generation, args)
 
-	//-
-
-	if err := t.orig.Delete(ctx, id); err != nil {
-		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "orig.Delete")
+	res, err := load(ctx, t.search, key, 25*time.Second, func(ctx context.Context) (internal.SearchResults, error) {
+		return t.orig.Search(ctx, args) //nolint: wrapcheck
+	})
+	if err != nil {
+		return internal.SearchResults{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "orig.Search")
 	}
 
-	return nil
+	return res, nil
 }
 
-// Search ...
-func (t *SearchableTask) Search(ctx context.Context, args internal.SearchParams) (internal.SearchResults, error) {
-	defer newOTELSpan(ctx, "SearchableTask.Search").End()
+// Suggest ...
+func (t *SearchableTask) Suggest(ctx context.Context, args internal.SuggestParams) ([]internal.Suggestion, error) {
+	defer newOTELSpan(ctx, "SearchableTask.Suggest").End()
 
 	//-
 
-	key := newSearchableKey(args)
-
-	var res internal.SearchResults
-
-	if err := getTask(ctx, t.client, key, &res); err != nil {
-		if errors.Is(err, memcache.ErrCacheMiss) {
-			res, err := t.orig.Search(ctx, args)
-			if err != nil {
-				return internal.SearchResults{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "orig.Search")
-			}
-
-			setTask(ctx, t.client, key, &res, 25*time.Second)
+	generation, err := t.generation.Current(ctx)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "generation.Current")
+	}
 
-			return res, nil
-		}
+	key := newSuggestKey(generation, args)
 
-		return internal.SearchResults{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "get")
+	res, err := load(ctx, t.suggest, key, 10*time.Second, func(ctx context.Context) ([]internal.Suggestion, error) {
+		return t.orig.Suggest(ctx, args) //nolint: wrapcheck
+	})
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "orig.Suggest")
 	}
 
 	return res, nil
 }
 
-func newSearchableKey(args internal.SearchParams) string {
-	var (
-		description string
-		priority    int8
-		isDone      bool
-	)
+//-
 
-	if args.Description != nil {
-		description = *args.Description
-	}
+// BulkSearchableTaskStore defines the search datastore writing tasks in batches.
+type BulkSearchableTaskStore interface {
+	Bulk(ctx context.Context, tasks []internal.Task, deleted []internal.Task) error
+}
+
+// BulkSearchableTask drops the cached search results after writing tasks in batches.
+type BulkSearchableTask struct {
+	orig       BulkSearchableTaskStore
+	generation *SearchGeneration
+}
 
-	if args.Priority != nil {
-		priority = int8(*args.Priority)
+// NewBulkSearchableTask instantiates the BulkSearchableTask repository.
+func NewBulkSearchableTask(generation *SearchGeneration, orig BulkSearchableTaskStore) *BulkSearchableTask {
+	return &BulkSearchableTask{
+		orig:       orig,
+		generation: generation,
 	}
+}
 
-	if args.IsDone != nil {
-		isDone = *args.IsDone
+// Bulk ...
+func (t *BulkSearchableTask) Bulk(ctx context.Context, tasks []internal.Task, deleted []internal.Task) error {
+	defer newOTELSpan(ctx, "BulkSearchableTask.Bulk").End()
+
+	//-
+
+	if err := t.orig.Bulk(ctx, tasks, deleted); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "orig.Bulk")
 	}
 
-	return fmt.Sprintf("%s_%d_%t_%d_%d", description, priority, isDone, args.From, args.Size)
+	t.generation.Bump(ctx)
+
+	return nil
 }
@@ -0,0 +1,66 @@
+package memcached_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/memcached"
+	"github.com/MarioCarrion/todo-api/internal/memcached/memcachedtesting"
+)
+
+func TestSearchableTask_Suggest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{
+			"OK: one word",
+			"bu",
+		},
+		{
+			"OK: multiple words",
+			"buy mi",
+		},
+		{
+			"OK: control characters",
+			"buy\tmilk\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Memcached rejects keys including whitespace, using a client validates the keys.
+			ring := newRing(t, time.Hour, newFakeServer(t))
+
+			orig := &memcachedtesting.FakeSearchableTaskStore{}
+			orig.SuggestReturns([]internal.Suggestion{{ID: "a-b-c", Description: "buy milk"}}, nil)
+
+			task := newSearchableTask(memcached.NewRingClient(ring), orig)
+
+			// The second call is served from the cache.
+			for i := 0; i < 2; i++ {
+				res, err := task.Suggest(context.Background(), internal.SuggestParams{Query: tt.query, Size: 5})
+				if err != nil {
+					t.Fatalf("expected no error, got %s", err)
+				}
+
+				expected := []internal.Suggestion{{ID: "a-b-c", Description: "buy milk"}}
+				if !cmp.Equal(expected, res) {
+					t.Fatalf("expected result does not match: %s", cmp.Diff(expected, res))
+				}
+			}
+
+			if orig.SuggestCallCount() != 1 {
+				t.Fatalf("expected 1 suggestion, got %d", orig.SuggestCallCount())
+			}
+		})
+	}
+}
@@ -4,29 +4,34 @@ import (
 	"context"
 	"time"
 
-	"github.com/bradfitz/gomemcache/memcache"
 	"go.uber.org/zap"
 
 	"github.com/MarioCarrion/todo-api/internal"
 )
 
 type Task struct {
-	client     *memcache.Client
+	loader     *loader
 	orig       TaskStore
 	expiration time.Duration
 	logger     *zap.Logger
 }
 
+//counterfeiter:generate -o memcachedtesting/task_store.gen.go . TaskStore
+
 type TaskStore interface {
 	Create(ctx context.Context, params internal.CreateParams) (internal.Task, error)
-	Delete(ctx context.Context, id string) error
+	Delete(ctx context.Context, id string) (int64, error)
 	Find(ctx context.Context, id string) (internal.Task, error)
 	Update(ctx context.Context, id string, description string, priority internal.Priority, dates internal.Dates, isDone bool) error
 }
 
-func NewTask(client *memcache.Client, orig TaskStore, logger *zap.Logger) *Task {
+// negativeExpiration is how long "not found" errors are cached, it's short because tasks could be created with the
+// same id after being looked up, for example when restoring backups.
+const negativeExpiration = 30 * time.Second
+
+func NewTask(cache Cache, orig TaskStore, logger *zap.Logger) *Task {
 	return &Task{
-		client:     client,
+		loader:     newLoader(cache, logger, "task", negativeExpiration),
 		orig:       orig,
 		expiration: 10 * time.Minute,
 		logger:     logger,
@@ -47,23 +52,25 @@ func (t *Task) Create(ctx context.Context, params internal.CreateParams) (intern
 
 	t.logger.Info("Create: setting value")
 
-	setTask(ctx, t.client, task.ID, &task, t.expiration)
+	store(ctx, t.loader, newTaskKey(task.ID), task, t.expiration)
 
 	return task, nil
 }
 
-func (t *Task) Delete(ctx context.Context, id string) error {
+func (t *Task) Delete(ctx context.Context, id string) (int64, error) {
 	defer newOTELSpan(ctx, "Task.Delete").End()
 
 	//-
 
-	if err := t.orig.Delete(ctx, id); err != nil {
-		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "orig.Delete")
+	version, err := t.orig.Delete(ctx, id)
+	if err != nil {
+		return 0, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "orig.Delete")
 	}
 
-	deleteTask(ctx, t.client, id)
+	// The next Find caches the "not found" error.
+	remove(ctx, t.loader, newTaskKey(id))
 
-	return nil
+	return version, nil
 }
 
 func (t *Task) Find(ctx context.Context, id string) (internal.Task, error) {
@@ -71,25 +78,19 @@ func (t *Task) Find(ctx context.Context, id string) (internal.Task, error) {
 
 	//-
 
-	var res internal.Task
-
 	t.logger.Info("Find: get value")
 
-	if err := getTask(ctx, t.client, id, &res); err == nil {
-		return res, nil
-	}
-
-	t.logger.Info("Find: not found, let's cache it")
-
 	// Cache-Aside Caching
 
-	res, err := t.orig.Find(ctx, id)
+	res, err := load(ctx, t.loader, newTaskKey(id), t.expiration, func(ctx context.Context) (internal.Task, error) {
+		t.logger.Info("Find: not found, let's cache it")
+
+		return t.orig.Find(ctx, id) //nolint: wrapcheck
+	})
 	if err != nil {
-		return res, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "orig.Find")
+		return res, internal.WrapErrorf(err, errorCode(err), "orig.Find")
 	}
 
-	setTask(ctx, t.client, res.ID, &res, t.expiration)
-
 	return res, nil
 }
 
@@ -112,14 +113,14 @@ func (t *Task) Update(ctx context.Context, id string, description string, priori
 	// What if any of the following instructions fail? We may end up with stale
 	// values
 
-	deleteTask(ctx, t.client, id) // XXX
+	remove(ctx, t.loader, newTaskKey(id)) // XXX
 
 	task, err := t.orig.Find(ctx, id)
 	if err != nil { // XXX
 		return nil //nolint: nilerr
 	}
 
-	setTask(ctx, t.client, task.ID, &task, t.expiration) // XXX
+	store(ctx, t.loader, newTaskKey(task.ID), task, t.expiration) // XXX
 
 	return nil
 }
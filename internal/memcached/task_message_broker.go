@@ -0,0 +1,72 @@
+package memcached
+
+import (
+	"context"
+
+	"github.com/MarioCarrion/todo-api/internal"
+)
+
+// TaskMessageBroker drops the cached search results after publishing task events.
+type TaskMessageBroker struct {
+	orig       TaskMessageBrokerStore
+	generation *SearchGeneration
+}
+
+type TaskMessageBrokerStore interface {
+	Created(ctx context.Context, task internal.Task) error
+	Deleted(ctx context.Context, id string, version int64) error
+	Updated(ctx context.Context, task internal.Task) error
+}
+
+// NewTaskMessageBroker instantiates the TaskMessageBroker repository.
+func NewTaskMessageBroker(generation *SearchGeneration, orig TaskMessageBrokerStore) *TaskMessageBroker {
+	return &TaskMessageBroker{
+		orig:       orig,
+		generation: generation,
+	}
+}
+
+// Created ...
+func (t *TaskMessageBroker) Created(ctx context.Context, task internal.Task) error {
+	defer newOTELSpan(ctx, "TaskMessageBroker.Created").End()
+
+	//-
+
+	if err := t.orig.Created(ctx, task); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "orig.Created")
+	}
+
+	t.generation.Bump(ctx)
+
+	return nil
+}
+
+// Deleted ...
+func (t *TaskMessageBroker) Deleted(ctx context.Context, id string, version int64) error {
+	defer newOTELSpan(ctx, "TaskMessageBroker.Deleted").End()
+
+	//-
+
+	if err := t.orig.Deleted(ctx, id, version); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "orig.Deleted")
+	}
+
+	t.generation.Bump(ctx)
+
+	return nil
+}
+
+// Updated ...
+func (t *TaskMessageBroker) Updated(ctx context.Context, task internal.Task) error {
+	defer newOTELSpan(ctx, "TaskMessageBroker.Updated").End()
+
+	//-
+
+	if err := t.orig.Updated(ctx, task); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "orig.Updated")
+	}
+
+	t.generation.Bump(ctx)
+
+	return nil
+}
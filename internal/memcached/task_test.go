@@ -0,0 +1,164 @@
+package memcached_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/lru"
+	"github.com/MarioCarrion/todo-api/internal/memcached"
+	"github.com/MarioCarrion/todo-api/internal/memcached/memcachedtesting"
+)
+
+func TestTask_Find(t *testing.T) {
+	t.Parallel()
+
+	type output struct {
+		task    internal.Task
+		code    internal.ErrorCode
+		withErr bool
+	}
+
+	tests := []struct {
+		name   string
+		setup  func(*memcachedtesting.FakeTaskStore)
+		calls  int
+		output output
+	}{
+		{
+			"OK: cached",
+			func(s *memcachedtesting.FakeTaskStore) {
+				s.FindReturns(internal.Task{ID: "a-b-c", Description: "cached"}, nil)
+			},
+			1,
+			output{
+				task: internal.Task{ID: "a-b-c", Description: "cached"},
+			},
+		},
+		{
+			"ERR: not found cached",
+			func(s *memcachedtesting.FakeTaskStore) {
+				s.FindReturns(internal.Task{}, internal.NewErrorf(internal.ErrorCodeNotFound, "not found"))
+			},
+			1,
+			output{
+				code:    internal.ErrorCodeNotFound,
+				withErr: true,
+			},
+		},
+		{
+			"ERR: unknown not cached",
+			func(s *memcachedtesting.FakeTaskStore) {
+				s.FindReturns(internal.Task{}, errors.New("failed"))
+			},
+			2,
+			output{
+				code:    internal.ErrorCodeUnknown,
+				withErr: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			orig := &memcachedtesting.FakeTaskStore{}
+			tt.setup(orig)
+
+			task := memcached.NewTask(lru.NewCache(10), orig, zap.NewNop())
+
+			// The second call is served from the cache, unless the first one failed with an unknown error.
+			for i := 0; i < 2; i++ {
+				res, err := task.Find(context.Background(), "a-b-c")
+				if (err != nil) != tt.output.withErr {
+					t.Fatalf("expected error %t, got %v", tt.output.withErr, err)
+				}
+
+				var ierr *internal.Error
+				if err != nil && (!errors.As(err, &ierr) || ierr.Code() != tt.output.code) {
+					t.Fatalf("expected error code %d, got %v", tt.output.code, err)
+				}
+
+				if !cmp.Equal(tt.output.task, res) {
+					t.Fatalf("expected result does not match: %s", cmp.Diff(tt.output.task, res))
+				}
+			}
+
+			if orig.FindCallCount() != tt.calls {
+				t.Fatalf("expected %d calls to the original datastore, got %d", tt.calls, orig.FindCallCount())
+			}
+		})
+	}
+}
+
+func TestTask_CacheErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		call     func(*memcached.Task) error
+		expected string
+	}{
+		{
+			"Create",
+			func(task *memcached.Task) error {
+				_, err := task.Create(context.Background(), internal.CreateParams{Description: "new"})
+
+				return err
+			},
+			"Couldn't set cached value",
+		},
+		{
+			"Delete",
+			func(task *memcached.Task) error {
+				_, err := task.Delete(context.Background(), "a-b-c")
+
+				return err
+			},
+			"Couldn't delete cached value",
+		},
+		{
+			"Find",
+			func(task *memcached.Task) error {
+				_, err := task.Find(context.Background(), "a-b-c")
+
+				return err
+			},
+			"Couldn't set cached value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cache := &memcachedtesting.FakeCache{}
+			cache.GetReturns(nil, errors.New("unavailable"))
+			cache.SetReturns(errors.New("unavailable"))
+			cache.DeleteReturns(errors.New("unavailable"))
+
+			core, logs := observer.New(zapcore.ErrorLevel)
+
+			// Failing to use the cache doesn't fail the request, it's only logged.
+			if err := tt.call(memcached.NewTask(cache, &memcachedtesting.FakeTaskStore{}, zap.New(core))); err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			entries := logs.FilterMessage(tt.expected).All()
+			if len(entries) != 1 {
+				t.Fatalf("expected %q to be logged once, got %v", tt.expected, logs.All())
+			}
+
+			if key, _ := entries[0].ContextMap()["key"].(string); key == "" {
+				t.Fatalf("expected key to be logged, got %v", entries[0].ContextMap())
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package memcached
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api/internal"
+)
+
+// TaskTransfer caches the Tasks changed by imports, the same way Task does when they are created or updated; finding
+// and listing them use the embedded store directly.
+type TaskTransfer struct {
+	TaskTransferStore
+
+	loader     *loader
+	expiration time.Duration
+}
+
+// TaskTransferStore defines the datastore exporting and importing Tasks.
+type TaskTransferStore interface {
+	FindByExternalID(ctx context.Context, externalID string) (internal.Task, error)
+	ListAfter(ctx context.Context, id string, size int32) ([]internal.Task, error)
+	Upsert(ctx context.Context, params internal.ImportParams) (internal.Task, internal.ImportStatus, error)
+}
+
+// NewTaskTransfer instantiates the TaskTransfer repository, cache must be the one used by Task.
+func NewTaskTransfer(cache Cache, orig TaskTransferStore, logger *zap.Logger) *TaskTransfer {
+	return &TaskTransfer{
+		TaskTransferStore: orig,
+		loader:            newLoader(cache, logger, "task", negativeExpiration),
+		expiration:        10 * time.Minute,
+	}
+}
+
+// Upsert ...
+func (t *TaskTransfer) Upsert(ctx context.Context, params internal.ImportParams) (internal.Task, internal.ImportStatus, error) { //nolint: lll
+	defer newOTELSpan(ctx, "TaskTransfer.Upsert").End()
+
+	//-
+
+	task, status, err := t.TaskTransferStore.Upsert(ctx, params)
+	if err != nil {
+		return internal.Task{}, 0, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "orig.Upsert")
+	}
+
+	// Write-Through Caching
+
+	if status != internal.ImportStatusUnchanged {
+		store(ctx, t.loader, newTaskKey(task.ID), task, t.expiration)
+	}
+
+	return task, status, nil
+}
@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/events"
+)
+
+// IndexerStore defines the search datastore the events are indexed to.
+type IndexerStore interface {
+	Bulk(ctx context.Context, tasks []internal.Task, deleted []internal.Task) error
+}
+
+// Indexer indexes the Task events published to a MessageBroker, it's the in-process equivalent of the
+// "elasticsearch-indexer" commands.
+type Indexer struct {
+	logger *zap.Logger
+	broker *MessageBroker
+	store  IndexerStore
+	done   chan struct{}
+}
+
+// NewIndexer instantiates the Indexer.
+func NewIndexer(logger *zap.Logger, broker *MessageBroker, store IndexerStore) *Indexer {
+	return &Indexer{
+		logger: logger,
+		broker: broker,
+		store:  store,
+		done:   make(chan struct{}),
+	}
+}
+
+// Start indexes the events in the background until Close is called.
+func (i *Indexer) Start() {
+	go func() {
+		defer close(i.done)
+
+		for evt := range i.broker.Events() {
+			i.index(evt)
+		}
+	}()
+}
+
+// Close closes the MessageBroker and waits until the events already published are indexed, or ctx is done.
+func (i *Indexer) Close(ctx context.Context) error {
+	i.broker.Close()
+
+	select {
+	case <-ctx.Done():
+		return internal.WrapErrorf(ctx.Err(), internal.ErrorCodeUnknown, "ctx.Done")
+	case <-i.done:
+		return nil
+	}
+}
+
+func (i *Indexer) index(evt events.Event) {
+	// The trace started by the request publishing the event continues while indexing it.
+	ctx := events.Extract(context.Background(), evt)
+
+	var err error
+
+	switch evt.Type {
+	case events.TypeTaskCreated, events.TypeTaskUpdated:
+		err = i.store.Bulk(ctx, []internal.Task{evt.Task}, nil)
+	case events.TypeTaskDeleted:
+		err = i.store.Bulk(ctx, nil, []internal.Task{evt.Task})
+	}
+
+	if err != nil {
+		i.logger.Error("Couldn't index event", zap.String("type", evt.Type), zap.Error(err))
+	}
+}
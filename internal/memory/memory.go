@@ -0,0 +1,18 @@
+// Package memory implements in-process repositories meant for local development and tests, nothing is persisted
+// and nothing is shared with other processes.
+package memory
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const otelName = "github.com/MarioCarrion/todo-api/internal/memory"
+
+func newOTELSpan(ctx context.Context, name string) trace.Span {
+	_, span := otel.Tracer(otelName).Start(ctx, name)
+
+	return span
+}
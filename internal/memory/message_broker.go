@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/events"
+)
+
+// MessageBroker represents the repository used for publishing Task events to the consumers running in the same
+// process, like Indexer.
+type MessageBroker struct {
+	mu      sync.RWMutex
+	closed  bool
+	eventsC chan events.Event
+}
+
+// NewMessageBroker instantiates the MessageBroker repository, publishing blocks once size events are waiting to
+// be consumed.
+func NewMessageBroker(size int) *MessageBroker {
+	return &MessageBroker{
+		eventsC: make(chan events.Event, size),
+	}
+}
+
+// Created publishes a message indicating a task was created.
+func (b *MessageBroker) Created(ctx context.Context, task internal.Task) error {
+	return b.publish(ctx, "MessageBroker.Created", events.TypeTaskCreated, task)
+}
+
+// Deleted publishes a message indicating a task was deleted.
+func (b *MessageBroker) Deleted(ctx context.Context, id string, version int64) error {
+	return b.publish(ctx, "MessageBroker.Deleted", events.TypeTaskDeleted, internal.Task{ID: id, Version: version})
+}
+
+// Updated publishes a message indicating a task was updated.
+func (b *MessageBroker) Updated(ctx context.Context, task internal.Task) error {
+	return b.publish(ctx, "MessageBroker.Updated", events.TypeTaskUpdated, task)
+}
+
+// DueSoon publishes a message indicating a task is due soon.
+func (b *MessageBroker) DueSoon(ctx context.Context, task internal.Task) error {
+	return b.publish(ctx, "MessageBroker.DueSoon", events.TypeTaskDueSoon, task)
+}
+
+// Overdue publishes a message indicating a task is overdue.
+func (b *MessageBroker) Overdue(ctx context.Context, task internal.Task) error {
+	return b.publish(ctx, "MessageBroker.Overdue", events.TypeTaskOverdue, task)
+}
+
+// Events returns the channel receiving the published events, it's closed after calling Close.
+func (b *MessageBroker) Events() <-chan events.Event {
+	return b.eventsC
+}
+
+// Close stops publishing events, the ones already published are still received.
+func (b *MessageBroker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.closed {
+		b.closed = true
+		close(b.eventsC)
+	}
+}
+
+func (b *MessageBroker) publish(ctx context.Context, spanName, typ string, task internal.Task) error {
+	ctx, span := otel.Tracer(otelName).Start(ctx, spanName, trace.WithSpanKind(trace.SpanKindProducer))
+	defer span.End()
+
+	//-
+
+	evt := events.NewTaskEvent(typ, task)
+
+	events.Inject(ctx, &evt)
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		return internal.NewErrorf(internal.ErrorCodeUnknown, "message broker closed")
+	}
+
+	select {
+	case b.eventsC <- evt:
+	case <-ctx.Done():
+		return internal.WrapErrorf(ctx.Err(), internal.ErrorCodeUnknown, "ctx.Done")
+	}
+
+	return nil
+}
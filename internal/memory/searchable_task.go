@@ -0,0 +1,216 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/MarioCarrion/todo-api/internal"
+)
+
+// SearchableTask represents the repository used for searching Task records kept in memory, results are
+// calculated similarly to Elasticsearch: any of the arguments must match and results are sorted by relevance.
+type SearchableTask struct {
+	mu    sync.RWMutex
+	tasks map[string]internal.Task
+}
+
+// NewSearchableTask instantiates the SearchableTask repository.
+func NewSearchableTask() *SearchableTask {
+	return &SearchableTask{
+		tasks: make(map[string]internal.Task),
+	}
+}
+
+// Index creates or updates a task in the index.
+func (t *SearchableTask) Index(ctx context.Context, task internal.Task) error {
+	defer newOTELSpan(ctx, "SearchableTask.Index").End()
+
+	//-
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.tasks[task.ID] = task
+
+	return nil
+}
+
+// Delete removes a task from the index.
+func (t *SearchableTask) Delete(ctx context.Context, id string) error {
+	defer newOTELSpan(ctx, "SearchableTask.Delete").End()
+
+	//-
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.tasks, id)
+
+	return nil
+}
+
+// Bulk indexes tasks and deletes the ones matching the IDs of deleted.
+func (t *SearchableTask) Bulk(ctx context.Context, tasks []internal.Task, deleted []internal.Task) error {
+	defer newOTELSpan(ctx, "SearchableTask.Bulk").End()
+
+	//-
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, task := range tasks {
+		t.tasks[task.ID] = task
+	}
+
+	for _, task := range deleted {
+		delete(t.tasks, task.ID)
+	}
+
+	return nil
+}
+
+// Search returns tasks matching a query, the relevance of each task is the number of matching arguments and
+// description words.
+func (t *SearchableTask) Search(ctx context.Context, args internal.SearchParams) (internal.SearchResults, error) {
+	defer newOTELSpan(ctx, "SearchableTask.Search").End()
+
+	//-
+
+	if args.IsZero() {
+		return internal.SearchResults{}, nil
+	}
+
+	type hit struct {
+		task  internal.Task
+		score int
+	}
+
+	var words []string
+
+	if args.Description != nil {
+		words = newWords(*args.Description)
+	}
+
+	t.mu.RLock()
+
+	hits := make([]hit, 0, len(t.tasks))
+
+	for _, task := range t.tasks {
+		score := matchWords(newWords(task.Description), words)
+
+		if args.Priority != nil && task.Priority == *args.Priority {
+			score++
+		}
+
+		if args.IsDone != nil && task.IsDone == *args.IsDone {
+			score++
+		}
+
+		if score > 0 {
+			hits = append(hits, hit{task: task, score: score})
+		}
+	}
+
+	t.mu.RUnlock()
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].score != hits[j].score {
+			return hits[i].score > hits[j].score
+		}
+
+		return hits[i].task.ID < hits[j].task.ID
+	})
+
+	res := internal.SearchResults{
+		Tasks: []internal.Task{},
+		Total: int64(len(hits)),
+	}
+
+	for i := args.From; i < int64(len(hits)) && i < args.From+args.Size; i++ {
+		res.Tasks = append(res.Tasks, hits[i].task)
+	}
+
+	return res, nil
+}
+
+// Suggest returns tasks with descriptions including all the words typed so far, the last one as a prefix.
+func (t *SearchableTask) Suggest(ctx context.Context, args internal.SuggestParams) ([]internal.Suggestion, error) {
+	defer newOTELSpan(ctx, "SearchableTask.Suggest").End()
+
+	//-
+
+	words := newWords(args.Query)
+	if len(words) == 0 {
+		return []internal.Suggestion{}, nil
+	}
+
+	res := []internal.Suggestion{}
+
+	t.mu.RLock()
+
+	for _, task := range t.tasks {
+		if matchPrefix(newWords(task.Description), words) {
+			res = append(res, internal.Suggestion{ID: task.ID, Description: task.Description})
+		}
+	}
+
+	t.mu.RUnlock()
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Description != res[j].Description {
+			return res[i].Description < res[j].Description
+		}
+
+		return res[i].ID < res[j].ID
+	})
+
+	if int64(len(res)) > args.Size {
+		res = res[:args.Size]
+	}
+
+	return res, nil
+}
+
+// newWords splits text into lowercase words, anything that is not a letter or a digit is a separator.
+func newWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// matchWords returns the number of words in query included in words.
+func matchWords(words, query []string) int {
+	var res int
+
+	for _, q := range query {
+		for _, w := range words {
+			if w == q {
+				res++
+
+				break
+			}
+		}
+	}
+
+	return res
+}
+
+// matchPrefix indicates whether all the words in query are included in words, the last one as a prefix.
+func matchPrefix(words, query []string) bool {
+	last := len(query) - 1
+
+	if matchWords(words, query[:last]) != last {
+		return false
+	}
+
+	for _, w := range words {
+		if strings.HasPrefix(w, query[last]) {
+			return true
+		}
+	}
+
+	return false
+}
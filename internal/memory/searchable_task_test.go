@@ -0,0 +1,33 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/memory"
+	"github.com/MarioCarrion/todo-api/internal/service"
+	"github.com/MarioCarrion/todo-api/internal/service/servicetesting"
+)
+
+func TestSearchableTask(t *testing.T) {
+	t.Parallel()
+
+	servicetesting.TestTaskSearchRepository(t, func(t *testing.T, tasks []internal.Task) (service.TaskSearchRepository, []internal.Task) {
+		t.Helper()
+
+		search := memory.NewSearchableTask()
+
+		for i := range tasks {
+			tasks[i].ID = uuid.NewString()
+		}
+
+		if err := search.Bulk(context.Background(), tasks, nil); err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		return search, tasks
+	})
+}
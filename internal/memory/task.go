@@ -0,0 +1,199 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/MarioCarrion/todo-api/internal"
+)
+
+// Task represents the repository used for interacting with Task records kept in memory.
+type Task struct {
+	mu    sync.RWMutex
+	tasks map[string]internal.Task
+	// external indexes the ids of the imported tasks by their external id.
+	external map[string]string
+}
+
+// NewTask instantiates the Task repository.
+func NewTask() *Task {
+	return &Task{
+		tasks:    make(map[string]internal.Task),
+		external: make(map[string]string),
+	}
+}
+
+// Create inserts a new task record.
+func (t *Task) Create(ctx context.Context, params internal.CreateParams) (internal.Task, error) {
+	defer newOTELSpan(ctx, "Task.Create").End()
+
+	//-
+
+	// XXX: `SubTasks` and `Categories` are not supported, the same way the PostgreSQL repository does.
+	// XXX: `Reminders` are ignored, "reminder-scheduler" finds the tasks due soon using PostgreSQL.
+
+	task := internal.Task{
+		ID:          uuid.NewString(),
+		Description: params.Description,
+		Priority:    params.Priority,
+		Dates:       params.Dates,
+		Version:     1,
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.tasks[task.ID] = task
+
+	return task, nil
+}
+
+// Delete deletes the existing record matching the id, it returns the version of the deleted record.
+func (t *Task) Delete(ctx context.Context, id string) (int64, error) {
+	defer newOTELSpan(ctx, "Task.Delete").End()
+
+	//-
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	task, ok := t.tasks[id]
+	if !ok {
+		return 0, internal.NewErrorf(internal.ErrorCodeNotFound, "task not found")
+	}
+
+	delete(t.tasks, id)
+	delete(t.external, task.ExternalID)
+
+	return task.Version, nil
+}
+
+// Find returns the requested task.
+func (t *Task) Find(ctx context.Context, id string) (internal.Task, error) {
+	defer newOTELSpan(ctx, "Task.Find").End()
+
+	//-
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	task, ok := t.tasks[id]
+	if !ok {
+		return internal.Task{}, internal.NewErrorf(internal.ErrorCodeNotFound, "task not found")
+	}
+
+	return task, nil
+}
+
+// FindByExternalID returns the task imported using the external id.
+func (t *Task) FindByExternalID(ctx context.Context, externalID string) (internal.Task, error) {
+	defer newOTELSpan(ctx, "Task.FindByExternalID").End()
+
+	//-
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	id, ok := t.external[externalID]
+	if !ok || externalID == "" {
+		return internal.Task{}, internal.NewErrorf(internal.ErrorCodeNotFound, "task not found")
+	}
+
+	return t.tasks[id], nil
+}
+
+// ListAfter returns, sorted by id, up to size tasks with an id greater than the received one; use an empty id to
+// start from the first task.
+func (t *Task) ListAfter(ctx context.Context, id string, size int32) ([]internal.Task, error) {
+	defer newOTELSpan(ctx, "Task.ListAfter").End()
+
+	//-
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	res := make([]internal.Task, 0, len(t.tasks))
+
+	for _, task := range t.tasks {
+		if task.ID > id {
+			res = append(res, task)
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ID < res[j].ID
+	})
+
+	return res[:min(len(res), int(size))], nil
+}
+
+// Update updates the existing record with new values.
+func (t *Task) Update(ctx context.Context, id string, description string, priority internal.Priority, dates internal.Dates, isDone bool) error { //nolint: lll
+	defer newOTELSpan(ctx, "Task.Update").End()
+
+	//-
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	task, ok := t.tasks[id]
+	if !ok {
+		return internal.NewErrorf(internal.ErrorCodeNotFound, "task not found")
+	}
+
+	task.Description = description
+	task.Priority = priority
+	task.Dates = dates
+	task.IsDone = isDone
+	task.Version++
+
+	t.tasks[id] = task
+
+	return nil
+}
+
+// Upsert inserts the task, or updates the one imported using the same external id; the existing task is left as
+// is, including its version, when the values are the same.
+func (t *Task) Upsert(ctx context.Context, params internal.ImportParams) (internal.Task, internal.ImportStatus, error) {
+	defer newOTELSpan(ctx, "Task.Upsert").End()
+
+	//-
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	task, ok := t.tasks[t.external[params.ExternalID]]
+
+	switch {
+	case ok && params.Matches(task):
+		return task, internal.ImportStatusUnchanged, nil
+	case ok:
+		task.Version++
+	default:
+		task = internal.Task{
+			ID:         uuid.NewString(),
+			ExternalID: params.ExternalID,
+			Version:    1,
+		}
+	}
+
+	task.Description = params.Description
+	task.Priority = params.Priority
+	task.Dates = params.Dates
+	task.IsDone = params.IsDone
+
+	t.tasks[task.ID] = task
+
+	if params.ExternalID != "" {
+		t.external[params.ExternalID] = task.ID
+	}
+
+	if ok {
+		return task, internal.ImportStatusUpdated, nil
+	}
+
+	return task, internal.ImportStatusCreated, nil
+}
@@ -0,0 +1,227 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/memory"
+)
+
+func TestTask(t *testing.T) {
+	t.Parallel()
+
+	store := memory.NewTask()
+
+	created, err := store.Create(context.Background(), internal.CreateParams{
+		Description: "buy milk",
+		Priority:    internal.PriorityHigh,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if created.ID == "" {
+		t.Fatalf("expected id")
+	}
+
+	dates := internal.Dates{Due: time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)}
+
+	if err := store.Update(context.Background(), created.ID, "buy bread", internal.PriorityLow, dates, true); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	found, err := store.Find(context.Background(), created.ID)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	expected := internal.Task{
+		ID:          created.ID,
+		Description: "buy bread",
+		Priority:    internal.PriorityLow,
+		Dates:       dates,
+		IsDone:      true,
+		Version:     2,
+	}
+
+	if diff := cmp.Diff(expected, found); diff != "" {
+		t.Fatalf("the expected result does not match: %s", diff)
+	}
+
+	version, err := store.Delete(context.Background(), created.ID)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if version != 2 {
+		t.Fatalf("expected version 2, got %d", version)
+	}
+
+	_, err = store.Find(context.Background(), created.ID)
+	assertNotFound(t, err)
+
+	_, err = store.Delete(context.Background(), created.ID)
+	assertNotFound(t, err)
+
+	err = store.Update(context.Background(), created.ID, "buy bread", internal.PriorityLow, dates, true)
+	assertNotFound(t, err)
+}
+
+func TestTask_ListAfter(t *testing.T) {
+	t.Parallel()
+
+	store := memory.NewTask()
+
+	var expected []string
+
+	for _, description := range []string{"buy milk", "buy bread", "buy eggs"} {
+		created, err := store.Create(context.Background(), internal.CreateParams{
+			Description: description,
+			Priority:    internal.PriorityHigh,
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		expected = append(expected, created.ID)
+	}
+
+	sort.Strings(expected)
+
+	var (
+		actual []string
+		lastID string
+	)
+
+	for {
+		tasks, err := store.ListAfter(context.Background(), lastID, 2)
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		if len(tasks) == 0 {
+			break
+		}
+
+		for _, task := range tasks {
+			actual = append(actual, task.ID)
+		}
+
+		lastID = tasks[len(tasks)-1].ID
+	}
+
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Fatalf("the expected result does not match: %s", diff)
+	}
+}
+
+func TestTask_Upsert(t *testing.T) {
+	t.Parallel()
+
+	store := memory.NewTask()
+
+	params := internal.ImportParams{
+		CreateParams: internal.CreateParams{
+			Description: "buy milk",
+			Priority:    internal.PriorityHigh,
+		},
+		ExternalID: "external-1",
+	}
+
+	upsert := func(expected internal.ImportStatus) internal.Task {
+		task, status, err := store.Upsert(context.Background(), params)
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		if status != expected {
+			t.Fatalf("expected status %d, got %d", expected, status)
+		}
+
+		return task
+	}
+
+	created := upsert(internal.ImportStatusCreated)
+
+	if unchanged := upsert(internal.ImportStatusUnchanged); !cmp.Equal(created, unchanged) {
+		t.Fatalf("the expected result does not match: %s", cmp.Diff(created, unchanged))
+	}
+
+	params.IsDone = true
+
+	updated := upsert(internal.ImportStatusUpdated)
+
+	if updated.ID != created.ID || updated.Version != 2 || !updated.IsDone {
+		t.Fatalf("expected updated task, got %+v", updated)
+	}
+
+	found, err := store.FindByExternalID(context.Background(), "external-1")
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if diff := cmp.Diff(updated, found); diff != "" {
+		t.Fatalf("the expected result does not match: %s", diff)
+	}
+
+	if _, err := store.Delete(context.Background(), created.ID); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	var ierr *internal.Error
+	if _, err := store.FindByExternalID(context.Background(), "external-1"); !errors.As(err, &ierr) ||
+		ierr.Code() != internal.ErrorCodeNotFound {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
+
+func TestIndexer(t *testing.T) {
+	t.Parallel()
+
+	broker := memory.NewMessageBroker(10)
+	search := memory.NewSearchableTask()
+	indexer := memory.NewIndexer(zap.NewNop(), broker, search)
+
+	indexer.Start()
+
+	task := internal.Task{ID: "1", Description: "buy milk", Priority: internal.PriorityHigh}
+
+	_ = broker.Created(context.Background(), task)
+	_ = broker.Created(context.Background(), internal.Task{ID: "2", Description: "buy bread"})
+	_ = broker.Deleted(context.Background(), "2", 2)
+
+	if err := indexer.Close(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	description := "buy"
+
+	res, err := search.Search(context.Background(), internal.SearchParams{Description: &description, Size: 10})
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if diff := cmp.Diff(internal.SearchResults{Tasks: []internal.Task{task}, Total: 1}, res); diff != "" {
+		t.Fatalf("the expected result does not match: %s", diff)
+	}
+
+	if err := broker.Updated(context.Background(), task); err == nil {
+		t.Fatalf("expected error after closing")
+	}
+}
+
+func assertNotFound(t *testing.T, err error) {
+	t.Helper()
+
+	var ierr *internal.Error
+	if !errors.As(err, &ierr) || ierr.Code() != internal.ErrorCodeNotFound {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
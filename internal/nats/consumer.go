@@ -0,0 +1,43 @@
+package nats
+
+import (
+	"context"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/events"
+)
+
+// CreateConsumer creates the durable pull consumer of the stream, or updates it when it already exists. Messages
+// must be acknowledged explicitly, the ones not acknowledged within ackWait are delivered again up to maxDeliver
+// times; maxAckPending limits the number of messages delivered and not acknowledged yet.
+func CreateConsumer(ctx context.Context, js jetstream.JetStream, durable string, ackWait time.Duration,
+	maxAckPending int, maxDeliver int,
+) (jetstream.Consumer, error) {
+	cons, err := js.CreateOrUpdateConsumer(ctx, Stream, jetstream.ConsumerConfig{
+		Durable:       durable,
+		AckPolicy:     jetstream.AckExplicitPolicy,
+		AckWait:       ackWait,
+		MaxDeliver:    maxDeliver,
+		MaxAckPending: maxAckPending,
+		FilterSubject: Subjects,
+		DeliverPolicy: jetstream.DeliverAllPolicy,
+	})
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "js.CreateOrUpdateConsumer")
+	}
+
+	return cons, nil
+}
+
+// DecodeMessage decodes the event in the message.
+func DecodeMessage(msg jetstream.Msg) (events.Event, error) {
+	evt, err := events.Decode(msg.Subject(), msg.Headers().Get(HeaderContentType), msg.Data())
+	if err != nil {
+		return events.Event{}, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "events.Decode")
+	}
+
+	return evt, nil
+}
@@ -0,0 +1,19 @@
+package nats
+
+import (
+	"context"
+
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// Extract returns a copy of ctx including the W3C Trace Context in the message headers.
+func Extract(ctx context.Context, headers nats.Header) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(headers))
+}
+
+// inject sets the W3C Trace Context in ctx to the message headers.
+func inject(ctx context.Context, headers nats.Header) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(headers))
+}
@@ -0,0 +1,178 @@
+package nats
+
+import (
+	"context"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/indexer"
+)
+
+// Source implements "indexer.Source" using a durable pull consumer, see CreateConsumer.
+//
+// Messages failing to be consumed are redelivered after retryDelay, up to the "MaxDeliver" of the consumer; the
+// last delivery is dead-lettered instead. Poison messages are dead-lettered right away. Dead-lettered messages are
+// published to the DeadLetterSubject of the consumer, and terminated: they are not delivered again.
+type Source struct {
+	logger            *zap.Logger
+	conn              *nats.Conn
+	js                jetstream.JetStream
+	consumer          jetstream.Consumer
+	batchSize         int
+	retryDelay        time.Duration
+	deadLetterSubject string
+	maxDeliver        int
+}
+
+// NewSource instantiates the Source, batchSize is the maximum number of messages pulled at once; js is used for
+// publishing the dead-lettered messages.
+func NewSource(logger *zap.Logger, conn *nats.Conn, js jetstream.JetStream, consumer jetstream.Consumer,
+	batchSize int, retryDelay time.Duration,
+) *Source {
+	info := consumer.CachedInfo()
+
+	return &Source{
+		logger:            logger,
+		conn:              conn,
+		js:                js,
+		consumer:          consumer,
+		batchSize:         batchSize,
+		retryDelay:        retryDelay,
+		deadLetterSubject: DeadLetterSubject(info.Name),
+		maxDeliver:        info.Config.MaxDeliver,
+	}
+}
+
+// Consume calls handler for each message until ctx is done.
+func (s *Source) Consume(ctx context.Context, handler indexer.Handler) error {
+	// Messages are handled sequentially by the consumer.
+	cc, err := s.consumer.Consume(func(msg jetstream.Msg) {
+		// The trace started by the request publishing the event continues while indexing it.
+		handler(Extract(context.Background(), msg.Headers()), s.message(msg))
+	},
+		jetstream.PullMaxMessages(s.batchSize),
+		jetstream.ConsumeErrHandler(func(_ jetstream.ConsumeContext, err error) {
+			s.logger.Error("Couldn't consume messages", zap.Error(err))
+		}),
+	)
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "consumer.Consume")
+	}
+
+	<-ctx.Done()
+
+	// Messages already pulled are handled before returning.
+	cc.Drain()
+
+	<-cc.Closed()
+
+	return nil
+}
+
+// Ping indicates whether the connection is connected.
+func (s *Source) Ping(_ context.Context) error {
+	if !s.conn.IsConnected() {
+		return internal.NewErrorf(internal.ErrorCodeUnknown, "connection status %s", s.conn.Status())
+	}
+
+	return nil
+}
+
+func (s *Source) message(msg jetstream.Msg) indexer.Message {
+	var deadLettered bool
+
+	return indexer.Message{
+		ID:          msg.Headers().Get(jetstream.MsgIDHeader),
+		Type:        msg.Subject(),
+		ContentType: msg.Headers().Get(HeaderContentType),
+		Data:        msg.Data(),
+		Ack: func() {
+			var err error
+
+			// Dead-lettered messages are terminated instead.
+			if deadLettered {
+				err = msg.Term()
+			} else {
+				err = msg.Ack()
+			}
+
+			if err != nil {
+				s.logger.Error("Couldn't acknowledge message", zap.Error(err))
+			}
+		},
+		Retry: func(cause error) {
+			// The server drops the message after the last delivery, unless it's dead-lettered.
+			if s.lastDelivery(msg) {
+				if err := s.deadLetter(msg, cause); err != nil {
+					s.logger.Error("Couldn't dead-letter message", zap.Error(err))
+				} else if err := msg.Term(); err != nil {
+					s.logger.Error("Couldn't terminate message", zap.Error(err))
+				}
+
+				return
+			}
+
+			if err := msg.NakWithDelay(s.retryDelay); err != nil {
+				s.logger.Error("Couldn't retry message", zap.Error(err))
+			}
+		},
+		DeadLetter: func(cause error) error {
+			if err := s.deadLetter(msg, cause); err != nil {
+				return err
+			}
+
+			deadLettered = true
+
+			return nil
+		},
+	}
+}
+
+// lastDelivery indicates whether the message reached the "MaxDeliver" of the consumer.
+func (s *Source) lastDelivery(msg jetstream.Msg) bool {
+	if s.maxDeliver <= 0 {
+		return false
+	}
+
+	meta, err := msg.Metadata()
+	if err != nil {
+		return false
+	}
+
+	return meta.NumDelivered >= uint64(s.maxDeliver) //nolint: gosec
+}
+
+// deadLetter publishes the message to the dead letter subject, including the headers of the original message.
+func (s *Source) deadLetter(msg jetstream.Msg, cause error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	dlq := nats.NewMsg(s.deadLetterSubject)
+	dlq.Data = msg.Data()
+
+	for key, values := range msg.Headers() {
+		dlq.Header[key] = values
+	}
+
+	dlq.Header.Set(HeaderSubject, msg.Subject())
+	dlq.Header.Set(HeaderError, cause.Error())
+
+	var opts []jetstream.PublishOpt
+
+	// Dead-lettering the message again, for example after failing to terminate it, is deduplicated by the server.
+	if id := msg.Headers().Get(jetstream.MsgIDHeader); id != "" {
+		dlq.Header.Del(jetstream.MsgIDHeader)
+
+		opts = append(opts, jetstream.WithMsgID(s.deadLetterSubject+":"+id))
+	}
+
+	if _, err := s.js.PublishMsg(ctx, dlq, opts...); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "js.PublishMsg")
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package nats_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api/internal/indexer"
+	"github.com/MarioCarrion/todo-api/internal/nats"
+)
+
+func TestSource_DeadLetter(t *testing.T) {
+	t.Parallel()
+
+	js := newJetStream(t)
+
+	ctx := context.Background()
+
+	if err := nats.CreateStream(ctx, js); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if _, err := js.Publish(ctx, "tasks.event.created", []byte("poison")); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	tests := []struct {
+		name     string
+		durable  string
+		handle   func(indexer.Message)
+		expected string
+	}{
+		{
+			"OK: dead-lettered",
+			"poison",
+			func(msg indexer.Message) {
+				if err := msg.DeadLetter(errors.New("can't be decoded")); err != nil {
+					t.Errorf("expected no error, got %s", err)
+				}
+
+				msg.Ack()
+			},
+			"can't be decoded",
+		},
+		{
+			"OK: last delivery",
+			"retried",
+			func(msg indexer.Message) {
+				msg.Retry(errors.New("unavailable"))
+			},
+			"unavailable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Not parallel, the consumers share the server.
+
+			// Messages are delivered once, so the first delivery is the last one.
+			consumer, err := nats.CreateConsumer(ctx, js, tt.durable, time.Minute, 10, 1)
+			if err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			source := nats.NewSource(zap.NewNop(), nil, js, consumer, 10, time.Millisecond)
+
+			ctxConsume, cancel := context.WithTimeout(ctx, 10*time.Second)
+			defer cancel()
+
+			handledC := make(chan struct{}, 1)
+
+			go func() {
+				_ = source.Consume(ctxConsume, func(_ context.Context, msg indexer.Message) {
+					tt.handle(msg)
+
+					select {
+					case handledC <- struct{}{}:
+					default:
+					}
+				})
+			}()
+
+			select {
+			case <-handledC:
+			case <-ctxConsume.Done():
+				t.Fatalf("expected message to be handled")
+			}
+
+			stream, err := js.Stream(ctx, nats.DeadLetterStream)
+			if err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			msg, err := stream.GetLastMsgForSubject(ctx, nats.DeadLetterSubject(tt.durable))
+			if err != nil {
+				t.Fatalf("expected dead-lettered message, got %s", err)
+			}
+
+			if actual := msg.Header.Get(nats.HeaderError); actual != tt.expected {
+				t.Fatalf("expected error %q, got %q", tt.expected, actual)
+			}
+
+			if actual := msg.Header.Get(nats.HeaderSubject); actual != "tasks.event.created" {
+				t.Fatalf("expected original subject, got %q", actual)
+			}
+
+			if string(msg.Data) != "poison" {
+				t.Fatalf("expected original data, got %q", msg.Data)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package nats
+
+import (
+	"context"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/events"
+)
+
+const otelName = "github.com/MarioCarrion/todo-api/internal/nats"
+
+const (
+	// Stream is the name of the JetStream stream keeping the Task events.
+	Stream = "TASKS"
+
+	// Subjects matches the subjects the Task events are published to, the subject is the event type, for example
+	// "tasks.event.created".
+	Subjects = "tasks.event.*"
+)
+
+const (
+	// DeadLetterStream is the name of the JetStream stream keeping the dead-lettered messages, see
+	// DeadLetterSubject.
+	DeadLetterStream = "TASKS_DLQ"
+
+	// deadLetterSubjects matches the subjects of the dead-lettered messages.
+	deadLetterSubjects = "tasks.dlq.*"
+
+	// maxDeliveriesAdvisory matches the advisories published by the server when a message reaches the "MaxDeliver"
+	// of a consumer of Stream without being acknowledged, it includes the stream sequence of the message.
+	maxDeliveriesAdvisory = "$JS.EVENT.ADVISORY.CONSUMER.MAX_DELIVERIES." + Stream + ".*"
+)
+
+const (
+	// HeaderContentType is the header indicating the codec used for encoding the event.
+	HeaderContentType = "Content-Type"
+
+	// HeaderError is the header of the dead-lettered messages indicating the error.
+	HeaderError = "X-Error"
+
+	// HeaderSubject is the header of the dead-lettered messages indicating the subject they were published to.
+	HeaderSubject = "X-Subject"
+)
+
+// DeadLetterSubject returns the subject the messages dead-lettered by the durable consumer are published to.
+func DeadLetterSubject(durable string) string {
+	return "tasks.dlq." + durable
+}
+
+// CreateStream creates the stream, and the dead letter stream, or updates them when they already exist.
+func CreateStream(ctx context.Context, js jetstream.JetStream) error {
+	if _, err := js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:       Stream,
+		Subjects:   []string{Subjects},
+		Storage:    jetstream.FileStorage,
+		Duplicates: 2 * time.Minute,
+	}); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "js.CreateOrUpdateStream")
+	}
+
+	// The advisories are kept as well, those are the messages dropped by the server after reaching "MaxDeliver"
+	// because they couldn't be dead-lettered; they are kept in Stream so they can be found using the sequence.
+	if _, err := js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:       DeadLetterStream,
+		Subjects:   []string{deadLetterSubjects, maxDeliveriesAdvisory},
+		Storage:    jetstream.FileStorage,
+		Duplicates: 2 * time.Minute,
+	}); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "js.CreateOrUpdateStream")
+	}
+
+	return nil
+}
+
+// Task represents the repository used for publishing Task records to JetStream.
+type Task struct {
+	js    jetstream.JetStream
+	codec events.Codec
+}
+
+// NewTask instantiates the Task repository, the stream is expected to exist already, see CreateStream.
+func NewTask(js jetstream.JetStream, codec events.Codec) *Task {
+	return &Task{
+		js:    js,
+		codec: codec,
+	}
+}
+
+// Created publishes a message indicating a task was created.
+func (t *Task) Created(ctx context.Context, task internal.Task) error {
+	return t.publish(ctx, "Task.Created", events.TypeTaskCreated, task)
+}
+
+// Deleted publishes a message indicating a task was deleted.
+func (t *Task) Deleted(ctx context.Context, id string, version int64) error {
+	return t.publish(ctx, "Task.Deleted", events.TypeTaskDeleted, internal.Task{ID: id, Version: version})
+}
+
+// Updated publishes a message indicating a task was updated.
+func (t *Task) Updated(ctx context.Context, task internal.Task) error {
+	return t.publish(ctx, "Task.Updated", events.TypeTaskUpdated, task)
+}
+
+// DueSoon publishes a message indicating a task is due soon.
+func (t *Task) DueSoon(ctx context.Context, task internal.Task) error {
+	return t.publish(ctx, "Task.DueSoon", events.TypeTaskDueSoon, task)
+}
+
+// Overdue publishes a message indicating a task is overdue.
+func (t *Task) Overdue(ctx context.Context, task internal.Task) error {
+	return t.publish(ctx, "Task.Overdue", events.TypeTaskOverdue, task)
+}
+
+func (t *Task) publish(ctx context.Context, spanName, subject string, task internal.Task) error {
+	ctx, span := otel.Tracer(otelName).Start(ctx, spanName, trace.WithSpanKind(trace.SpanKindProducer))
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.KeyValue{
+			Key:   semconv.MessagingSystemKey,
+			Value: attribute.StringValue("nats"),
+		},
+		attribute.KeyValue{
+			Key:   semconv.MessagingDestinationKey,
+			Value: attribute.StringValue(subject),
+		},
+	)
+
+	//-
+
+	evt := events.NewTaskEvent(subject, task)
+
+	b, err := t.codec.Marshal(evt)
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "codec.Marshal")
+	}
+
+	msg := nats.NewMsg(subject)
+	msg.Data = b
+	msg.Header.Set(HeaderContentType, t.codec.ContentType())
+
+	inject(ctx, msg.Header)
+
+	// The event ID is used for deduplicating the messages published more than once within the duplicates window,
+	// for example when the acknowledgement from the server is lost and publishing is retried.
+	if _, err := t.js.PublishMsg(ctx, msg, jetstream.WithMsgID(evt.ID)); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "js.PublishMsg")
+	}
+
+	return nil
+}
@@ -0,0 +1,163 @@
+package nats_test
+
+import (
+	"context"
+	"net"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	natsgo "github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/ory/dockertest/v3"
+	"github.com/ory/dockertest/v3/docker"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/events"
+	"github.com/MarioCarrion/todo-api/internal/nats"
+)
+
+func TestTask(t *testing.T) {
+	t.Parallel()
+
+	js := newJetStream(t)
+
+	ctx := context.Background()
+
+	if err := nats.CreateStream(ctx, js); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	codec, err := events.NewCodec("json")
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	task := nats.NewTask(js, codec)
+
+	created := internal.Task{ID: "1", Description: "new task", Priority: internal.PriorityHigh}
+	updated := internal.Task{ID: "1", Description: "updated task", IsDone: true}
+
+	if err := task.Created(ctx, created); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if err := task.Updated(ctx, updated); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if err := task.Deleted(ctx, "1", 2); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	consumer, err := nats.CreateConsumer(ctx, js, "test", time.Minute, 10, 5)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	batch, err := consumer.Fetch(3, jetstream.FetchMaxWait(5*time.Second))
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	var actual []events.Event
+
+	for msg := range batch.Messages() {
+		evt, err := nats.DecodeMessage(msg)
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		if err := msg.Ack(); err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		actual = append(actual, evt)
+	}
+
+	if err := batch.Error(); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	expected := []struct {
+		Type string
+		Task internal.Task
+	}{
+		{events.TypeTaskCreated, created},
+		{events.TypeTaskUpdated, updated},
+		{events.TypeTaskDeleted, internal.Task{ID: "1"}},
+	}
+
+	if len(actual) != len(expected) {
+		t.Fatalf("expected %d events, got %d", len(expected), len(actual))
+	}
+
+	for i, evt := range actual {
+		if evt.Type != expected[i].Type {
+			t.Fatalf("expected type %s, got %s", expected[i].Type, evt.Type)
+		}
+
+		if !cmp.Equal(expected[i].Task, evt.Task, cmpopts.EquateEmpty()) {
+			t.Fatalf("expected result does not match: %s", cmp.Diff(expected[i].Task, evt.Task, cmpopts.EquateEmpty()))
+		}
+	}
+}
+
+func newJetStream(tb testing.TB) jetstream.JetStream {
+	tb.Helper()
+
+	pool, err := dockertest.NewPool("")
+	if err != nil {
+		tb.Fatalf("Couldn't connect to docker: %s", err)
+	}
+
+	pool.MaxWait = 30 * time.Second
+
+	resource, err := pool.RunWithOptions(&dockertest.RunOptions{
+		Repository: "nats",
+		Tag:        "2.10-alpine",
+		Cmd:        []string{"-js"},
+	}, func(config *docker.HostConfig) {
+		config.AutoRemove = true
+		config.RestartPolicy = docker.RestartPolicy{
+			Name: "no",
+		}
+	})
+	if err != nil {
+		tb.Fatalf("Couldn't start resource: %s", err)
+	}
+
+	_ = resource.Expire(60)
+
+	tb.Cleanup(func() {
+		if errC := pool.Purge(resource); errC != nil {
+			tb.Fatalf("Couldn't purge container: %v", errC)
+		}
+	})
+
+	host := resource.Container.NetworkSettings.IPAddress + ":4222"
+	if runtime.GOOS == "darwin" { // MacOS-specific
+		host = net.JoinHostPort(resource.GetBoundIP("4222/tcp"), resource.GetPort("4222/tcp"))
+	}
+
+	var conn *natsgo.Conn
+
+	if err = pool.Retry(func() error {
+		conn, err = natsgo.Connect("nats://" + host)
+
+		return err
+	}); err != nil {
+		tb.Fatalf("Couldn't connect to NATS: %s", err)
+	}
+
+	tb.Cleanup(conn.Close)
+
+	js, err := jetstream.New(conn)
+	if err != nil {
+		tb.Fatalf("Couldn't create JetStream: %s", err)
+	}
+
+	return js
+}
@@ -0,0 +1,78 @@
+package internal
+
+import (
+	"net/mail"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// NotificationPreference indicates the Task events notified by email to a user.
+//
+// XXX: Tasks don't have owners or assignees yet, users are identified by their email and they are notified about
+// the events of all the Tasks.
+type NotificationPreference struct {
+	Email string
+	// EventTypes are the types of the events notified, all of them when empty.
+	EventTypes []string
+	// Digest indicates the events are notified together, periodically, instead of one email per event.
+	Digest    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Subscribed indicates whether the events of the received type are notified.
+func (n NotificationPreference) Subscribed(eventType string) bool {
+	if len(n.EventTypes) == 0 {
+		return true
+	}
+
+	for _, typ := range n.EventTypes {
+		if typ == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SaveNotificationPreferenceParams defines the arguments used for creating, or replacing, NotificationPreference
+// records.
+type SaveNotificationPreferenceParams struct {
+	Email      string
+	EventTypes []string
+	Digest     bool
+}
+
+// Validate indicates whether the fields are valid or not.
+func (s SaveNotificationPreferenceParams) Validate() error {
+	if err := validation.ValidateStruct(&s,
+		validation.Field(&s.Email, validation.Required, validation.Length(1, 254), validation.By(validateEmail)),
+		validation.Field(&s.EventTypes, validation.Each(validation.Required, validation.Match(eventTypeRegEx))),
+	); err != nil {
+		return WrapErrorf(err, ErrorCodeInvalidArgument, "invalid values")
+	}
+
+	return nil
+}
+
+func validateEmail(value interface{}) error {
+	val, _ := value.(string)
+
+	// Only the address is allowed, without a display name like "Name <user@example.com>".
+	addr, err := mail.ParseAddress(val)
+	if err != nil || addr.Address != val {
+		return NewErrorf(ErrorCodeInvalidArgument, "must be a valid email address")
+	}
+
+	return nil
+}
+
+// Notification is a Task event waiting to be notified in the next digest.
+type Notification struct {
+	EventID   string
+	EventType string
+	// Time indicates when the event happened.
+	Time time.Time
+	Task Task
+}
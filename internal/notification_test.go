@@ -0,0 +1,97 @@
+package internal_test
+
+import (
+	"errors"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/MarioCarrion/todo-api/internal"
+)
+
+func TestSaveNotificationPreferenceParams_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   internal.SaveNotificationPreferenceParams
+		withErr bool
+	}{
+		{
+			"OK",
+			internal.SaveNotificationPreferenceParams{
+				Email:      "user@example.com",
+				EventTypes: []string{"tasks.event.due_soon", "tasks.event.overdue"},
+				Digest:     true,
+			},
+			false,
+		},
+		{
+			"OK: all event types",
+			internal.SaveNotificationPreferenceParams{
+				Email: "user@example.com",
+			},
+			false,
+		},
+		{
+			"ERR: empty",
+			internal.SaveNotificationPreferenceParams{},
+			true,
+		},
+		{
+			"ERR: invalid email",
+			internal.SaveNotificationPreferenceParams{
+				Email: "user",
+			},
+			true,
+		},
+		{
+			"ERR: email with name",
+			internal.SaveNotificationPreferenceParams{
+				Email: "User <user@example.com>",
+			},
+			true,
+		},
+		{
+			"ERR: invalid event type",
+			internal.SaveNotificationPreferenceParams{
+				Email:      "user@example.com",
+				EventTypes: []string{"overdue"},
+			},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			actualErr := tt.input.Validate()
+			if (actualErr != nil) != tt.withErr {
+				t.Fatalf("expected error %t, got %s", tt.withErr, actualErr)
+			}
+
+			var ierr validation.Errors
+			if tt.withErr && !errors.As(actualErr, &ierr) {
+				t.Fatalf("expected %T error, got %T", ierr, actualErr)
+			}
+		})
+	}
+}
+
+func TestNotificationPreference_Subscribed(t *testing.T) {
+	t.Parallel()
+
+	all := internal.NotificationPreference{}
+	some := internal.NotificationPreference{EventTypes: []string{"tasks.event.overdue"}}
+
+	if !all.Subscribed("tasks.event.updated") {
+		t.Fatalf("expected subscribed to all the event types")
+	}
+
+	if !some.Subscribed("tasks.event.overdue") || some.Subscribed("tasks.event.updated") {
+		t.Fatalf("expected subscribed only to %v", some.EventTypes)
+	}
+}
@@ -0,0 +1,405 @@
+// Package notifier implements the notifier sending emails about the Task events to the users subscribed using
+// NotificationPreferences, one email per event or periodic digests.
+package notifier
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/events"
+	"github.com/MarioCarrion/todo-api/internal/indexer"
+	"github.com/MarioCarrion/todo-api/internal/worker"
+)
+
+const otelName = "github.com/MarioCarrion/todo-api/internal/notifier"
+
+// digestType is the "type" attribute of the metrics of the digests, events use their type instead.
+const digestType = "digest"
+
+// Repository defines the datastore handling the NotificationPreference records and the digests.
+type Repository interface {
+	List(ctx context.Context) ([]internal.NotificationPreference, error)
+	AddToDigest(ctx context.Context, email string, notification internal.Notification) error
+	DigestEmails(ctx context.Context) ([]string, error)
+	Digest(ctx context.Context, email string, size int32) ([]internal.Notification, error)
+	ClearDigest(ctx context.Context, email string, eventIDs []string) error
+}
+
+// Sender defines the service sending the emails, like SMTP.
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// Locker defines the lock electing the replica sending the digests.
+type Locker interface {
+	// TryLock acquires the lock without waiting, it indicates whether the lock is held.
+	TryLock(ctx context.Context) (bool, error)
+	Unlock(ctx context.Context) error
+}
+
+// Options defines the retries and the digests of the Notifier, zero values use the defaults.
+type Options struct {
+	// RetryOptions defines how the emails are sent again.
+	worker.RetryOptions
+	// DigestInterval is the time between digests, defaults to 1h.
+	DigestInterval time.Duration
+	// DigestSize is the maximum number of events included in each digest email, defaults to 100.
+	DigestSize int32
+}
+
+// Notifier notifies the Task events received from a Source to the users subscribed to them:
+//
+//   - Users not using digests receive one email per event, sent in the order they were received.
+//   - Events notified using digests are stored using the Repository, and sent every Options.DigestInterval by the
+//     replica holding the lock.
+//   - Failed emails are sent again using exponential backoff, except the ones rejected by the SMTP server.
+//
+// Events are acknowledged after they are sent to all the subscribed users, or added to their digests; emails are
+// sent at least once.
+type Notifier struct {
+	logger    *zap.Logger
+	source    indexer.Source
+	repo      Repository
+	sender    Sender
+	templates *Templates
+	lock      Locker
+	opts      Options
+
+	sent         metric.Int64Counter
+	failed       metric.Int64Counter
+	deadLettered metric.Int64Counter
+
+	lifecycle *worker.Lifecycle
+}
+
+// NewNotifier instantiates the Notifier.
+func NewNotifier(logger *zap.Logger, source indexer.Source, repo Repository, sender Sender, templates *Templates,
+	lock Locker, opts Options,
+) *Notifier {
+	opts.RetryOptions = opts.RetryOptions.WithDefaults()
+
+	if opts.DigestInterval <= 0 {
+		opts.DigestInterval = time.Hour
+	}
+
+	if opts.DigestSize <= 0 {
+		opts.DigestSize = 100
+	}
+
+	meter := otel.Meter(otelName)
+
+	return &Notifier{
+		logger:       logger,
+		source:       source,
+		repo:         repo,
+		sender:       sender,
+		templates:    templates,
+		lock:         lock,
+		opts:         opts,
+		sent:         worker.NewCounter(meter, "notifier.sent", "Emails sent"),
+		failed:       worker.NewCounter(meter, "notifier.failed", "Emails never sent, or events not added to digests"),
+		deadLettered: worker.NewCounter(meter, "notifier.dead_lettered", "Messages that can't be notified"),
+		lifecycle:    worker.NewLifecycle(),
+	}
+}
+
+// ListenAndServe consumes the Source, and sends the digests, until Shutdown is called.
+func (n *Notifier) ListenAndServe() error {
+	done := n.lifecycle.Start()
+
+	// Stops sending the digests when the Source fails.
+	ctx, cancel := context.WithCancel(n.lifecycle.Context())
+
+	var wg sync.WaitGroup
+
+	defer func() {
+		cancel()
+		wg.Wait()
+		done()
+	}()
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		n.digests(ctx)
+	}()
+
+	err := n.source.Consume(ctx, n.Handle)
+
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "source.Consume")
+	}
+
+	n.logger.Info("No more messages to consume. Exiting.")
+
+	return nil
+}
+
+// Shutdown stops consuming the Source and sending the digests, events being notified are not acknowledged so
+// they are notified again after restarting.
+func (n *Notifier) Shutdown(ctx context.Context) error {
+	n.logger.Info("Shutting down notifier")
+
+	if err := n.lifecycle.Stop(ctx); err != nil {
+		return err //nolint: wrapcheck
+	}
+
+	if err := n.lock.Unlock(ctx); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "lock.Unlock")
+	}
+
+	return nil
+}
+
+// Health indicates whether the Notifier is consuming the Source.
+func (n *Notifier) Health(ctx context.Context) error {
+	if err := n.lifecycle.Health(); err != nil {
+		return err //nolint: wrapcheck
+	}
+
+	if err := n.source.Ping(ctx); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "source.Ping")
+	}
+
+	return nil
+}
+
+// Handle notifies the event in the message to the subscribed users, it implements "indexer.Handler".
+func (n *Notifier) Handle(ctx context.Context, msg indexer.Message) {
+	n.logger.Info("Received message", zap.String("id", msg.ID), zap.String("type", msg.Type))
+
+	evt, err := events.Decode(msg.Type, msg.ContentType, msg.Data)
+	if err != nil {
+		if indexer.DeadLetter(ctx, n.logger, n.deadLettered, msg, err) {
+			msg.Ack()
+		}
+
+		return
+	}
+
+	// The trace started by the request publishing the event continues while notifying it, sources not
+	// supporting headers include it in the event instead.
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		ctx = events.Extract(ctx, evt)
+	}
+
+	ctx, span := otel.Tracer(otelName).Start(ctx, "Notifier.Handle")
+	defer span.End()
+
+	var preferences []internal.NotificationPreference
+
+	// Listing the preferences is retried until it succeeds, retrying in place keeps the events in order regardless
+	// of the Source.
+	if ok, _ := n.retry(0, func() error {
+		preferences, err = n.repo.List(ctx)
+
+		return err //nolint: wrapcheck
+	}); !ok {
+		// Shutting down, not acknowledged so it's notified again.
+		return
+	}
+
+	notification := internal.Notification{
+		EventID:   evt.ID,
+		EventType: evt.Type,
+		Time:      evt.Time,
+		Task:      evt.Task,
+	}
+
+	for _, preference := range preferences {
+		if !preference.Subscribed(evt.Type) {
+			continue
+		}
+
+		if !n.notify(ctx, preference, notification) {
+			// Shutting down, not acknowledged so it's notified again.
+			return
+		}
+	}
+
+	msg.Ack()
+}
+
+// notify sends one email notifying the event, or adds it to the digest, until it succeeds, the maximum number of
+// attempts is reached or it fails permanently; it returns false when it's interrupted by shutting down.
+func (n *Notifier) notify(ctx context.Context, preference internal.NotificationPreference,
+	notification internal.Notification,
+) bool {
+	attrs := metric.WithAttributes(attribute.String("type", notification.EventType))
+
+	fn := func() error {
+		return n.repo.AddToDigest(ctx, preference.Email, notification) //nolint: wrapcheck
+	}
+
+	if !preference.Digest {
+		msg, err := n.templates.Notification(preference.Email, notification)
+		if err != nil {
+			n.logger.Error("Couldn't render email", zap.String("event", notification.EventID), zap.Error(err))
+			n.failed.Add(ctx, 1, attrs)
+
+			return true
+		}
+
+		fn = func() error {
+			return n.sender.Send(ctx, msg) //nolint: wrapcheck
+		}
+	}
+
+	ok, err := n.retry(n.opts.MaxAttempts, fn)
+	if !ok {
+		return false
+	}
+
+	if err != nil {
+		n.logger.Info("Couldn't notify event",
+			zap.String("email", preference.Email),
+			zap.String("event", notification.EventID),
+			zap.Bool("digest", preference.Digest),
+			zap.Error(err))
+
+		n.failed.Add(ctx, 1, attrs)
+
+		return true
+	}
+
+	if !preference.Digest {
+		n.sent.Add(ctx, 1, attrs)
+	}
+
+	return true
+}
+
+// retry calls fn until it succeeds, maxAttempts is reached, zero means unlimited attempts, or it fails
+// permanently; it returns the last error, and false when it's interrupted by shutting down.
+func (n *Notifier) retry(maxAttempts int, fn func() error) (bool, error) {
+	backoff := n.opts.Backoff
+
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return true, nil
+		}
+
+		if maxAttempts > 0 && (!isRetryable(err) || attempt >= maxAttempts) {
+			return true, err
+		}
+
+		n.logger.Error("Failed, trying again", zap.Int("attempt", attempt), zap.Error(err))
+
+		if !n.lifecycle.Wait(backoff) {
+			return false, err
+		}
+
+		backoff = n.opts.Next(backoff)
+	}
+}
+
+// digests sends the digests every Options.DigestInterval until shutting down, only when holding the lock.
+func (n *Notifier) digests(ctx context.Context) {
+	ticker := time.NewTicker(n.opts.DigestInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		n.SendDigests(ctx)
+	}
+}
+
+// SendDigests sends the pending digests, only when holding the lock; the events included in the digests failing
+// to be sent are sent in the next digest.
+func (n *Notifier) SendDigests(ctx context.Context) {
+	ctx, span := otel.Tracer(otelName).Start(ctx, "Notifier.SendDigests")
+	defer span.End()
+
+	//-
+
+	leader, err := n.lock.TryLock(ctx)
+	if err != nil {
+		n.logger.Error("Couldn't acquire lock", zap.Error(err))
+	}
+
+	if !leader {
+		return
+	}
+
+	emails, err := n.repo.DigestEmails(ctx)
+	if err != nil {
+		n.logger.Error("Couldn't list digests", zap.Error(err))
+
+		return
+	}
+
+	for _, email := range emails {
+		if !n.sendDigest(ctx, email) {
+			return
+		}
+	}
+}
+
+// sendDigest sends the pending events to the email, in one or more digests of up to Options.DigestSize events;
+// it returns false when shutting down.
+func (n *Notifier) sendDigest(ctx context.Context, email string) bool {
+	attrs := metric.WithAttributes(attribute.String("type", digestType))
+
+	for {
+		notifications, err := n.repo.Digest(ctx, email, n.opts.DigestSize)
+		if err != nil {
+			n.logger.Error("Couldn't read digest", zap.Error(err))
+
+			return true
+		}
+
+		if len(notifications) == 0 {
+			return true
+		}
+
+		msg, err := n.templates.Digest(email, notifications)
+		if err != nil {
+			n.logger.Error("Couldn't render digest", zap.Error(err))
+			n.failed.Add(ctx, 1, attrs)
+
+			return true
+		}
+
+		if err := n.sender.Send(ctx, msg); err != nil {
+			n.logger.Error("Couldn't send digest", zap.Error(err))
+			n.failed.Add(ctx, 1, attrs)
+
+			return ctx.Err() == nil
+		}
+
+		n.sent.Add(ctx, 1, attrs)
+
+		ids := make([]string, len(notifications))
+
+		for i, notification := range notifications {
+			ids[i] = notification.EventID
+		}
+
+		// XXX: The digest is sent again when clearing it fails, the same way events are notified at least once.
+		if err := n.repo.ClearDigest(context.WithoutCancel(ctx), email, ids); err != nil {
+			n.logger.Error("Couldn't clear digest", zap.Error(err))
+
+			return true
+		}
+
+		if len(notifications) < int(n.opts.DigestSize) {
+			return true
+		}
+	}
+}
@@ -0,0 +1,433 @@
+package notifier_test
+
+import (
+	"context"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/events"
+	"github.com/MarioCarrion/todo-api/internal/indexer"
+	"github.com/MarioCarrion/todo-api/internal/notifier"
+	"github.com/MarioCarrion/todo-api/internal/worker"
+)
+
+func TestNotifier_Handle(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		replies  []int
+		expected int
+	}{
+		{
+			"OK",
+			[]int{250},
+			1,
+		},
+		{
+			"OK: retried",
+			[]int{451, 421, 250},
+			3,
+		},
+		{
+			"ERR: rejected",
+			[]int{550, 250},
+			1,
+		},
+		{
+			"ERR: max attempts",
+			[]int{451, 451, 451, 250},
+			3,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := newSMTPServer(t, tt.replies...)
+
+			repo := &fakeRepository{
+				preferences: []internal.NotificationPreference{
+					{Email: "instant@example.com", EventTypes: []string{events.TypeTaskCreated}},
+					{Email: "digest@example.com", Digest: true},
+					{Email: "unsubscribed@example.com", EventTypes: []string{events.TypeTaskDeleted}},
+				},
+			}
+
+			n := newNotifier(t, server, repo, &fakeLocker{})
+
+			msg, acked := newMessage(t, events.TypeTaskCreated, internal.Task{ID: "1", Description: "created"})
+
+			n.Handle(context.Background(), msg)
+
+			if !acked.Load() {
+				t.Fatalf("expected message to be acknowledged")
+			}
+
+			if actual := server.attempts.Load(); actual != int64(tt.expected) {
+				t.Fatalf("expected %d attempts, got %d", tt.expected, actual)
+			}
+
+			if actual := repo.digestEmails(); !cmp.Equal([]string{"digest@example.com"}, actual) {
+				t.Fatalf("expected digests don't match: %s", cmp.Diff([]string{"digest@example.com"}, actual))
+			}
+
+			if tt.replies[tt.expected-1] != 250 {
+				return
+			}
+
+			mail := server.lastMail()
+
+			if mail.to != "instant@example.com" {
+				t.Fatalf("expected recipient instant@example.com, got %s", mail.to)
+			}
+
+			if !strings.Contains(mail.data, "Subject: Task created: created") {
+				t.Fatalf("expected subject, got %s", mail.data)
+			}
+		})
+	}
+}
+
+func TestNotifier_Handle_DeadLetter(t *testing.T) {
+	t.Parallel()
+
+	var deadLettered, acked atomic.Bool
+
+	n := newNotifier(t, newSMTPServer(t, 250), &fakeRepository{}, &fakeLocker{})
+
+	n.Handle(context.Background(), indexer.Message{
+		ID:          "invalid",
+		ContentType: events.ContentTypeJSON,
+		Data:        []byte("{"),
+		Ack:         func() { acked.Store(true) },
+		DeadLetter: func(error) error {
+			deadLettered.Store(true)
+
+			return nil
+		},
+	})
+
+	if !deadLettered.Load() || !acked.Load() {
+		t.Fatalf("expected message to be dead-lettered and acknowledged")
+	}
+}
+
+func TestNotifier_SendDigests(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		leader   bool
+		replies  []int
+		expected int
+		pending  int
+	}{
+		{
+			"OK",
+			true,
+			[]int{250},
+			2,
+			0,
+		},
+		{
+			"OK: not leader",
+			false,
+			[]int{250},
+			0,
+			3,
+		},
+		{
+			"ERR: rejected",
+			true,
+			[]int{550},
+			1,
+			3,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := newSMTPServer(t, tt.replies...)
+			repo := &fakeRepository{}
+
+			for i, typ := range []string{events.TypeTaskCreated, events.TypeTaskUpdated, events.TypeTaskDueSoon} {
+				_ = repo.AddToDigest(context.Background(), "digest@example.com", internal.Notification{
+					EventID:   typ,
+					EventType: typ,
+					Time:      time.Date(2026, 1, 1, i, 0, 0, 0, time.UTC),
+					Task:      internal.Task{ID: "1", Description: "task"},
+				})
+			}
+
+			n := newNotifier(t, server, repo, &fakeLocker{leader: tt.leader})
+
+			n.SendDigests(context.Background())
+
+			if actual := server.attempts.Load(); actual != int64(tt.expected) {
+				t.Fatalf("expected %d emails, got %d", tt.expected, actual)
+			}
+
+			if actual := len(repo.digests["digest@example.com"]); actual != tt.pending {
+				t.Fatalf("expected %d pending events, got %d", tt.pending, actual)
+			}
+
+			if tt.expected == 0 || tt.replies[0] != 250 {
+				return
+			}
+
+			if mail := server.lastMail(); !strings.Contains(mail.data, "Subject: Task notifications digest") {
+				t.Fatalf("expected digest subject, got %s", mail.data)
+			}
+		})
+	}
+}
+
+//-
+
+func newNotifier(t *testing.T, server *smtpServer, repo notifier.Repository, lock notifier.Locker) *notifier.Notifier {
+	t.Helper()
+
+	templates, err := notifier.NewTemplates(notifier.DefaultTemplates())
+	if err != nil {
+		t.Fatalf("couldn't parse templates %s", err)
+	}
+
+	host, port, _ := net.SplitHostPort(server.addr)
+
+	sender := notifier.NewSMTP(notifier.SMTPConfig{
+		Host:    host,
+		Port:    port,
+		From:    "todo-api@example.com",
+		Timeout: time.Second,
+	})
+
+	return notifier.NewNotifier(zap.NewNop(), nil, repo, sender, templates, lock, notifier.Options{
+		RetryOptions: worker.RetryOptions{MaxAttempts: 3, Backoff: time.Millisecond},
+		DigestSize:   2,
+	})
+}
+
+type mail struct {
+	to   string
+	data string
+}
+
+type smtpServer struct {
+	addr     string
+	attempts atomic.Int64
+
+	mu    sync.Mutex
+	mails []mail
+}
+
+// newSMTPServer starts a server capturing the emails, it replies to the recipients using the codes in order,
+// repeating the last one.
+func newSMTPServer(t *testing.T, replies ...int) *smtpServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("couldn't listen %s", err)
+	}
+
+	t.Cleanup(func() { listener.Close() })
+
+	server := &smtpServer{addr: listener.Addr().String()}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go server.serve(conn, replies)
+		}
+	}()
+
+	return server
+}
+
+func (s *smtpServer) serve(conn net.Conn, replies []int) {
+	defer conn.Close()
+
+	text := textproto.NewConn(conn)
+
+	var to string
+
+	_ = text.PrintfLine("220 localhost")
+
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+
+		cmd, arg, _ := strings.Cut(line, " ")
+
+		switch strings.ToUpper(cmd) {
+		case "EHLO", "HELO", "MAIL":
+			_ = text.PrintfLine("250 OK")
+		case "RCPT":
+			code := replies[min(int(s.attempts.Add(1))-1, len(replies)-1)]
+			to = strings.Trim(strings.TrimPrefix(arg, "TO:"), "<>")
+
+			_ = text.PrintfLine("%d recipient", code)
+		case "DATA":
+			_ = text.PrintfLine("354 Go ahead")
+
+			data, err := text.ReadDotBytes()
+			if err != nil {
+				return
+			}
+
+			s.mu.Lock()
+			s.mails = append(s.mails, mail{to: to, data: string(data)})
+			s.mu.Unlock()
+
+			_ = text.PrintfLine("250 OK")
+		case "QUIT":
+			_ = text.PrintfLine("221 Bye")
+
+			return
+		default:
+			_ = text.PrintfLine("250 OK")
+		}
+	}
+}
+
+func (s *smtpServer) lastMail() mail {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.mails) == 0 {
+		return mail{}
+	}
+
+	return s.mails[len(s.mails)-1]
+}
+
+type fakeRepository struct {
+	preferences []internal.NotificationPreference
+
+	mu      sync.Mutex
+	digests map[string][]internal.Notification
+}
+
+func (f *fakeRepository) List(_ context.Context) ([]internal.NotificationPreference, error) {
+	return f.preferences, nil
+}
+
+func (f *fakeRepository) AddToDigest(_ context.Context, email string, notification internal.Notification) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.digests == nil {
+		f.digests = make(map[string][]internal.Notification)
+	}
+
+	f.digests[email] = append(f.digests[email], notification)
+
+	return nil
+}
+
+func (f *fakeRepository) DigestEmails(_ context.Context) ([]string, error) {
+	return f.digestEmails(), nil
+}
+
+func (f *fakeRepository) Digest(_ context.Context, email string, size int32) ([]internal.Notification, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	res := f.digests[email]
+
+	return res[:min(len(res), int(size))], nil
+}
+
+func (f *fakeRepository) ClearDigest(_ context.Context, email string, eventIDs []string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var res []internal.Notification
+
+	for _, notification := range f.digests[email] {
+		cleared := false
+
+		for _, id := range eventIDs {
+			if notification.EventID == id {
+				cleared = true
+			}
+		}
+
+		if !cleared {
+			res = append(res, notification)
+		}
+	}
+
+	f.digests[email] = res
+
+	return nil
+}
+
+func (f *fakeRepository) digestEmails() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var res []string
+
+	for email, notifications := range f.digests {
+		if len(notifications) > 0 {
+			res = append(res, email)
+		}
+	}
+
+	return res
+}
+
+type fakeLocker struct {
+	leader bool
+}
+
+func (f *fakeLocker) TryLock(_ context.Context) (bool, error) {
+	return f.leader, nil
+}
+
+func (f *fakeLocker) Unlock(_ context.Context) error {
+	return nil
+}
+
+func newMessage(t *testing.T, typ string, task internal.Task) (indexer.Message, *atomic.Bool) {
+	t.Helper()
+
+	data, err := events.JSON.Marshal(events.NewTaskEvent(typ, task))
+	if err != nil {
+		t.Fatalf("couldn't marshal event %s", err)
+	}
+
+	acked := &atomic.Bool{}
+
+	return indexer.Message{
+		ID:          typ,
+		Type:        typ,
+		ContentType: events.ContentTypeJSON,
+		Data:        data,
+		Ack:         func() { acked.Store(true) },
+	}, acked
+}
@@ -0,0 +1,221 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+
+	"github.com/MarioCarrion/todo-api/internal"
+)
+
+// Message is an email rendered using Templates.
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// SMTPConfig defines the SMTP server used for sending the emails.
+type SMTPConfig struct {
+	Host string
+	Port string
+	// Username and Password are used for authenticating using PLAIN, the authentication is skipped when
+	// Username is empty. Credentials are only sent using TLS, or to localhost.
+	Username string
+	Password string
+	// From is the sender address.
+	From string
+	// Timeout is the maximum time sending each email takes, defaults to 30s.
+	Timeout time.Duration
+}
+
+// SMTP sends the emails using an SMTP server, STARTTLS is used when the server supports it.
+type SMTP struct {
+	config SMTPConfig
+	auth   smtp.Auth
+}
+
+// NewSMTP instantiates the SMTP sender.
+func NewSMTP(config SMTPConfig) *SMTP {
+	if config.Timeout <= 0 {
+		config.Timeout = 30 * time.Second
+	}
+
+	var auth smtp.Auth
+
+	if config.Username != "" {
+		auth = smtp.PlainAuth("", config.Username, config.Password, config.Host)
+	}
+
+	return &SMTP{
+		config: config,
+		auth:   auth,
+	}
+}
+
+// Send sends the message, one connection is used per message.
+func (s *SMTP) Send(ctx context.Context, msg Message) error {
+	ctx, span := otel.Tracer(otelName).Start(ctx, "SMTP.Send")
+	defer span.End()
+
+	//-
+
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	data, err := msg.encode(s.config.From, time.Now())
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "msg.encode")
+	}
+
+	var dialer net.Dialer
+
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(s.config.Host, s.config.Port))
+	if err != nil {
+		span.RecordError(err)
+
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "dialer.DialContext")
+	}
+	defer conn.Close()
+
+	deadline, _ := ctx.Deadline()
+	_ = conn.SetDeadline(deadline)
+
+	if err := s.send(conn, msg.To, data); err != nil {
+		span.RecordError(err)
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *SMTP) send(conn net.Conn, to string, data []byte) error {
+	client, err := smtp.NewClient(conn, s.config.Host)
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "smtp.NewClient")
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.config.Host, MinVersion: tls.VersionTLS12}); err != nil {
+			return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "client.StartTLS")
+		}
+	}
+
+	if s.auth != nil {
+		if err := client.Auth(s.auth); err != nil {
+			return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "client.Auth")
+		}
+	}
+
+	if err := client.Mail(s.config.From); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "client.Mail")
+	}
+
+	if err := client.Rcpt(to); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "client.Rcpt")
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "client.Data")
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "w.Write")
+	}
+
+	if err := w.Close(); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "w.Close")
+	}
+
+	if err := client.Quit(); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "client.Quit")
+	}
+
+	return nil
+}
+
+// isRetryable indicates whether sending the email again may succeed, permanent failures are the ones rejected by
+// the SMTP server using 5xx replies.
+func isRetryable(err error) bool {
+	var terr *textproto.Error
+	if errors.As(err, &terr) {
+		return terr.Code < 500
+	}
+
+	return true
+}
+
+// encode encodes the message using MIME, the text and HTML parts are sent as alternatives.
+func (m Message) encode(from string, now time.Time) ([]byte, error) {
+	var body bytes.Buffer
+
+	mw := multipart.NewWriter(&body)
+
+	for _, part := range []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=utf-8", m.Text},
+		{"text/html; charset=utf-8", m.HTML},
+	} {
+		w, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "mw.CreatePart")
+		}
+
+		qw := quotedprintable.NewWriter(w)
+
+		if _, err := qw.Write([]byte(part.content)); err != nil {
+			return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "qw.Write")
+		}
+
+		if err := qw.Close(); err != nil {
+			return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "qw.Close")
+		}
+	}
+
+	if err := mw.Close(); err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "mw.Close")
+	}
+
+	var res bytes.Buffer
+
+	for _, header := range []struct {
+		key   string
+		value string
+	}{
+		{"From", from},
+		{"To", m.To},
+		{"Subject", mime.QEncoding.Encode("utf-8", m.Subject)},
+		{"Date", now.Format(time.RFC1123Z)},
+		{"Message-ID", fmt.Sprintf("<%s@todo-api>", uuid.NewString())},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", fmt.Sprintf("multipart/alternative; boundary=%q", mw.Boundary())},
+	} {
+		fmt.Fprintf(&res, "%s: %s\r\n", header.key, header.value)
+	}
+
+	res.WriteString("\r\n")
+	res.Write(body.Bytes())
+
+	return res.Bytes(), nil
+}
@@ -0,0 +1,176 @@
+package notifier
+
+import (
+	"bytes"
+	"embed"
+	htmltemplate "html/template"
+	"io/fs"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/events"
+)
+
+//go:embed templates/*.tmpl
+var templates embed.FS
+
+// DefaultTemplates returns the file system including the default "text.tmpl" and "html.tmpl" templates.
+func DefaultTemplates() fs.FS {
+	// NOTE: Safe to ignore error, because the directory is always embedded.
+	fsys, _ := fs.Sub(templates, "templates")
+
+	return fsys
+}
+
+// Templates renders the emails notifying the Task events.
+type Templates struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+// NewTemplates parses the "text.tmpl" and "html.tmpl" templates in fsys, both of them must define the
+// "notification" and "digest" templates.
+func NewTemplates(fsys fs.FS) (*Templates, error) {
+	funcs := map[string]any{
+		"date": formatDate,
+	}
+
+	text, err := texttemplate.New("").Funcs(funcs).ParseFS(fsys, "text.tmpl")
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "texttemplate.ParseFS")
+	}
+
+	html, err := htmltemplate.New("").Funcs(funcs).ParseFS(fsys, "html.tmpl")
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "htmltemplate.ParseFS")
+	}
+
+	for _, name := range []string{"notification", "digest"} {
+		if text.Lookup(name) == nil || html.Lookup(name) == nil {
+			return nil, internal.NewErrorf(internal.ErrorCodeInvalidArgument, "missing %q template", name)
+		}
+	}
+
+	return &Templates{
+		text: text,
+		html: html,
+	}, nil
+}
+
+// Notification renders the email notifying one event.
+func (t *Templates) Notification(to string, notification internal.Notification) (Message, error) {
+	entry := newItem(notification)
+
+	subject := entry.Title + ": " + entry.Description
+	if entry.Deleted {
+		subject = entry.Title + ": " + entry.TaskID
+	}
+
+	return t.render("notification", view{To: to, Subject: subject, Items: []item{entry}})
+}
+
+// Digest renders the email notifying multiple events, oldest first.
+func (t *Templates) Digest(to string, notifications []internal.Notification) (Message, error) {
+	items := make([]item, len(notifications))
+
+	for i, notification := range notifications {
+		items[i] = newItem(notification)
+	}
+
+	return t.render("digest", view{To: to, Subject: "Task notifications digest", Items: items})
+}
+
+func (t *Templates) render(name string, data view) (Message, error) {
+	var text, html bytes.Buffer
+
+	if err := t.text.ExecuteTemplate(&text, name, data); err != nil {
+		return Message{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "text.ExecuteTemplate")
+	}
+
+	if err := t.html.ExecuteTemplate(&html, name, data); err != nil {
+		return Message{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "html.ExecuteTemplate")
+	}
+
+	return Message{
+		To:      data.To,
+		Subject: data.Subject,
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
+
+// view is the data used for executing the templates.
+type view struct {
+	To      string
+	Subject string
+	Items   []item
+}
+
+// item is the notified event, Deleted events only include the TaskID.
+type item struct {
+	Title       string
+	Time        time.Time
+	TaskID      string
+	Description string
+	Priority    string
+	Start       time.Time
+	Due         time.Time
+	Done        bool
+	Deleted     bool
+}
+
+func newItem(notification internal.Notification) item {
+	return item{
+		Title:       newTitle(notification.EventType),
+		Time:        notification.Time,
+		TaskID:      notification.Task.ID,
+		Description: notification.Task.Description,
+		Priority:    newPriority(notification.Task.Priority),
+		Start:       notification.Task.Dates.Start,
+		Due:         notification.Task.Dates.Due,
+		Done:        notification.Task.IsDone,
+		Deleted:     notification.EventType == events.TypeTaskDeleted,
+	}
+}
+
+func newTitle(eventType string) string {
+	switch eventType {
+	case events.TypeTaskCreated:
+		return "Task created"
+	case events.TypeTaskUpdated:
+		return "Task updated"
+	case events.TypeTaskDeleted:
+		return "Task deleted"
+	case events.TypeTaskDueSoon:
+		return "Task due soon"
+	case events.TypeTaskOverdue:
+		return "Task overdue"
+	}
+
+	return eventType
+}
+
+func newPriority(p internal.Priority) string {
+	switch p {
+	case internal.PriorityNone:
+		return "none"
+	case internal.PriorityLow:
+		return "low"
+	case internal.PriorityMedium:
+		return "medium"
+	case internal.PriorityHigh:
+		return "high"
+	}
+
+	return "none"
+}
+
+// formatDate formats the dates using UTC, zero dates are empty.
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format("Mon, 02 Jan 2006 15:04 MST")
+}
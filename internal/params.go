@@ -1,14 +1,26 @@
 package internal
 
 import (
+	"time"
+
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
 
+const (
+	maxReminders  = 5
+	minReminder   = time.Minute
+	maxReminder   = 30 * 24 * time.Hour
+	maxExternalID = 255
+)
+
 // CreateParams defines the arguments used for creating Task records.
 type CreateParams struct {
 	Description string
 	Priority    Priority
 	Dates       Dates
+	// Reminders indicates how long before the due date the Task is due soon, for example 24 hours; a Reminder is
+	// sent for each one of them.
+	Reminders []time.Duration
 }
 
 // Validate indicates whether the fields are valid or not.
@@ -29,11 +41,111 @@ func (c CreateParams) Validate() error {
 		return WrapErrorf(err, ErrorCodeInvalidArgument, "validation.Validate")
 	}
 
+	if err := validation.ValidateStruct(&c,
+		validation.Field(&c.Reminders,
+			validation.Length(0, maxReminders),
+			validation.Each(validation.Min(minReminder), validation.Max(maxReminder)),
+			validation.By(c.validateReminders),
+		),
+	); err != nil {
+		return WrapErrorf(err, ErrorCodeInvalidArgument, "invalid reminders")
+	}
+
+	return nil
+}
+
+func (c CreateParams) validateReminders(_ interface{}) error {
+	if len(c.Reminders) > 0 && c.Dates.Due.IsZero() {
+		return NewErrorf(ErrorCodeInvalidArgument, "require a due date")
+	}
+
+	seen := make(map[time.Duration]struct{}, len(c.Reminders))
+
+	for _, reminder := range c.Reminders {
+		if _, ok := seen[reminder]; ok {
+			return NewErrorf(ErrorCodeInvalidArgument, "must be unique")
+		}
+
+		seen[reminder] = struct{}{}
+	}
+
 	return nil
 }
 
 //-
 
+// ImportParams defines the arguments used for importing Task records created by other applications.
+type ImportParams struct {
+	CreateParams
+	IsDone bool
+	// ExternalID identifies the Task in the other application, importing the same value again updates the Task
+	// instead of creating a new one.
+	ExternalID string
+}
+
+// Validate indicates whether the fields are valid or not.
+func (i ImportParams) Validate() error {
+	if err := i.CreateParams.Validate(); err != nil {
+		return err
+	}
+
+	if err := validation.Validate(i.ExternalID, validation.Length(0, maxExternalID)); err != nil {
+		return WrapErrorf(validation.Errors{"external_id": err}, ErrorCodeInvalidArgument, "invalid external id")
+	}
+
+	return nil
+}
+
+// Matches indicates whether the Task already has the imported values.
+func (i ImportParams) Matches(task Task) bool {
+	return task.Description == i.Description &&
+		task.Priority == i.Priority &&
+		task.Dates.Start.Equal(i.Dates.Start) &&
+		task.Dates.Due.Equal(i.Dates.Due) &&
+		task.IsDone == i.IsDone
+}
+
+// ImportStatus indicates the change made to the datastore when importing a Task record.
+type ImportStatus int8
+
+const (
+	// ImportStatusUnchanged indicates the Task already existed using the same values.
+	ImportStatusUnchanged ImportStatus = iota
+
+	// ImportStatusCreated indicates the Task was created.
+	ImportStatusCreated
+
+	// ImportStatusUpdated indicates the Task already existed and its values were replaced.
+	ImportStatusUpdated
+)
+
+// ImportRow defines a Task record read from an imported file.
+type ImportRow struct {
+	// Row is the line where the record starts in the file, the first one is 1.
+	Row    int
+	Params ImportParams
+	// Err is the error found while reading the record, like an invalid date; the record is not imported.
+	Err error
+}
+
+// ImportResults defines the outcome of importing Task records.
+type ImportResults struct {
+	Created   int64
+	Updated   int64
+	Unchanged int64
+	// Errors are the records that were not imported.
+	Errors []ImportError
+}
+
+// ImportError defines the reason why a record was not imported.
+type ImportError struct {
+	Row        int
+	ExternalID string
+	Err        error
+}
+
+//-
+
 // SearchParams defines the arguments used for searching Task records.
 type SearchParams struct {
 	Description *string
@@ -55,3 +167,29 @@ type SearchResults struct {
 	Tasks []Task
 	Total int64
 }
+
+//-
+
+// SuggestParams defines the arguments used for suggesting Task descriptions while typing.
+type SuggestParams struct {
+	Query string
+	Size  int64
+}
+
+// Validate indicates whether the fields are valid or not.
+func (s SuggestParams) Validate() error {
+	if err := validation.ValidateStruct(&s,
+		validation.Field(&s.Query, validation.Required, validation.Length(1, 100)),
+		validation.Field(&s.Size, validation.Min(int64(0)), validation.Max(int64(20))),
+	); err != nil {
+		return WrapErrorf(err, ErrorCodeInvalidArgument, "invalid values")
+	}
+
+	return nil
+}
+
+// Suggestion defines a Task description matching the text typed so far.
+type Suggestion struct {
+	ID          string
+	Description string
+}
@@ -2,7 +2,9 @@ package internal_test
 
 import (
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 
@@ -25,6 +27,16 @@ func TestCreateParams_Validate(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"OK: Reminders",
+			internal.CreateParams{
+				Description: "Description",
+				Priority:    internal.PriorityLow,
+				Dates:       internal.Dates{Due: time.Now()},
+				Reminders:   []time.Duration{time.Hour, 24 * time.Hour},
+			},
+			false,
+		},
 		{
 			"ERR",
 			internal.CreateParams{},
@@ -35,6 +47,91 @@ func TestCreateParams_Validate(t *testing.T) {
 			internal.CreateParams{},
 			true,
 		},
+		{
+			"ERR: Reminders without due date",
+			internal.CreateParams{
+				Description: "Description",
+				Priority:    internal.PriorityLow,
+				Reminders:   []time.Duration{time.Hour},
+			},
+			true,
+		},
+		{
+			"ERR: Reminders duplicated",
+			internal.CreateParams{
+				Description: "Description",
+				Priority:    internal.PriorityLow,
+				Dates:       internal.Dates{Due: time.Now()},
+				Reminders:   []time.Duration{time.Hour, time.Hour},
+			},
+			true,
+		},
+		{
+			"ERR: Reminders too short",
+			internal.CreateParams{
+				Description: "Description",
+				Priority:    internal.PriorityLow,
+				Dates:       internal.Dates{Due: time.Now()},
+				Reminders:   []time.Duration{time.Second},
+			},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			actualErr := tt.input.Validate()
+			if (actualErr != nil) != tt.withErr {
+				t.Fatalf("expected error %t, got %s", tt.withErr, actualErr)
+			}
+
+			var ierr validation.Errors
+			if tt.withErr && !errors.As(actualErr, &ierr) {
+				t.Fatalf("expected %T error, got %T", ierr, actualErr)
+			}
+		})
+	}
+}
+
+func TestImportParams_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   internal.ImportParams
+		withErr bool
+	}{
+		{
+			"OK",
+			internal.ImportParams{
+				CreateParams: internal.CreateParams{
+					Description: "Description",
+					Priority:    internal.PriorityLow,
+				},
+				ExternalID: "external",
+			},
+			false,
+		},
+		{
+			"ERR: CreateParams",
+			internal.ImportParams{
+				ExternalID: "external",
+			},
+			true,
+		},
+		{
+			"ERR: ExternalID too long",
+			internal.ImportParams{
+				CreateParams: internal.CreateParams{
+					Description: "Description",
+					Priority:    internal.PriorityLow,
+				},
+				ExternalID: strings.Repeat("x", 256),
+			},
+			true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -54,6 +151,40 @@ func TestCreateParams_Validate(t *testing.T) {
 	}
 }
 
+func TestImportParams_Matches(t *testing.T) {
+	t.Parallel()
+
+	due := time.Date(2026, 10, 3, 17, 30, 0, 0, time.UTC)
+
+	params := internal.ImportParams{
+		CreateParams: internal.CreateParams{
+			Description: "Description",
+			Priority:    internal.PriorityLow,
+			Dates:       internal.Dates{Due: due},
+		},
+		IsDone: true,
+	}
+
+	task := internal.Task{
+		ID:          "1",
+		Description: "Description",
+		Priority:    internal.PriorityLow,
+		Dates:       internal.Dates{Due: due.In(time.FixedZone("CST", -6*60*60))},
+		IsDone:      true,
+		Version:     3,
+	}
+
+	if !params.Matches(task) {
+		t.Fatalf("expected match")
+	}
+
+	task.IsDone = false
+
+	if params.Matches(task) {
+		t.Fatalf("expected no match")
+	}
+}
+
 func TestSearchParams_IsZero(t *testing.T) {
 	t.Parallel()
 
@@ -119,3 +250,51 @@ func TestSearchParams_IsZero(t *testing.T) {
 		})
 	}
 }
+
+func TestSuggestParams_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   internal.SuggestParams
+		withErr bool
+	}{
+		{
+			"OK",
+			internal.SuggestParams{
+				Query: "buy",
+				Size:  5,
+			},
+			false,
+		},
+		{
+			"ERR: Query",
+			internal.SuggestParams{},
+			true,
+		},
+		{
+			"ERR: Size",
+			internal.SuggestParams{
+				Query: "buy",
+				Size:  100,
+			},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			actualErr := tt.input.Validate()
+			if (actualErr != nil) != tt.withErr {
+				t.Fatalf("expected error %t, got %s", tt.withErr, actualErr)
+			}
+
+			var ierr *internal.Error
+			if tt.withErr && !errors.As(actualErr, &ierr) {
+				t.Fatalf("expected %T error, got %T", ierr, actualErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package postgresql
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/postgresql/db"
+)
+
+// AdvisoryLock is a session level advisory lock, meant to be used for electing a leader: only one of the processes
+// using the same key holds it at a time. The lock is held by a connection taken from the pool, it's released when
+// unlocking it or when that connection is closed, for example when the process exits.
+type AdvisoryLock struct {
+	pool *pgxpool.Pool
+	key  int64
+
+	mu   sync.Mutex
+	conn *pgxpool.Conn
+}
+
+// NewAdvisoryLock instantiates the AdvisoryLock.
+func NewAdvisoryLock(pool *pgxpool.Pool, key int64) *AdvisoryLock {
+	return &AdvisoryLock{
+		pool: pool,
+		key:  key,
+	}
+}
+
+// TryLock acquires the lock without waiting, it indicates whether the lock is held. Calling it while holding the
+// lock verifies the connection holding it is still open, otherwise the lock is acquired again.
+func (l *AdvisoryLock) TryLock(ctx context.Context) (bool, error) {
+	defer newOTELSpan(ctx, "AdvisoryLock.TryLock").End()
+
+	//-
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn != nil {
+		if err := l.conn.Ping(ctx); err == nil {
+			return true, nil
+		}
+
+		// The lock was released when the connection was lost.
+		l.discard(ctx)
+	}
+
+	conn, err := l.pool.Acquire(ctx)
+	if err != nil {
+		return false, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "pool.Acquire")
+	}
+
+	locked, err := db.New(conn).TryAdvisoryLock(ctx, l.key)
+	if err != nil {
+		conn.Release()
+
+		return false, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "try advisory lock")
+	}
+
+	if !locked {
+		conn.Release()
+
+		return false, nil
+	}
+
+	l.conn = conn
+
+	return true, nil
+}
+
+// Unlock releases the lock, if held.
+func (l *AdvisoryLock) Unlock(ctx context.Context) error {
+	defer newOTELSpan(ctx, "AdvisoryLock.Unlock").End()
+
+	//-
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn == nil {
+		return nil
+	}
+
+	if _, err := db.New(l.conn).AdvisoryUnlock(ctx, l.key); err != nil {
+		// Closing the connection releases the lock as well.
+		l.discard(ctx)
+
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "advisory unlock")
+	}
+
+	l.conn.Release()
+	l.conn = nil
+
+	return nil
+}
+
+// discard closes the connection holding the lock instead of returning it to the pool.
+func (l *AdvisoryLock) discard(ctx context.Context) {
+	_ = l.conn.Hijack().Close(ctx)
+
+	l.conn = nil
+}
@@ -56,11 +56,60 @@ func (ns NullPriority) Value() (driver.Value, error) {
 	return string(ns.Priority), nil
 }
 
+type NotificationDigests struct {
+	Email     string
+	EventID   string
+	EventType string
+	EventTime pgtype.Timestamp
+	Task      []byte
+	CreatedAt pgtype.Timestamp
+}
+
+type NotificationPreferences struct {
+	Email      string
+	EventTypes []string
+	Digest     bool
+	CreatedAt  pgtype.Timestamp
+	UpdatedAt  pgtype.Timestamp
+}
+
+type TaskReminders struct {
+	TaskID    uuid.UUID
+	Type      string
+	DueDate   pgtype.Timestamp
+	Reminder  int64
+	CreatedAt pgtype.Timestamp
+}
+
 type Tasks struct {
-	ID          uuid.UUID
-	Description string
-	Priority    Priority
-	StartDate   pgtype.Timestamp
-	DueDate     pgtype.Timestamp
-	Done        bool
+	ID             uuid.UUID
+	Description    string
+	Priority       Priority
+	StartDate      pgtype.Timestamp
+	DueDate        pgtype.Timestamp
+	Done           bool
+	DescriptionTsv interface{}
+	Version        int64
+	Reminders      []int64
+	ExternalID     pgtype.Text
+}
+
+type WebhookDeliveries struct {
+	ID         uuid.UUID
+	WebhookID  uuid.UUID
+	EventID    string
+	EventType  string
+	Attempt    int32
+	StatusCode int32
+	Error      string
+	DurationMs int64
+	CreatedAt  pgtype.Timestamp
+}
+
+type Webhooks struct {
+	ID         uuid.UUID
+	Url        string
+	EventTypes []string
+	Secret     string
+	CreatedAt  pgtype.Timestamp
 }
@@ -17,14 +17,14 @@ DELETE FROM
   tasks
 WHERE
   id = $1
-RETURNING id AS res
+RETURNING version
 `
 
-func (q *Queries) DeleteTask(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+func (q *Queries) DeleteTask(ctx context.Context, id uuid.UUID) (int64, error) {
 	row := q.db.QueryRow(ctx, DeleteTask, id)
-	var res uuid.UUID
-	err := row.Scan(&res)
-	return res, err
+	var version int64
+	err := row.Scan(&version)
+	return version, err
 }
 
 const InsertTask = `-- name: InsertTask :one
@@ -32,15 +32,17 @@ INSERT INTO tasks (
   description,
   priority,
   start_date,
-  due_date
+  due_date,
+  reminders
 )
 VALUES (
   $1,
   $2,
   $3,
-  $4
+  $4,
+  $5
 )
-RETURNING id
+RETURNING id, version
 `
 
 type InsertTaskParams struct {
@@ -48,18 +50,102 @@ type InsertTaskParams struct {
 	Priority    Priority
 	StartDate   pgtype.Timestamp
 	DueDate     pgtype.Timestamp
+	Reminders   []int64
 }
 
-func (q *Queries) InsertTask(ctx context.Context, arg InsertTaskParams) (uuid.UUID, error) {
+type InsertTaskRow struct {
+	ID      uuid.UUID
+	Version int64
+}
+
+func (q *Queries) InsertTask(ctx context.Context, arg InsertTaskParams) (InsertTaskRow, error) {
 	row := q.db.QueryRow(ctx, InsertTask,
 		arg.Description,
 		arg.Priority,
 		arg.StartDate,
 		arg.DueDate,
+		arg.Reminders,
+	)
+	var i InsertTaskRow
+	err := row.Scan(&i.ID, &i.Version)
+	return i, err
+}
+
+const SearchTasks = `-- name: SearchTasks :many
+SELECT
+  id,
+  description,
+  priority,
+  start_date,
+  due_date,
+  done,
+  COUNT(*) OVER () AS total
+FROM
+  tasks,
+  to_tsquery('simple', replace(plainto_tsquery('english', COALESCE($1::text, ''))::text, '&', '|')) AS search_query
+WHERE
+  description_tsv @@ search_query OR
+  priority = $2 OR
+  done = $3
+ORDER BY
+  ts_rank(description_tsv, search_query) +
+    COALESCE((priority = $2)::int, 0) +
+    COALESCE((done = $3)::int, 0) DESC,
+  id
+LIMIT $4
+OFFSET $5
+`
+
+type SearchTasksParams struct {
+	Description pgtype.Text
+	Priority    NullPriority
+	Done        pgtype.Bool
+	MaxRows     int32
+	FromRows    int32
+}
+
+type SearchTasksRow struct {
+	ID          uuid.UUID
+	Description string
+	Priority    Priority
+	StartDate   pgtype.Timestamp
+	DueDate     pgtype.Timestamp
+	Done        bool
+	Total       int64
+}
+
+func (q *Queries) SearchTasks(ctx context.Context, arg SearchTasksParams) ([]SearchTasksRow, error) {
+	rows, err := q.db.Query(ctx, SearchTasks,
+		arg.Description,
+		arg.Priority,
+		arg.Done,
+		arg.MaxRows,
+		arg.FromRows,
 	)
-	var id uuid.UUID
-	err := row.Scan(&id)
-	return id, err
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchTasksRow{}
+	for rows.Next() {
+		var i SearchTasksRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Description,
+			&i.Priority,
+			&i.StartDate,
+			&i.DueDate,
+			&i.Done,
+			&i.Total,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
 const SelectTask = `-- name: SelectTask :one
@@ -69,7 +155,9 @@ SELECT
   priority,
   start_date,
   due_date,
-  done
+  done,
+  version,
+  external_id
 FROM
   tasks
 WHERE
@@ -77,9 +165,64 @@ WHERE
 LIMIT 1
 `
 
-func (q *Queries) SelectTask(ctx context.Context, id uuid.UUID) (Tasks, error) {
+type SelectTaskRow struct {
+	ID          uuid.UUID
+	Description string
+	Priority    Priority
+	StartDate   pgtype.Timestamp
+	DueDate     pgtype.Timestamp
+	Done        bool
+	Version     int64
+	ExternalID  pgtype.Text
+}
+
+func (q *Queries) SelectTask(ctx context.Context, id uuid.UUID) (SelectTaskRow, error) {
 	row := q.db.QueryRow(ctx, SelectTask, id)
-	var i Tasks
+	var i SelectTaskRow
+	err := row.Scan(
+		&i.ID,
+		&i.Description,
+		&i.Priority,
+		&i.StartDate,
+		&i.DueDate,
+		&i.Done,
+		&i.Version,
+		&i.ExternalID,
+	)
+	return i, err
+}
+
+const SelectTaskByExternalID = `-- name: SelectTaskByExternalID :one
+SELECT
+  id,
+  description,
+  priority,
+  start_date,
+  due_date,
+  done,
+  version,
+  external_id
+FROM
+  tasks
+WHERE
+  external_id = $1
+LIMIT 1
+`
+
+type SelectTaskByExternalIDRow struct {
+	ID          uuid.UUID
+	Description string
+	Priority    Priority
+	StartDate   pgtype.Timestamp
+	DueDate     pgtype.Timestamp
+	Done        bool
+	Version     int64
+	ExternalID  pgtype.Text
+}
+
+func (q *Queries) SelectTaskByExternalID(ctx context.Context, externalID pgtype.Text) (SelectTaskByExternalIDRow, error) {
+	row := q.db.QueryRow(ctx, SelectTaskByExternalID, externalID)
+	var i SelectTaskByExternalIDRow
 	err := row.Scan(
 		&i.ID,
 		&i.Description,
@@ -87,17 +230,129 @@ func (q *Queries) SelectTask(ctx context.Context, id uuid.UUID) (Tasks, error) {
 		&i.StartDate,
 		&i.DueDate,
 		&i.Done,
+		&i.Version,
+		&i.ExternalID,
 	)
 	return i, err
 }
 
+const SelectTasksAfter = `-- name: SelectTasksAfter :many
+SELECT
+  id,
+  description,
+  priority,
+  start_date,
+  due_date,
+  done,
+  version,
+  external_id
+FROM
+  tasks
+WHERE
+  id > $1
+ORDER BY
+  id
+LIMIT $2
+`
+
+type SelectTasksAfterParams struct {
+	ID      uuid.UUID
+	MaxRows int32
+}
+
+type SelectTasksAfterRow struct {
+	ID          uuid.UUID
+	Description string
+	Priority    Priority
+	StartDate   pgtype.Timestamp
+	DueDate     pgtype.Timestamp
+	Done        bool
+	Version     int64
+	ExternalID  pgtype.Text
+}
+
+func (q *Queries) SelectTasksAfter(ctx context.Context, arg SelectTasksAfterParams) ([]SelectTasksAfterRow, error) {
+	rows, err := q.db.Query(ctx, SelectTasksAfter, arg.ID, arg.MaxRows)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SelectTasksAfterRow{}
+	for rows.Next() {
+		var i SelectTasksAfterRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Description,
+			&i.Priority,
+			&i.StartDate,
+			&i.DueDate,
+			&i.Done,
+			&i.Version,
+			&i.ExternalID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const SuggestTasks = `-- name: SuggestTasks :many
+SELECT
+  id,
+  description
+FROM
+  tasks,
+  to_tsquery('english', $1::text) AS search_query
+WHERE
+  description_tsv @@ search_query
+ORDER BY
+  ts_rank(description_tsv, search_query) DESC,
+  id
+LIMIT $2
+`
+
+type SuggestTasksParams struct {
+	Query   string
+	MaxRows int32
+}
+
+type SuggestTasksRow struct {
+	ID          uuid.UUID
+	Description string
+}
+
+func (q *Queries) SuggestTasks(ctx context.Context, arg SuggestTasksParams) ([]SuggestTasksRow, error) {
+	rows, err := q.db.Query(ctx, SuggestTasks, arg.Query, arg.MaxRows)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SuggestTasksRow{}
+	for rows.Next() {
+		var i SuggestTasksRow
+		if err := rows.Scan(&i.ID, &i.Description); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const UpdateTask = `-- name: UpdateTask :one
 UPDATE tasks SET
   description = $1,
   priority    = $2,
   start_date  = $3,
   due_date    = $4,
-  done        = $5
+  done        = $5,
+  version     = version + 1
 WHERE id = $6
 RETURNING id AS res
 `
@@ -124,3 +379,62 @@ func (q *Queries) UpdateTask(ctx context.Context, arg UpdateTaskParams) (uuid.UU
 	err := row.Scan(&res)
 	return res, err
 }
+
+const UpsertTask = `-- name: UpsertTask :one
+INSERT INTO tasks (
+  external_id,
+  description,
+  priority,
+  start_date,
+  due_date,
+  done
+)
+VALUES (
+  $1,
+  $2,
+  $3,
+  $4,
+  $5,
+  $6
+)
+ON CONFLICT (external_id) DO UPDATE SET
+  description = EXCLUDED.description,
+  priority    = EXCLUDED.priority,
+  start_date  = EXCLUDED.start_date,
+  due_date    = EXCLUDED.due_date,
+  done        = EXCLUDED.done,
+  version     = tasks.version + 1
+WHERE
+  (tasks.description, tasks.priority, tasks.start_date, tasks.due_date, tasks.done) IS DISTINCT FROM
+  (EXCLUDED.description, EXCLUDED.priority, EXCLUDED.start_date, EXCLUDED.due_date, EXCLUDED.done)
+RETURNING id, version, (xmax = 0) AS created
+`
+
+type UpsertTaskParams struct {
+	ExternalID  pgtype.Text
+	Description string
+	Priority    Priority
+	StartDate   pgtype.Timestamp
+	DueDate     pgtype.Timestamp
+	Done        bool
+}
+
+type UpsertTaskRow struct {
+	ID      uuid.UUID
+	Version int64
+	Created bool
+}
+
+func (q *Queries) UpsertTask(ctx context.Context, arg UpsertTaskParams) (UpsertTaskRow, error) {
+	row := q.db.QueryRow(ctx, UpsertTask,
+		arg.ExternalID,
+		arg.Description,
+		arg.Priority,
+		arg.StartDate,
+		arg.DueDate,
+		arg.Done,
+	)
+	var i UpsertTaskRow
+	err := row.Scan(&i.ID, &i.Version, &i.Created)
+	return i, err
+}
@@ -0,0 +1,203 @@
+package postgresql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/postgresql/db"
+)
+
+// Notification represents the repository used for interacting with NotificationPreference records and the
+// Notifications waiting to be sent in the next digest.
+//
+// XXX: Tasks in the digest are stored using JSON, only the notifier reads them back.
+type Notification struct {
+	q *db.Queries
+}
+
+// NewNotification instantiates the Notification repository.
+func NewNotification(d db.DBTX) *Notification {
+	return &Notification{
+		q: db.New(d),
+	}
+}
+
+// Save inserts a new preference record, or replaces the existing one using the same email.
+func (n *Notification) Save(ctx context.Context, params internal.SaveNotificationPreferenceParams,
+) (internal.NotificationPreference, error) {
+	defer newOTELSpan(ctx, "Notification.Save").End()
+
+	//-
+
+	eventTypes := params.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+
+	row, err := n.q.UpsertNotificationPreference(ctx, db.UpsertNotificationPreferenceParams{
+		Email:      params.Email,
+		EventTypes: eventTypes,
+		Digest:     params.Digest,
+	})
+	if err != nil {
+		return internal.NotificationPreference{},
+			internal.WrapErrorf(err, internal.ErrorCodeUnknown, "upsert notification preference")
+	}
+
+	return internal.NotificationPreference{
+		Email:      params.Email,
+		EventTypes: eventTypes,
+		Digest:     params.Digest,
+		CreatedAt:  row.CreatedAt.Time,
+		UpdatedAt:  row.UpdatedAt.Time,
+	}, nil
+}
+
+// Delete deletes the existing preference record matching the email, its pending digest is deleted as well.
+func (n *Notification) Delete(ctx context.Context, email string) error {
+	defer newOTELSpan(ctx, "Notification.Delete").End()
+
+	//-
+
+	if _, err := n.q.DeleteNotificationPreference(ctx, email); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return internal.WrapErrorf(err, internal.ErrorCodeNotFound, "notification preference not found")
+		}
+
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "delete notification preference")
+	}
+
+	return nil
+}
+
+// Find returns the requested preference by searching its email.
+func (n *Notification) Find(ctx context.Context, email string) (internal.NotificationPreference, error) {
+	defer newOTELSpan(ctx, "Notification.Find").End()
+
+	//-
+
+	res, err := n.q.SelectNotificationPreference(ctx, email)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return internal.NotificationPreference{},
+				internal.WrapErrorf(err, internal.ErrorCodeNotFound, "notification preference not found")
+		}
+
+		return internal.NotificationPreference{},
+			internal.WrapErrorf(err, internal.ErrorCodeUnknown, "select notification preference")
+	}
+
+	return newNotificationPreference(res), nil
+}
+
+// List returns all the preferences sorted by email.
+func (n *Notification) List(ctx context.Context) ([]internal.NotificationPreference, error) {
+	defer newOTELSpan(ctx, "Notification.List").End()
+
+	//-
+
+	rows, err := n.q.SelectNotificationPreferences(ctx)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "select notification preferences")
+	}
+
+	res := make([]internal.NotificationPreference, len(rows))
+
+	for i, row := range rows {
+		res[i] = newNotificationPreference(row)
+	}
+
+	return res, nil
+}
+
+// AddToDigest adds the notification to the next digest sent to the email, adding the same event again is ignored.
+func (n *Notification) AddToDigest(ctx context.Context, email string, notification internal.Notification) error {
+	defer newOTELSpan(ctx, "Notification.AddToDigest").End()
+
+	//-
+
+	task, err := json.Marshal(notification.Task)
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "json.Marshal")
+	}
+
+	if err := n.q.InsertNotificationDigest(ctx, db.InsertNotificationDigestParams{
+		Email:     email,
+		EventID:   notification.EventID,
+		EventType: notification.EventType,
+		EventTime: newTimestamp(notification.Time.UTC()),
+		Task:      task,
+	}); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "insert notification digest")
+	}
+
+	return nil
+}
+
+// DigestEmails returns the emails with notifications waiting to be sent.
+func (n *Notification) DigestEmails(ctx context.Context) ([]string, error) {
+	defer newOTELSpan(ctx, "Notification.DigestEmails").End()
+
+	//-
+
+	res, err := n.q.SelectNotificationDigestEmails(ctx)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "select notification digest emails")
+	}
+
+	return res, nil
+}
+
+// Digest returns, oldest first, up to size notifications waiting to be sent to the email.
+func (n *Notification) Digest(ctx context.Context, email string, size int32) ([]internal.Notification, error) {
+	defer newOTELSpan(ctx, "Notification.Digest").End()
+
+	//-
+
+	rows, err := n.q.SelectNotificationDigest(ctx, db.SelectNotificationDigestParams{
+		Email:   email,
+		MaxRows: size,
+	})
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "select notification digest")
+	}
+
+	res := make([]internal.Notification, len(rows))
+
+	for i, row := range rows {
+		var task internal.Task
+
+		if err := json.Unmarshal(row.Task, &task); err != nil {
+			return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "json.Unmarshal")
+		}
+
+		res[i] = internal.Notification{
+			EventID:   row.EventID,
+			EventType: row.EventType,
+			Time:      row.EventTime.Time,
+			Task:      task,
+		}
+	}
+
+	return res, nil
+}
+
+// ClearDigest deletes the notifications already sent to the email.
+func (n *Notification) ClearDigest(ctx context.Context, email string, eventIDs []string) error {
+	defer newOTELSpan(ctx, "Notification.ClearDigest").End()
+
+	//-
+
+	if err := n.q.DeleteNotificationDigest(ctx, db.DeleteNotificationDigestParams{
+		Email:    email,
+		EventIds: eventIDs,
+	}); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "delete notification digest")
+	}
+
+	return nil
+}
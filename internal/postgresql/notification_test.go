@@ -0,0 +1,130 @@
+package postgresql_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/postgresql"
+)
+
+func TestNotification(t *testing.T) {
+	t.Parallel()
+
+	store := postgresql.NewNotification(newDB(t))
+
+	created, err := store.Save(context.Background(), internal.SaveNotificationPreferenceParams{
+		Email:      "user@example.com",
+		EventTypes: []string{"tasks.event.due_soon"},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	updated, err := store.Save(context.Background(), internal.SaveNotificationPreferenceParams{
+		Email:  "user@example.com",
+		Digest: true,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if !updated.CreatedAt.Equal(created.CreatedAt) || !updated.Digest || len(updated.EventTypes) != 0 {
+		t.Fatalf("expected preference to be replaced, got %v", updated)
+	}
+
+	found, err := store.Find(context.Background(), created.Email)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if !cmp.Equal(updated, found) {
+		t.Fatalf("expected result does not match: %s", cmp.Diff(updated, found))
+	}
+
+	list, err := store.List(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if !cmp.Equal([]internal.NotificationPreference{updated}, list) {
+		t.Fatalf("expected result does not match: %s", cmp.Diff([]internal.NotificationPreference{updated}, list))
+	}
+
+	//-
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	notifications := []internal.Notification{
+		{
+			EventID:   "1",
+			EventType: "tasks.event.due_soon",
+			Time:      now,
+			Task:      internal.Task{ID: "task", Description: "due soon", Dates: internal.Dates{Due: now}},
+		},
+		{
+			EventID:   "2",
+			EventType: "tasks.event.overdue",
+			Time:      now.Add(time.Second),
+			Task:      internal.Task{ID: "task", Description: "overdue", Dates: internal.Dates{Due: now}},
+		},
+	}
+
+	for _, notification := range append(notifications, notifications[0]) {
+		if err := store.AddToDigest(context.Background(), created.Email, notification); err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+	}
+
+	emails, err := store.DigestEmails(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if !cmp.Equal([]string{created.Email}, emails) {
+		t.Fatalf("expected result does not match: %s", cmp.Diff([]string{created.Email}, emails))
+	}
+
+	digest, err := store.Digest(context.Background(), created.Email, 10)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if !cmp.Equal(notifications, digest) {
+		t.Fatalf("expected result does not match: %s", cmp.Diff(notifications, digest))
+	}
+
+	if err := store.ClearDigest(context.Background(), created.Email, []string{"1"}); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if digest, err = store.Digest(context.Background(), created.Email, 10); err != nil || len(digest) != 1 {
+		t.Fatalf("expected one notification, got %v : %v", digest, err)
+	}
+
+	//-
+
+	if err := store.Delete(context.Background(), created.Email); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if emails, err = store.DigestEmails(context.Background()); err != nil || len(emails) != 0 {
+		t.Fatalf("expected digest to be deleted, got %v : %v", emails, err)
+	}
+
+	var ierr *internal.Error
+
+	_, err = store.Find(context.Background(), created.Email)
+	if !errors.As(err, &ierr) || ierr.Code() != internal.ErrorCodeNotFound {
+		t.Fatalf("expected %T error, got %T : %v", ierr, err, err)
+	}
+
+	err = store.Delete(context.Background(), created.Email)
+	if !errors.As(err, &ierr) || ierr.Code() != internal.ErrorCodeNotFound {
+		t.Fatalf("expected %T error, got %T : %v", ierr, err, err)
+	}
+}
@@ -33,6 +33,46 @@ func convertPriority(priority db.Priority) (internal.Priority, error) {
 	return internal.Priority(-1), fmt.Errorf("unknown value: %s", priority)
 }
 
+func newTask(row db.SelectTaskRow) (internal.Task, error) {
+	priority, err := convertPriority(row.Priority)
+	if err != nil {
+		return internal.Task{}, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "convert priority")
+	}
+
+	return internal.Task{
+		ID:          row.ID.String(),
+		Description: row.Description,
+		Priority:    priority,
+		Dates: internal.Dates{
+			Start: row.StartDate.Time,
+			Due:   row.DueDate.Time,
+		},
+		IsDone:     row.Done,
+		ExternalID: row.ExternalID.String,
+		Version:    row.Version,
+	}, nil
+}
+
+func newWebhook(row db.Webhooks) internal.Webhook {
+	return internal.Webhook{
+		ID:         row.ID.String(),
+		URL:        row.Url,
+		EventTypes: row.EventTypes,
+		Secret:     row.Secret,
+		CreatedAt:  row.CreatedAt.Time,
+	}
+}
+
+func newNotificationPreference(row db.NotificationPreferences) internal.NotificationPreference {
+	return internal.NotificationPreference{
+		Email:      row.Email,
+		EventTypes: row.EventTypes,
+		Digest:     row.Digest,
+		CreatedAt:  row.CreatedAt.Time,
+		UpdatedAt:  row.UpdatedAt.Time,
+	}
+}
+
 func newTimestamp(t time.Time) pgtype.Timestamp {
 	return pgtype.Timestamp{
 		Time:  t,
@@ -40,6 +80,25 @@ func newTimestamp(t time.Time) pgtype.Timestamp {
 	}
 }
 
+// newText converts empty values to NULL, the same way newTimestamp does with zero dates.
+func newText(s string) pgtype.Text {
+	return pgtype.Text{
+		String: s,
+		Valid:  s != "",
+	}
+}
+
+// newReminders converts the reminders to seconds, the unit used for storing them.
+func newReminders(reminders []time.Duration) []int64 {
+	res := make([]int64, len(reminders))
+
+	for i, reminder := range reminders {
+		res[i] = int64(reminder / time.Second)
+	}
+
+	return res
+}
+
 func newPriority(p internal.Priority) db.Priority {
 	switch p {
 	case internal.PriorityNone:
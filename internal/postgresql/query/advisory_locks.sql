@@ -0,0 +1,5 @@
+-- name: TryAdvisoryLock :one
+SELECT pg_try_advisory_lock(@key::BIGINT) AS locked;
+
+-- name: AdvisoryUnlock :one
+SELECT pg_advisory_unlock(@key::BIGINT) AS unlocked;
@@ -0,0 +1,95 @@
+-- name: UpsertNotificationPreference :one
+INSERT INTO notification_preferences (
+  email,
+  event_types,
+  digest
+)
+VALUES (
+  @email,
+  @event_types,
+  @digest
+)
+ON CONFLICT (email) DO UPDATE SET
+  event_types = EXCLUDED.event_types,
+  digest      = EXCLUDED.digest,
+  updated_at  = (NOW() AT TIME ZONE 'UTC')
+RETURNING created_at, updated_at;
+
+-- name: SelectNotificationPreference :one
+SELECT
+  email,
+  event_types,
+  digest,
+  created_at,
+  updated_at
+FROM
+  notification_preferences
+WHERE
+  email = @email
+LIMIT 1;
+
+-- name: SelectNotificationPreferences :many
+SELECT
+  email,
+  event_types,
+  digest,
+  created_at,
+  updated_at
+FROM
+  notification_preferences
+ORDER BY
+  email;
+
+-- name: DeleteNotificationPreference :one
+DELETE FROM
+  notification_preferences
+WHERE
+  email = @email
+RETURNING email AS res;
+
+-- name: InsertNotificationDigest :exec
+INSERT INTO notification_digests (
+  email,
+  event_id,
+  event_type,
+  event_time,
+  task
+)
+VALUES (
+  @email,
+  @event_id,
+  @event_type,
+  @event_time,
+  @task
+)
+ON CONFLICT DO NOTHING;
+
+-- name: SelectNotificationDigestEmails :many
+SELECT DISTINCT
+  email
+FROM
+  notification_digests
+ORDER BY
+  email;
+
+-- name: SelectNotificationDigest :many
+SELECT
+  event_id,
+  event_type,
+  event_time,
+  task
+FROM
+  notification_digests
+WHERE
+  email = @email
+ORDER BY
+  event_time,
+  event_id
+LIMIT @max_rows;
+
+-- name: DeleteNotificationDigest :exec
+DELETE FROM
+  notification_digests
+WHERE
+  email = @email AND
+  event_id = ANY(@event_ids::VARCHAR[]);
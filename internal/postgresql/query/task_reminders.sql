@@ -0,0 +1,72 @@
+-- name: SelectTasksDueSoon :many
+SELECT
+  tasks.id,
+  tasks.description,
+  tasks.priority,
+  tasks.start_date,
+  tasks.due_date,
+  tasks.done,
+  tasks.version,
+  r.seconds::BIGINT AS reminder
+FROM
+  tasks,
+  unnest(tasks.reminders) AS r (seconds)
+WHERE
+  NOT tasks.done AND
+  tasks.due_date > @now AND
+  tasks.due_date - make_interval(secs => r.seconds) <= @now AND
+  NOT EXISTS (
+    SELECT 1 FROM task_reminders
+    WHERE
+      task_reminders.task_id = tasks.id AND
+      task_reminders.type = 'due_soon' AND
+      task_reminders.due_date = tasks.due_date AND
+      task_reminders.reminder = r.seconds
+  )
+ORDER BY
+  tasks.due_date,
+  tasks.id,
+  r.seconds
+LIMIT @max_rows;
+
+-- name: SelectTasksOverdue :many
+SELECT
+  id,
+  description,
+  priority,
+  start_date,
+  due_date,
+  done,
+  version,
+  external_id
+FROM
+  tasks
+WHERE
+  NOT done AND
+  due_date <= @now AND
+  NOT EXISTS (
+    SELECT 1 FROM task_reminders
+    WHERE
+      task_reminders.task_id = tasks.id AND
+      task_reminders.type = 'overdue' AND
+      task_reminders.due_date = tasks.due_date
+  )
+ORDER BY
+  due_date,
+  id
+LIMIT @max_rows;
+
+-- name: InsertTaskReminder :exec
+INSERT INTO task_reminders (
+  task_id,
+  type,
+  due_date,
+  reminder
+)
+VALUES (
+  @task_id,
+  @type,
+  @due_date,
+  @reminder
+)
+ON CONFLICT DO NOTHING;
@@ -5,27 +5,47 @@ SELECT
   priority,
   start_date,
   due_date,
-  done
+  done,
+  version,
+  external_id
 FROM
   tasks
 WHERE
   id = @id
 LIMIT 1;
 
+-- name: SelectTaskByExternalID :one
+SELECT
+  id,
+  description,
+  priority,
+  start_date,
+  due_date,
+  done,
+  version,
+  external_id
+FROM
+  tasks
+WHERE
+  external_id = @external_id
+LIMIT 1;
+
 -- name: InsertTask :one
 INSERT INTO tasks (
   description,
   priority,
   start_date,
-  due_date
+  due_date,
+  reminders
 )
 VALUES (
   @description,
   @priority,
   @start_date,
-  @due_date
+  @due_date,
+  @reminders
 )
-RETURNING id;
+RETURNING id, version;
 
 -- name: UpdateTask :one
 UPDATE tasks SET
@@ -33,13 +53,99 @@ UPDATE tasks SET
   priority    = @priority,
   start_date  = @start_date,
   due_date    = @due_date,
-  done        = @done
+  done        = @done,
+  version     = version + 1
 WHERE id = @id
 RETURNING id AS res;
 
+-- name: UpsertTask :one
+INSERT INTO tasks (
+  external_id,
+  description,
+  priority,
+  start_date,
+  due_date,
+  done
+)
+VALUES (
+  @external_id,
+  @description,
+  @priority,
+  @start_date,
+  @due_date,
+  @done
+)
+ON CONFLICT (external_id) DO UPDATE SET
+  description = EXCLUDED.description,
+  priority    = EXCLUDED.priority,
+  start_date  = EXCLUDED.start_date,
+  due_date    = EXCLUDED.due_date,
+  done        = EXCLUDED.done,
+  version     = tasks.version + 1
+WHERE
+  (tasks.description, tasks.priority, tasks.start_date, tasks.due_date, tasks.done) IS DISTINCT FROM
+  (EXCLUDED.description, EXCLUDED.priority, EXCLUDED.start_date, EXCLUDED.due_date, EXCLUDED.done)
+RETURNING id, version, (xmax = 0) AS created;
+
 -- name: DeleteTask :one
 DELETE FROM
   tasks
 WHERE
   id = @id
-RETURNING id AS res;
+RETURNING version;
+
+-- name: SelectTasksAfter :many
+SELECT
+  id,
+  description,
+  priority,
+  start_date,
+  due_date,
+  done,
+  version,
+  external_id
+FROM
+  tasks
+WHERE
+  id > @id
+ORDER BY
+  id
+LIMIT @max_rows;
+
+-- name: SearchTasks :many
+SELECT
+  id,
+  description,
+  priority,
+  start_date,
+  due_date,
+  done,
+  COUNT(*) OVER () AS total
+FROM
+  tasks,
+  to_tsquery('simple', replace(plainto_tsquery('english', COALESCE(sqlc.narg(description)::text, ''))::text, '&', '|')) AS search_query
+WHERE
+  description_tsv @@ search_query OR
+  priority = sqlc.narg(priority) OR
+  done = sqlc.narg(done)
+ORDER BY
+  ts_rank(description_tsv, search_query) +
+    COALESCE((priority = sqlc.narg(priority))::int, 0) +
+    COALESCE((done = sqlc.narg(done))::int, 0) DESC,
+  id
+LIMIT sqlc.arg(max_rows)
+OFFSET sqlc.arg(from_rows);
+
+-- name: SuggestTasks :many
+SELECT
+  id,
+  description
+FROM
+  tasks,
+  to_tsquery('english', @query::text) AS search_query
+WHERE
+  description_tsv @@ search_query
+ORDER BY
+  ts_rank(description_tsv, search_query) DESC,
+  id
+LIMIT @max_rows;
@@ -0,0 +1,85 @@
+-- name: InsertWebhook :one
+INSERT INTO webhooks (
+  url,
+  event_types,
+  secret
+)
+VALUES (
+  @url,
+  @event_types,
+  @secret
+)
+RETURNING id, created_at;
+
+-- name: SelectWebhook :one
+SELECT
+  id,
+  url,
+  event_types,
+  secret,
+  created_at
+FROM
+  webhooks
+WHERE
+  id = @id
+LIMIT 1;
+
+-- name: SelectWebhooks :many
+SELECT
+  id,
+  url,
+  event_types,
+  secret,
+  created_at
+FROM
+  webhooks
+ORDER BY
+  created_at,
+  id;
+
+-- name: DeleteWebhook :one
+DELETE FROM
+  webhooks
+WHERE
+  id = @id
+RETURNING id AS res;
+
+-- name: InsertWebhookDelivery :exec
+INSERT INTO webhook_deliveries (
+  webhook_id,
+  event_id,
+  event_type,
+  attempt,
+  status_code,
+  error,
+  duration_ms
+)
+VALUES (
+  @webhook_id,
+  @event_id,
+  @event_type,
+  @attempt,
+  @status_code,
+  @error,
+  @duration_ms
+);
+
+-- name: SelectWebhookDeliveries :many
+SELECT
+  id,
+  webhook_id,
+  event_id,
+  event_type,
+  attempt,
+  status_code,
+  error,
+  duration_ms,
+  created_at
+FROM
+  webhook_deliveries
+WHERE
+  webhook_id = @webhook_id
+ORDER BY
+  created_at DESC,
+  id
+LIMIT @max_rows;
@@ -0,0 +1,129 @@
+package postgresql
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/postgresql/db"
+)
+
+// SearchableTask represents the repository used for searching Task records using PostgreSQL full-text search,
+// it's an alternative to Elasticsearch for deployments with a small number of records.
+type SearchableTask struct {
+	q *db.Queries
+}
+
+// NewSearchableTask instantiates the SearchableTask repository.
+func NewSearchableTask(d db.DBTX) *SearchableTask {
+	return &SearchableTask{
+		q: db.New(d),
+	}
+}
+
+// Search returns tasks matching a query, any of the arguments must match and results are sorted by relevance,
+// the same way Elasticsearch does.
+func (t *SearchableTask) Search(ctx context.Context, args internal.SearchParams) (internal.SearchResults, error) {
+	defer newOTELSpan(ctx, "SearchableTask.Search").End()
+
+	//-
+
+	if args.IsZero() {
+		return internal.SearchResults{}, nil
+	}
+
+	params := db.SearchTasksParams{
+		MaxRows:  int32(args.Size), //nolint: gosec
+		FromRows: int32(args.From), //nolint: gosec
+	}
+
+	if args.Description != nil {
+		params.Description = pgtype.Text{String: *args.Description, Valid: true}
+	}
+
+	if args.Priority != nil {
+		params.Priority = db.NullPriority{Priority: newPriority(*args.Priority), Valid: true}
+	}
+
+	if args.IsDone != nil {
+		params.Done = pgtype.Bool{Bool: *args.IsDone, Valid: true}
+	}
+
+	rows, err := t.q.SearchTasks(ctx, params)
+	if err != nil {
+		return internal.SearchResults{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "search tasks")
+	}
+
+	// XXX: The total is calculated using the rows returned, paginating past the last result returns zero instead
+	// of the number of matching records like Elasticsearch does.
+
+	res := internal.SearchResults{
+		Tasks: make([]internal.Task, len(rows)),
+	}
+
+	for i, row := range rows {
+		task, err := newTask(db.SelectTaskRow{
+			ID:          row.ID,
+			Description: row.Description,
+			Priority:    row.Priority,
+			StartDate:   row.StartDate,
+			DueDate:     row.DueDate,
+			Done:        row.Done,
+		})
+		if err != nil {
+			return internal.SearchResults{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "newTask")
+		}
+
+		res.Tasks[i] = task
+		res.Total = row.Total
+	}
+
+	return res, nil
+}
+
+// Suggest returns tasks with descriptions including all the words typed so far, the last one as a prefix; only the
+// tasks visible to the caller are suggested: the same ones returned by Search.
+func (t *SearchableTask) Suggest(ctx context.Context, args internal.SuggestParams) ([]internal.Suggestion, error) {
+	defer newOTELSpan(ctx, "SearchableTask.Suggest").End()
+
+	//-
+
+	query := newPrefixQuery(args.Query)
+	if query == "" {
+		return []internal.Suggestion{}, nil
+	}
+
+	rows, err := t.q.SuggestTasks(ctx, db.SuggestTasksParams{
+		Query:   query,
+		MaxRows: int32(args.Size), //nolint: gosec
+	})
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "suggest tasks")
+	}
+
+	res := make([]internal.Suggestion, len(rows))
+
+	for i, row := range rows {
+		res[i].ID = row.ID.String()
+		res[i].Description = row.Description
+	}
+
+	return res, nil
+}
+
+// newPrefixQuery converts the text typed so far into a "tsquery" matching all the words, the last one as a prefix;
+// anything that is not a letter or a digit is dropped to avoid injecting "tsquery" operators.
+func newPrefixQuery(text string) string {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	if len(words) == 0 {
+		return ""
+	}
+
+	return strings.Join(words, " & ") + ":*"
+}
@@ -0,0 +1,44 @@
+package postgresql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/postgresql"
+	"github.com/MarioCarrion/todo-api/internal/service"
+	"github.com/MarioCarrion/todo-api/internal/service/servicetesting"
+)
+
+func TestSearchableTask(t *testing.T) {
+	t.Parallel()
+
+	servicetesting.TestTaskSearchRepository(t, func(t *testing.T, tasks []internal.Task) (service.TaskSearchRepository, []internal.Task) {
+		t.Helper()
+
+		pool := newDB(t)
+		store := postgresql.NewTask(pool)
+
+		res := make([]internal.Task, len(tasks))
+
+		for i, task := range tasks {
+			created, err := store.Create(context.Background(), internal.CreateParams{
+				Description: task.Description,
+				Priority:    task.Priority,
+				Dates:       task.Dates,
+			})
+			if err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			if err := store.Update(context.Background(), created.ID, task.Description, task.Priority, task.Dates, task.IsDone); err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			task.ID = created.ID
+			res[i] = task
+		}
+
+		return postgresql.NewSearchableTask(pool), res
+	})
+}
@@ -32,45 +32,47 @@ func (t *Task) Create(ctx context.Context, params internal.CreateParams) (intern
 	// XXX: `ID` and `IsDone` make no sense when creating new records, that's why those are ignored.
 	// XXX: We are intentionally NOT SUPPORTING `SubTasks` and `Categories` JUST YET.
 
-	newID, err := t.q.InsertTask(ctx, db.InsertTaskParams{
+	row, err := t.q.InsertTask(ctx, db.InsertTaskParams{
 		Description: params.Description,
 		Priority:    newPriority(params.Priority),
 		StartDate:   newTimestamp(params.Dates.Start),
 		DueDate:     newTimestamp(params.Dates.Due),
+		Reminders:   newReminders(params.Reminders),
 	})
 	if err != nil {
 		return internal.Task{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "insert task")
 	}
 
 	return internal.Task{
-		ID:          newID.String(),
+		ID:          row.ID.String(),
 		Description: params.Description,
 		Priority:    params.Priority,
 		Dates:       params.Dates,
+		Version:     row.Version,
 	}, nil
 }
 
-// Delete deletes the existing record matching the id.
-func (t *Task) Delete(ctx context.Context, id string) error {
+// Delete deletes the existing record matching the id, it returns the version of the deleted record.
+func (t *Task) Delete(ctx context.Context, id string) (int64, error) {
 	defer newOTELSpan(ctx, "Task.Delete").End()
 
 	//-
 
 	val, err := uuid.Parse(id)
 	if err != nil {
-		return internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "invalid uuid")
+		return 0, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "invalid uuid")
 	}
 
-	_, err = t.q.DeleteTask(ctx, val)
+	version, err := t.q.DeleteTask(ctx, val)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return internal.WrapErrorf(err, internal.ErrorCodeNotFound, "task not found")
+			return 0, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "task not found")
 		}
 
-		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "delete task")
+		return 0, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "delete task")
 	}
 
-	return nil
+	return version, nil
 }
 
 // Find returns the requested task by searching its id.
@@ -93,21 +95,65 @@ func (t *Task) Find(ctx context.Context, id string) (internal.Task, error) {
 		return internal.Task{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "select task")
 	}
 
-	priority, err := convertPriority(res.Priority)
+	return newTask(res)
+}
+
+// FindByExternalID returns the task imported using the external id.
+func (t *Task) FindByExternalID(ctx context.Context, externalID string) (internal.Task, error) {
+	defer newOTELSpan(ctx, "Task.FindByExternalID").End()
+
+	//-
+
+	res, err := t.q.SelectTaskByExternalID(ctx, newText(externalID))
 	if err != nil {
-		return internal.Task{}, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "convert priority")
+		if errors.Is(err, pgx.ErrNoRows) {
+			return internal.Task{}, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "task not found")
+		}
+
+		return internal.Task{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "select task")
 	}
 
-	return internal.Task{
-		ID:          res.ID.String(),
-		Description: res.Description,
-		Priority:    priority,
-		Dates: internal.Dates{
-			Start: res.StartDate.Time,
-			Due:   res.DueDate.Time,
-		},
-		IsDone: res.Done,
-	}, nil
+	return newTask(db.SelectTaskRow(res))
+}
+
+// ListAfter returns, sorted by id, up to size tasks with an id greater than the received one; use an empty id to
+// start from the first task.
+func (t *Task) ListAfter(ctx context.Context, id string, size int32) ([]internal.Task, error) {
+	defer newOTELSpan(ctx, "Task.ListAfter").End()
+
+	//-
+
+	var val uuid.UUID
+
+	if id != "" {
+		res, err := uuid.Parse(id)
+		if err != nil {
+			return nil, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "invalid uuid")
+		}
+
+		val = res
+	}
+
+	rows, err := t.q.SelectTasksAfter(ctx, db.SelectTasksAfterParams{
+		ID:      val,
+		MaxRows: size,
+	})
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "select tasks")
+	}
+
+	res := make([]internal.Task, len(rows))
+
+	for i, row := range rows {
+		task, err := newTask(db.SelectTaskRow(row))
+		if err != nil {
+			return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "newTask")
+		}
+
+		res[i] = task
+	}
+
+	return res, nil
 }
 
 // Update updates the existing record with new values.
@@ -139,3 +185,50 @@ func (t *Task) Update(ctx context.Context, id string, description string, priori
 
 	return nil
 }
+
+// Upsert inserts the task, or updates the one imported using the same external id; the existing task is left as
+// is, including its version, when the values are the same.
+func (t *Task) Upsert(ctx context.Context, params internal.ImportParams) (internal.Task, internal.ImportStatus, error) {
+	defer newOTELSpan(ctx, "Task.Upsert").End()
+
+	//-
+
+	// XXX: `Reminders` are not imported, the same way `SubTasks` and `Categories` are not supported.
+
+	row, err := t.q.UpsertTask(ctx, db.UpsertTaskParams{
+		ExternalID:  newText(params.ExternalID),
+		Description: params.Description,
+		Priority:    newPriority(params.Priority),
+		StartDate:   newTimestamp(params.Dates.Start),
+		DueDate:     newTimestamp(params.Dates.Due),
+		Done:        params.IsDone,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// The conflicting row was not updated because its values are the same.
+			task, err := t.FindByExternalID(ctx, params.ExternalID)
+			if err != nil {
+				return internal.Task{}, 0, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "FindByExternalID")
+			}
+
+			return task, internal.ImportStatusUnchanged, nil
+		}
+
+		return internal.Task{}, 0, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "upsert task")
+	}
+
+	status := internal.ImportStatusUpdated
+	if row.Created {
+		status = internal.ImportStatusCreated
+	}
+
+	return internal.Task{
+		ID:          row.ID.String(),
+		Description: params.Description,
+		Priority:    params.Priority,
+		Dates:       params.Dates,
+		IsDone:      params.IsDone,
+		ExternalID:  params.ExternalID,
+		Version:     row.Version,
+	}, status, nil
+}
@@ -0,0 +1,122 @@
+package postgresql
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/postgresql/db"
+)
+
+// TaskReminder represents the repository used for finding the Tasks due soon, or overdue, and recording the
+// Reminders already sent.
+//
+// XXX: Dates are stored without time zone, they are expected to be in UTC.
+type TaskReminder struct {
+	q *db.Queries
+}
+
+// NewTaskReminder instantiates the TaskReminder repository.
+func NewTaskReminder(d db.DBTX) *TaskReminder {
+	return &TaskReminder{
+		q: db.New(d),
+	}
+}
+
+// DueSoon returns up to size Reminders, sorted by due date, of the tasks not done that are due within one of
+// their reminders as of now; excluding the ones already sent.
+func (t *TaskReminder) DueSoon(ctx context.Context, now time.Time, size int32) ([]internal.Reminder, error) {
+	defer newOTELSpan(ctx, "TaskReminder.DueSoon").End()
+
+	//-
+
+	rows, err := t.q.SelectTasksDueSoon(ctx, db.SelectTasksDueSoonParams{
+		Now:     newTimestamp(now.UTC()),
+		MaxRows: size,
+	})
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "select tasks due soon")
+	}
+
+	res := make([]internal.Reminder, len(rows))
+
+	for i, row := range rows {
+		task, err := newTask(db.SelectTaskRow{
+			ID:          row.ID,
+			Description: row.Description,
+			Priority:    row.Priority,
+			StartDate:   row.StartDate,
+			DueDate:     row.DueDate,
+			Done:        row.Done,
+			Version:     row.Version,
+		})
+		if err != nil {
+			return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "newTask")
+		}
+
+		res[i] = internal.Reminder{
+			Type:   internal.ReminderDueSoon,
+			Task:   task,
+			Before: time.Duration(row.Reminder) * time.Second,
+		}
+	}
+
+	return res, nil
+}
+
+// Overdue returns up to size Reminders, sorted by due date, of the tasks not done with a due date before now;
+// excluding the ones already sent.
+func (t *TaskReminder) Overdue(ctx context.Context, now time.Time, size int32) ([]internal.Reminder, error) {
+	defer newOTELSpan(ctx, "TaskReminder.Overdue").End()
+
+	//-
+
+	rows, err := t.q.SelectTasksOverdue(ctx, db.SelectTasksOverdueParams{
+		Now:     newTimestamp(now.UTC()),
+		MaxRows: size,
+	})
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "select tasks overdue")
+	}
+
+	res := make([]internal.Reminder, len(rows))
+
+	for i, row := range rows {
+		task, err := newTask(db.SelectTaskRow(row))
+		if err != nil {
+			return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "newTask")
+		}
+
+		res[i] = internal.Reminder{
+			Type: internal.ReminderOverdue,
+			Task: task,
+		}
+	}
+
+	return res, nil
+}
+
+// Sent records the reminder was sent, so it's not returned again for the same due date.
+func (t *TaskReminder) Sent(ctx context.Context, reminder internal.Reminder) error {
+	defer newOTELSpan(ctx, "TaskReminder.Sent").End()
+
+	//-
+
+	val, err := uuid.Parse(reminder.Task.ID)
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "invalid uuid")
+	}
+
+	if err := t.q.InsertTaskReminder(ctx, db.InsertTaskReminderParams{
+		TaskID:   val,
+		Type:     string(reminder.Type),
+		DueDate:  newTimestamp(reminder.Task.Dates.Due),
+		Reminder: int64(reminder.Before / time.Second),
+	}); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "insert task reminder")
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package postgresql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/postgresql"
+)
+
+func TestTaskReminder(t *testing.T) {
+	t.Parallel()
+
+	pool := newDB(t)
+	now := time.Now().UTC().Truncate(time.Second)
+
+	newTask := func(due time.Time, reminders ...time.Duration) internal.Task {
+		task, err := postgresql.NewTask(pool).Create(context.Background(), internal.CreateParams{
+			Description: "reminder",
+			Priority:    internal.PriorityLow,
+			Dates:       internal.Dates{Due: due},
+			Reminders:   reminders,
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		return task
+	}
+
+	dueSoon := newTask(now.Add(30*time.Minute), time.Hour, 24*time.Hour)
+	overdue := newTask(now.Add(-time.Minute), time.Hour)
+	_ = newTask(now.Add(48*time.Hour), time.Hour)
+
+	store := postgresql.NewTaskReminder(pool)
+
+	//-
+
+	actual, err := store.DueSoon(context.Background(), now, 10)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	expected := []time.Duration{time.Hour, 24 * time.Hour}
+
+	if len(actual) != 2 || actual[0].Task.ID != dueSoon.ID || actual[1].Task.ID != dueSoon.ID {
+		t.Fatalf("expected reminders of %s, got %v", dueSoon.ID, actual)
+	}
+
+	if before := []time.Duration{actual[0].Before, actual[1].Before}; !cmp.Equal(expected, before) {
+		t.Fatalf("expected result does not match: %s", cmp.Diff(expected, before))
+	}
+
+	if err := store.Sent(context.Background(), actual[0]); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if actual, err = store.DueSoon(context.Background(), now, 10); err != nil || len(actual) != 1 {
+		t.Fatalf("expected one reminder not sent, got %v : %v", actual, err)
+	}
+
+	//-
+
+	actual, err = store.Overdue(context.Background(), now, 10)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if len(actual) != 1 || actual[0].Task.ID != overdue.ID || actual[0].Type != internal.ReminderOverdue {
+		t.Fatalf("expected overdue reminder of %s, got %v", overdue.ID, actual)
+	}
+
+	if err := store.Sent(context.Background(), actual[0]); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if actual, err = store.Overdue(context.Background(), now, 10); err != nil || len(actual) != 0 {
+		t.Fatalf("expected no reminders, got %v : %v", actual, err)
+	}
+}
+
+func TestAdvisoryLock(t *testing.T) {
+	t.Parallel()
+
+	pool := newDB(t)
+
+	leader := postgresql.NewAdvisoryLock(pool, 1)
+	follower := postgresql.NewAdvisoryLock(pool, 1)
+
+	for i, tt := range []struct {
+		lock     *postgresql.AdvisoryLock
+		expected bool
+	}{
+		{leader, true},
+		{follower, false},
+		{leader, true},
+	} {
+		locked, err := tt.lock.TryLock(context.Background())
+		if err != nil {
+			t.Fatalf("%d: expected no error, got %s", i, err)
+		}
+
+		if locked != tt.expected {
+			t.Fatalf("%d: expected %t, got %t", i, tt.expected, locked)
+		}
+	}
+
+	if err := leader.Unlock(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if locked, err := follower.TryLock(context.Background()); err != nil || !locked {
+		t.Fatalf("expected lock to be acquired, got %t : %v", locked, err)
+	}
+}
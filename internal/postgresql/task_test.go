@@ -80,10 +80,15 @@ func TestTask_Delete(t *testing.T) {
 			t.Fatalf("expected no error, got %s", err)
 		}
 
-		if err := store.Delete(context.Background(), createdTask.ID); err != nil {
+		version, err := store.Delete(context.Background(), createdTask.ID)
+		if err != nil {
 			t.Fatalf("expected no error, got %s", err)
 		}
 
+		if version != createdTask.Version {
+			t.Fatalf("expected version %d, got %d", createdTask.Version, version)
+		}
+
 		if _, err = store.Find(context.Background(), createdTask.ID); !errors.Is(err, pgx.ErrNoRows) {
 			t.Fatalf("expected no error, got %s", err)
 		}
@@ -92,7 +97,7 @@ func TestTask_Delete(t *testing.T) {
 	t.Run("Update: ERR uuid", func(t *testing.T) {
 		t.Parallel()
 
-		err := postgresql.NewTask(newDB(t)).Delete(context.Background(), "x")
+		_, err := postgresql.NewTask(newDB(t)).Delete(context.Background(), "x")
 
 		if err == nil {
 			t.Fatalf("expected error, got not value")
@@ -107,7 +112,7 @@ func TestTask_Delete(t *testing.T) {
 	t.Run("Delete: ERR not found", func(t *testing.T) {
 		t.Parallel()
 
-		err := postgresql.NewTask(newDB(t)).Delete(context.Background(), "44633fe3-b039-4fb3-a35f-a57fe3c906c7")
+		_, err := postgresql.NewTask(newDB(t)).Delete(context.Background(), "44633fe3-b039-4fb3-a35f-a57fe3c906c7")
 
 		var ierr *internal.Error
 		if !errors.As(err, &ierr) || ierr.Code() != internal.ErrorCodeNotFound {
@@ -172,6 +177,61 @@ func TestTask_Find(t *testing.T) {
 	})
 }
 
+func TestTask_ListAfter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ListAfter: OK", func(t *testing.T) {
+		t.Parallel()
+
+		store := postgresql.NewTask(newDB(t))
+
+		for _, description := range []string{"one", "two", "three"} {
+			if _, err := store.Create(context.Background(), internal.CreateParams{
+				Description: description,
+				Priority:    internal.PriorityLow,
+			}); err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+		}
+
+		first, err := store.ListAfter(context.Background(), "", 2)
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		if len(first) != 2 {
+			t.Fatalf("expected 2 tasks, got %d", len(first))
+		}
+
+		second, err := store.ListAfter(context.Background(), first[1].ID, 2)
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		if len(second) != 1 {
+			t.Fatalf("expected 1 task, got %d", len(second))
+		}
+
+		if second[0].ID <= first[1].ID {
+			t.Fatalf("expected sorted results, got %s after %s", second[0].ID, first[1].ID)
+		}
+	})
+
+	t.Run("ListAfter: ERR uuid", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := postgresql.NewTask(newDB(t)).ListAfter(context.Background(), "x", 10)
+		if err == nil {
+			t.Fatalf("expected error, got not value")
+		}
+
+		var ierr *internal.Error
+		if !errors.As(err, &ierr) || ierr.Code() != internal.ErrorCodeInvalidArgument {
+			t.Fatalf("expected %T error, got %T : %v", ierr, err, err)
+		}
+	})
+}
+
 func TestTask_Update(t *testing.T) {
 	t.Parallel()
 
@@ -192,6 +252,7 @@ func TestTask_Update(t *testing.T) {
 		originalTask.Description = "changed"
 		originalTask.Dates.Due = time.Now().UTC()
 		originalTask.Priority = internal.PriorityHigh
+		originalTask.Version++
 
 		if err := store.Update(context.Background(),
 			originalTask.ID,
@@ -285,6 +346,76 @@ func TestTask_Update(t *testing.T) {
 	})
 }
 
+func TestTask_Upsert(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Upsert: OK", func(t *testing.T) {
+		t.Parallel()
+
+		store := postgresql.NewTask(newDB(t))
+
+		params := internal.ImportParams{
+			CreateParams: internal.CreateParams{
+				Description: "imported",
+				Priority:    internal.PriorityLow,
+				Dates:       internal.Dates{Due: time.Date(2026, 10, 3, 17, 30, 0, 0, time.UTC)},
+			},
+			ExternalID: "external-1",
+		}
+
+		upsert := func(expected internal.ImportStatus) internal.Task {
+			task, status, err := store.Upsert(context.Background(), params)
+			if err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			if status != expected {
+				t.Fatalf("expected status %d, got %d", expected, status)
+			}
+
+			return task
+		}
+
+		created := upsert(internal.ImportStatusCreated)
+		unchanged := upsert(internal.ImportStatusUnchanged)
+
+		if created.ID != unchanged.ID || unchanged.Version != 1 {
+			t.Fatalf("expected same task, got %+v and %+v", created, unchanged)
+		}
+
+		params.IsDone = true
+
+		updated := upsert(internal.ImportStatusUpdated)
+
+		if created.ID != updated.ID || updated.Version != 2 {
+			t.Fatalf("expected updated task, got %+v", updated)
+		}
+
+		found, err := store.FindByExternalID(context.Background(), "external-1")
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		if !cmp.Equal(updated, found) {
+			t.Fatalf("expected result does not match: %s", cmp.Diff(updated, found))
+		}
+	})
+
+	t.Run("FindByExternalID: ERR not found", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := postgresql.NewTask(newDB(t)).FindByExternalID(context.Background(), "missing")
+		if err == nil {
+			t.Fatalf("expected error, got not value")
+		}
+
+		var ierr *internal.Error
+		if !errors.As(err, &ierr) || ierr.Code() != internal.ErrorCodeNotFound {
+			t.Fatalf("expected %T error, got %T : %v", ierr, err, err)
+		}
+	})
+}
+
 func newDB(tb testing.TB) *pgxpool.Pool {
 	tb.Helper()
 
@@ -0,0 +1,183 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/postgresql/db"
+)
+
+// Webhook represents the repository used for interacting with Webhook records.
+type Webhook struct {
+	q *db.Queries
+}
+
+// NewWebhook instantiates the Webhook repository.
+func NewWebhook(d db.DBTX) *Webhook {
+	return &Webhook{
+		q: db.New(d),
+	}
+}
+
+// Create inserts a new webhook record.
+func (w *Webhook) Create(ctx context.Context, params internal.CreateWebhookParams) (internal.Webhook, error) {
+	defer newOTELSpan(ctx, "Webhook.Create").End()
+
+	//-
+
+	eventTypes := params.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+
+	row, err := w.q.InsertWebhook(ctx, db.InsertWebhookParams{
+		Url:        params.URL,
+		EventTypes: eventTypes,
+		Secret:     params.Secret,
+	})
+	if err != nil {
+		return internal.Webhook{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "insert webhook")
+	}
+
+	return internal.Webhook{
+		ID:         row.ID.String(),
+		URL:        params.URL,
+		EventTypes: eventTypes,
+		Secret:     params.Secret,
+		CreatedAt:  row.CreatedAt.Time,
+	}, nil
+}
+
+// Delete deletes the existing record matching the id, its deliveries are deleted as well.
+func (w *Webhook) Delete(ctx context.Context, id string) error {
+	defer newOTELSpan(ctx, "Webhook.Delete").End()
+
+	//-
+
+	val, err := uuid.Parse(id)
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "invalid uuid")
+	}
+
+	if _, err := w.q.DeleteWebhook(ctx, val); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return internal.WrapErrorf(err, internal.ErrorCodeNotFound, "webhook not found")
+		}
+
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "delete webhook")
+	}
+
+	return nil
+}
+
+// Find returns the requested webhook by searching its id.
+func (w *Webhook) Find(ctx context.Context, id string) (internal.Webhook, error) {
+	defer newOTELSpan(ctx, "Webhook.Find").End()
+
+	//-
+
+	val, err := uuid.Parse(id)
+	if err != nil {
+		return internal.Webhook{}, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "invalid uuid")
+	}
+
+	res, err := w.q.SelectWebhook(ctx, val)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return internal.Webhook{}, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "webhook not found")
+		}
+
+		return internal.Webhook{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "select webhook")
+	}
+
+	return newWebhook(res), nil
+}
+
+// List returns all the webhooks sorted by creation time.
+func (w *Webhook) List(ctx context.Context) ([]internal.Webhook, error) {
+	defer newOTELSpan(ctx, "Webhook.List").End()
+
+	//-
+
+	rows, err := w.q.SelectWebhooks(ctx)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "select webhooks")
+	}
+
+	res := make([]internal.Webhook, len(rows))
+
+	for i, row := range rows {
+		res[i] = newWebhook(row)
+	}
+
+	return res, nil
+}
+
+// LogDelivery inserts a new delivery record.
+func (w *Webhook) LogDelivery(ctx context.Context, delivery internal.WebhookDelivery) error {
+	defer newOTELSpan(ctx, "Webhook.LogDelivery").End()
+
+	//-
+
+	val, err := uuid.Parse(delivery.WebhookID)
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "invalid uuid")
+	}
+
+	if err := w.q.InsertWebhookDelivery(ctx, db.InsertWebhookDeliveryParams{
+		WebhookID:  val,
+		EventID:    delivery.EventID,
+		EventType:  delivery.EventType,
+		Attempt:    int32(delivery.Attempt),
+		StatusCode: int32(delivery.StatusCode),
+		Error:      delivery.Error,
+		DurationMs: delivery.Duration.Milliseconds(),
+	}); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "insert webhook delivery")
+	}
+
+	return nil
+}
+
+// Deliveries returns, newest first, up to size deliveries of the webhook matching the id.
+func (w *Webhook) Deliveries(ctx context.Context, id string, size int32) ([]internal.WebhookDelivery, error) {
+	defer newOTELSpan(ctx, "Webhook.Deliveries").End()
+
+	//-
+
+	val, err := uuid.Parse(id)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "invalid uuid")
+	}
+
+	rows, err := w.q.SelectWebhookDeliveries(ctx, db.SelectWebhookDeliveriesParams{
+		WebhookID: val,
+		MaxRows:   size,
+	})
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "select webhook deliveries")
+	}
+
+	res := make([]internal.WebhookDelivery, len(rows))
+
+	for i, row := range rows {
+		res[i] = internal.WebhookDelivery{
+			ID:         row.ID.String(),
+			WebhookID:  row.WebhookID.String(),
+			EventID:    row.EventID,
+			EventType:  row.EventType,
+			Attempt:    int(row.Attempt),
+			StatusCode: int(row.StatusCode),
+			Error:      row.Error,
+			Duration:   time.Duration(row.DurationMs) * time.Millisecond,
+			CreatedAt:  row.CreatedAt.Time,
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,85 @@
+package postgresql_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/postgresql"
+)
+
+func TestWebhook(t *testing.T) {
+	t.Parallel()
+
+	store := postgresql.NewWebhook(newDB(t))
+
+	created, err := store.Create(context.Background(), internal.CreateWebhookParams{
+		URL:        "https://example.com/hooks",
+		EventTypes: []string{"tasks.event.created"},
+		Secret:     "0123456789abcdef",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	found, err := store.Find(context.Background(), created.ID)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if !cmp.Equal(created, found) {
+		t.Fatalf("expected result does not match: %s", cmp.Diff(created, found))
+	}
+
+	list, err := store.List(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if !cmp.Equal([]internal.Webhook{created}, list) {
+		t.Fatalf("expected result does not match: %s", cmp.Diff([]internal.Webhook{created}, list))
+	}
+
+	for attempt := 1; attempt <= 2; attempt++ {
+		if err := store.LogDelivery(context.Background(), internal.WebhookDelivery{
+			WebhookID:  created.ID,
+			EventID:    "event",
+			EventType:  "tasks.event.created",
+			Attempt:    attempt,
+			StatusCode: 500,
+			Error:      "unexpected status code",
+			Duration:   time.Second,
+		}); err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+	}
+
+	deliveries, err := store.Deliveries(context.Background(), created.ID, 1)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if len(deliveries) != 1 || deliveries[0].StatusCode != 500 || deliveries[0].Duration != time.Second {
+		t.Fatalf("expected one delivery, got %v", deliveries)
+	}
+
+	if err := store.Delete(context.Background(), created.ID); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	var ierr *internal.Error
+
+	_, err = store.Find(context.Background(), created.ID)
+	if !errors.As(err, &ierr) || ierr.Code() != internal.ErrorCodeNotFound {
+		t.Fatalf("expected %T error, got %T : %v", ierr, err, err)
+	}
+
+	err = store.Delete(context.Background(), created.ID)
+	if !errors.As(err, &ierr) || ierr.Code() != internal.ErrorCodeNotFound {
+		t.Fatalf("expected %T error, got %T : %v", ierr, err, err)
+	}
+}
@@ -0,0 +1,41 @@
+package rabbitmq
+
+import (
+	"context"
+
+	"github.com/streadway/amqp"
+	"go.opentelemetry.io/otel"
+)
+
+// Extract returns a copy of ctx including the W3C Trace Context in the message headers.
+func Extract(ctx context.Context, headers amqp.Table) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, headersCarrier(headers))
+}
+
+// inject sets the W3C Trace Context in ctx to the message headers.
+func inject(ctx context.Context, headers amqp.Table) {
+	otel.GetTextMapPropagator().Inject(ctx, headersCarrier(headers))
+}
+
+// headersCarrier implements "propagation.TextMapCarrier" using the message headers.
+type headersCarrier amqp.Table
+
+func (c headersCarrier) Get(key string) string {
+	val, _ := c[key].(string)
+
+	return val
+}
+
+func (c headersCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+func (c headersCarrier) Keys() []string {
+	res := make([]string, 0, len(c))
+
+	for key := range c {
+		res = append(res, key)
+	}
+
+	return res
+}
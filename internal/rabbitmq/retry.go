@@ -0,0 +1,264 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/streadway/amqp"
+
+	"github.com/MarioCarrion/todo-api/internal"
+)
+
+const (
+	// HeaderAttempts is the number of times consuming the message failed.
+	HeaderAttempts = "x-attempts"
+
+	// HeaderRoutingKey is the routing key used when the message was published, messages consumed from the retry
+	// and dead letter queues are routed using the queue name instead.
+	HeaderRoutingKey = "x-routing-key"
+
+	// HeaderError is the error that caused the message to be dead-lettered.
+	HeaderError = "x-error"
+)
+
+// maxDelay is the maximum delay before retrying a message, the delay stops doubling once it's reached.
+const maxDelay = 30 * time.Second
+
+//go:generate counterfeiter -generate
+
+//counterfeiter:generate -o rabbitmqtesting/channel.gen.go . Channel
+
+// Channel defines the AMQP channel used by Retry, like amqp.Channel.
+type Channel interface {
+	ExchangeDeclare(name, kind string, durable, autoDelete, internal, noWait bool, args amqp.Table) error
+	QueueDeclare(name string, durable, autoDelete, exclusive, noWait bool, args amqp.Table) (amqp.Queue, error)
+	QueueBind(name, key, exchange string, noWait bool, args amqp.Table) error
+	Publish(exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error
+}
+
+// Retry implements the retry policy of the messages consumed from a queue:
+//
+//   - Messages failing to be consumed are published to a retry queue, after a delay they are routed back to the
+//     queue; the delay increases exponentially with each attempt, up to 30 seconds,
+//   - Messages failing to be consumed after the maximum number of attempts, and the ones that can't be consumed at
+//     all, are published to a dead letter exchange, they are kept in the dead letter queue until they are replayed.
+//
+// Each delay uses its own retry queue, "<queue>.retry.<delay>", with the message TTL set to that delay; that way
+// messages expire in the order they were published.
+type Retry struct {
+	ch          Channel
+	queue       string
+	maxAttempts int
+	backoff     time.Duration
+}
+
+// NewRetry instantiates the Retry policy of queue, backoff is the delay before the first retry.
+func NewRetry(channel Channel, queue string, maxAttempts int, backoff time.Duration) *Retry {
+	return &Retry{
+		ch:          channel,
+		queue:       queue,
+		maxAttempts: maxAttempts,
+		backoff:     backoff,
+	}
+}
+
+// DeadLetterExchange returns the name of the dead letter exchange of queue.
+func DeadLetterExchange(queue string) string {
+	return queue + ".dlx"
+}
+
+// DeadLetterQueue returns the name of the dead letter queue of queue.
+func DeadLetterQueue(queue string) string {
+	return queue + ".dlq"
+}
+
+// Declare declares the durable queue, bound to exchange using bindingKey, its dead letter exchange and queue, and
+// the retry queues.
+func (r *Retry) Declare(exchange, bindingKey string) error {
+	dlx := DeadLetterExchange(r.queue)
+
+	if err := r.ch.ExchangeDeclare(dlx, "fanout", true, false, false, false, nil); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "ch.ExchangeDeclare")
+	}
+
+	if _, err := r.ch.QueueDeclare(DeadLetterQueue(r.queue), true, false, false, false, nil); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "ch.QueueDeclare")
+	}
+
+	if err := r.ch.QueueBind(DeadLetterQueue(r.queue), "", dlx, false, nil); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "ch.QueueBind")
+	}
+
+	// Messages rejected without being published to the dead letter exchange, for example by other consumers, are
+	// dead-lettered by RabbitMQ.
+	if _, err := r.ch.QueueDeclare(r.queue, true, false, false, false, amqp.Table{
+		"x-dead-letter-exchange": dlx,
+	}); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "ch.QueueDeclare")
+	}
+
+	if err := r.ch.QueueBind(r.queue, bindingKey, exchange, false, nil); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "ch.QueueBind")
+	}
+
+	for attempt := 1; attempt < r.maxAttempts; attempt++ {
+		delay := r.delay(attempt)
+
+		if _, err := r.ch.QueueDeclare(r.retryQueue(delay), true, false, false, false, amqp.Table{
+			"x-message-ttl":             delay.Milliseconds(),
+			"x-dead-letter-exchange":    "",
+			"x-dead-letter-routing-key": r.queue,
+		}); err != nil {
+			return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "ch.QueueDeclare")
+		}
+	}
+
+	return nil
+}
+
+// Retry acknowledges the message after publishing it to the retry queue, or to the dead letter exchange once the
+// maximum number of attempts is reached.
+func (r *Retry) Retry(msg amqp.Delivery, cause error) error {
+	attempts := Attempts(msg) + 1
+
+	if attempts >= r.maxAttempts {
+		return r.deadLetter(msg, attempts, cause)
+	}
+
+	headers := newHeaders(msg)
+	headers[HeaderAttempts] = int32(attempts) //nolint: gosec
+
+	if err := r.publish("", r.retryQueue(r.delay(attempts)), msg, headers); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "publish")
+	}
+
+	if err := msg.Ack(false); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "msg.Ack")
+	}
+
+	return nil
+}
+
+// DeadLetter acknowledges the message after publishing it to the dead letter exchange, it's meant for messages
+// that can't be consumed regardless of the number of attempts, like the ones that can't be decoded.
+func (r *Retry) DeadLetter(msg amqp.Delivery, cause error) error {
+	return r.deadLetter(msg, Attempts(msg)+1, cause)
+}
+
+// Replay acknowledges a message consumed from the dead letter queue after publishing it to the queue again, with
+// its attempts reset.
+func (r *Retry) Replay(msg amqp.Delivery) error {
+	headers := newHeaders(msg)
+
+	delete(headers, HeaderAttempts)
+	delete(headers, HeaderError)
+	delete(headers, "x-death")
+
+	if err := r.publish("", r.queue, msg, headers); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "publish")
+	}
+
+	if err := msg.Ack(false); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "msg.Ack")
+	}
+
+	return nil
+}
+
+// Attempts returns the number of times consuming the message failed.
+func Attempts(msg amqp.Delivery) int {
+	switch val := msg.Headers[HeaderAttempts].(type) {
+	case int32:
+		return int(val)
+	case int64:
+		return int(val)
+	}
+
+	return 0
+}
+
+// RoutingKey returns the routing key used when the message was published.
+func RoutingKey(msg amqp.Delivery) string {
+	if val, ok := msg.Headers[HeaderRoutingKey].(string); ok {
+		return val
+	}
+
+	return msg.RoutingKey
+}
+
+// PublishDeadLetter publishes the message to the dead letter exchange without acknowledging it, it's meant for
+// consumers acknowledging the messages in order, after the ones received before it.
+func (r *Retry) PublishDeadLetter(msg amqp.Delivery, cause error) error {
+	return r.publishDeadLetter(msg, Attempts(msg)+1, cause)
+}
+
+func (r *Retry) deadLetter(msg amqp.Delivery, attempts int, cause error) error {
+	if err := r.publishDeadLetter(msg, attempts, cause); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "publishDeadLetter")
+	}
+
+	if err := msg.Ack(false); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "msg.Ack")
+	}
+
+	return nil
+}
+
+func (r *Retry) publishDeadLetter(msg amqp.Delivery, attempts int, cause error) error {
+	headers := newHeaders(msg)
+	headers[HeaderAttempts] = int32(attempts) //nolint: gosec
+	headers[HeaderError] = cause.Error()
+
+	if err := r.publish(DeadLetterExchange(r.queue), "", msg, headers); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "publish")
+	}
+
+	return nil
+}
+
+func (r *Retry) publish(exchange, routingKey string, msg amqp.Delivery, headers amqp.Table) error {
+	return r.ch.Publish( //nolint: wrapcheck
+		exchange,   // exchange
+		routingKey, // routing key
+		false,      // mandatory
+		false,      // immediate
+		amqp.Publishing{
+			Headers:      headers,
+			ContentType:  msg.ContentType,
+			DeliveryMode: amqp.Persistent,
+			MessageId:    msg.MessageId,
+			Timestamp:    msg.Timestamp,
+			Type:         msg.Type,
+			AppId:        msg.AppId,
+			Body:         msg.Body,
+		})
+}
+
+// delay returns the delay before retrying the attempt, it doubles with each attempt up to maxDelay.
+func (r *Retry) delay(attempt int) time.Duration {
+	delay := r.backoff
+
+	// Doubling stops at maxDelay, shifting by the attempt would overflow after enough attempts.
+	for i := 1; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxDelay)
+}
+
+func (r *Retry) retryQueue(delay time.Duration) string {
+	return fmt.Sprintf("%s.retry.%s", r.queue, delay)
+}
+
+// newHeaders copies the message headers, including the original routing key.
+func newHeaders(msg amqp.Delivery) amqp.Table {
+	res := make(amqp.Table, len(msg.Headers)+3)
+
+	for key, val := range msg.Headers {
+		res[key] = val
+	}
+
+	res[HeaderRoutingKey] = RoutingKey(msg)
+
+	return res
+}
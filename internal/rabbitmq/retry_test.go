@@ -0,0 +1,306 @@
+package rabbitmq_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/streadway/amqp"
+
+	"github.com/MarioCarrion/todo-api/internal/rabbitmq"
+	"github.com/MarioCarrion/todo-api/internal/rabbitmq/rabbitmqtesting"
+)
+
+const queue = "elasticsearch-indexer"
+
+func TestRetry_Declare(t *testing.T) {
+	t.Parallel()
+
+	channel := &rabbitmqtesting.FakeChannel{}
+
+	if err := rabbitmq.NewRetry(channel, queue, 10, time.Second).Declare("tasks", "tasks.event.*"); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	// The first two declared queues are the dead letter queue and the queue itself.
+	var actual []string
+
+	for i := 2; i < channel.QueueDeclareCallCount(); i++ {
+		name, _, _, _, _, args := channel.QueueDeclareArgsForCall(i)
+
+		actual = append(actual, name)
+
+		if args["x-dead-letter-routing-key"] != queue {
+			t.Fatalf("expected %s to be routed back to the queue, got %v", name, args)
+		}
+	}
+
+	// The delay doubles with each attempt until it reaches 30 seconds.
+	expected := []string{
+		queue + ".retry.1s",
+		queue + ".retry.2s",
+		queue + ".retry.4s",
+		queue + ".retry.8s",
+		queue + ".retry.16s",
+		queue + ".retry.30s",
+		queue + ".retry.30s",
+		queue + ".retry.30s",
+		queue + ".retry.30s",
+	}
+
+	if !cmp.Equal(expected, actual) {
+		t.Fatalf("expected queues do not match: %s", cmp.Diff(expected, actual))
+	}
+
+	_, _, _, _, _, args := channel.QueueDeclareArgsForCall(channel.QueueDeclareCallCount() - 1)
+	if args["x-message-ttl"] != (30 * time.Second).Milliseconds() {
+		t.Fatalf("expected 30s TTL, got %v", args["x-message-ttl"])
+	}
+}
+
+func TestRetry_Retry(t *testing.T) {
+	t.Parallel()
+
+	type output struct {
+		exchange   string
+		routingKey string
+		headers    amqp.Table
+		acked      bool
+		withErr    bool
+	}
+
+	tests := []struct {
+		name        string
+		setup       func(*rabbitmqtesting.FakeChannel)
+		maxAttempts int
+		headers     amqp.Table
+		output      output
+	}{
+		{
+			"OK: first attempt",
+			func(*rabbitmqtesting.FakeChannel) {},
+			5,
+			nil,
+			output{
+				routingKey: queue + ".retry.1s",
+				headers: amqp.Table{
+					rabbitmq.HeaderAttempts:   int32(1),
+					rabbitmq.HeaderRoutingKey: "tasks.event.created",
+				},
+				acked: true,
+			},
+		},
+		{
+			"OK: int32 attempts",
+			func(*rabbitmqtesting.FakeChannel) {},
+			5,
+			amqp.Table{rabbitmq.HeaderAttempts: int32(2)},
+			output{
+				routingKey: queue + ".retry.4s",
+				headers: amqp.Table{
+					rabbitmq.HeaderAttempts:   int32(3),
+					rabbitmq.HeaderRoutingKey: "tasks.event.created",
+				},
+				acked: true,
+			},
+		},
+		{
+			"OK: int64 attempts",
+			func(*rabbitmqtesting.FakeChannel) {},
+			5,
+			amqp.Table{rabbitmq.HeaderAttempts: int64(2)},
+			output{
+				routingKey: queue + ".retry.4s",
+				headers: amqp.Table{
+					rabbitmq.HeaderAttempts:   int32(3),
+					rabbitmq.HeaderRoutingKey: "tasks.event.created",
+				},
+				acked: true,
+			},
+		},
+		{
+			"OK: maximum delay",
+			func(*rabbitmqtesting.FakeChannel) {},
+			100,
+			amqp.Table{rabbitmq.HeaderAttempts: int32(70)},
+			output{
+				routingKey: queue + ".retry.30s",
+				headers: amqp.Table{
+					rabbitmq.HeaderAttempts:   int32(71),
+					rabbitmq.HeaderRoutingKey: "tasks.event.created",
+				},
+				acked: true,
+			},
+		},
+		{
+			"OK: original routing key",
+			func(*rabbitmqtesting.FakeChannel) {},
+			5,
+			amqp.Table{
+				rabbitmq.HeaderAttempts:   int32(1),
+				rabbitmq.HeaderRoutingKey: "tasks.event.updated",
+			},
+			output{
+				routingKey: queue + ".retry.2s",
+				headers: amqp.Table{
+					rabbitmq.HeaderAttempts:   int32(2),
+					rabbitmq.HeaderRoutingKey: "tasks.event.updated",
+				},
+				acked: true,
+			},
+		},
+		{
+			"OK: dead-lettered after maximum attempts",
+			func(*rabbitmqtesting.FakeChannel) {},
+			5,
+			amqp.Table{rabbitmq.HeaderAttempts: int32(4)},
+			output{
+				exchange: rabbitmq.DeadLetterExchange(queue),
+				headers: amqp.Table{
+					rabbitmq.HeaderAttempts:   int32(5),
+					rabbitmq.HeaderRoutingKey: "tasks.event.created",
+					rabbitmq.HeaderError:      "failed",
+				},
+				acked: true,
+			},
+		},
+		{
+			"ERR: publish",
+			func(c *rabbitmqtesting.FakeChannel) {
+				c.PublishReturns(errors.New("failed"))
+			},
+			5,
+			nil,
+			output{
+				routingKey: queue + ".retry.1s",
+				headers: amqp.Table{
+					rabbitmq.HeaderAttempts:   int32(1),
+					rabbitmq.HeaderRoutingKey: "tasks.event.created",
+				},
+				withErr: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			channel := &rabbitmqtesting.FakeChannel{}
+			tt.setup(channel)
+
+			ack := &fakeAcknowledger{}
+			msg := amqp.Delivery{
+				Acknowledger: ack,
+				Headers:      tt.headers,
+				RoutingKey:   "tasks.event.created",
+				Body:         []byte("body"),
+			}
+
+			err := rabbitmq.NewRetry(channel, queue, tt.maxAttempts, time.Second).Retry(msg, errors.New("failed"))
+			if (err != nil) != tt.output.withErr {
+				t.Fatalf("expected error %t, got %v", tt.output.withErr, err)
+			}
+
+			exchange, routingKey, _, _, publishing := channel.PublishArgsForCall(0)
+
+			actual := output{
+				exchange:   exchange,
+				routingKey: routingKey,
+				headers:    publishing.Headers,
+				acked:      ack.acked,
+				withErr:    err != nil,
+			}
+
+			if !cmp.Equal(tt.output, actual, cmp.AllowUnexported(output{})) {
+				t.Fatalf("expected result does not match: %s", cmp.Diff(tt.output, actual, cmp.AllowUnexported(output{})))
+			}
+
+			if string(publishing.Body) != "body" {
+				t.Fatalf("expected body, got %s", publishing.Body)
+			}
+		})
+	}
+}
+
+func TestRetry_Replay(t *testing.T) {
+	t.Parallel()
+
+	channel := &rabbitmqtesting.FakeChannel{}
+	ack := &fakeAcknowledger{}
+
+	msg := amqp.Delivery{
+		Acknowledger: ack,
+		Headers: amqp.Table{
+			rabbitmq.HeaderAttempts:   int32(5),
+			rabbitmq.HeaderRoutingKey: "tasks.event.updated",
+			rabbitmq.HeaderError:      "failed",
+			"x-death":                 []interface{}{amqp.Table{"count": int64(1)}},
+			"traceparent":             "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+		},
+		RoutingKey: "",
+	}
+
+	if err := rabbitmq.NewRetry(channel, queue, 0, 0).Replay(msg); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	exchange, routingKey, _, _, publishing := channel.PublishArgsForCall(0)
+	if exchange != "" || routingKey != queue {
+		t.Fatalf("expected message to be published to the queue, got %q %q", exchange, routingKey)
+	}
+
+	expected := amqp.Table{
+		rabbitmq.HeaderRoutingKey: "tasks.event.updated",
+		"traceparent":             "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+	}
+
+	if !cmp.Equal(expected, publishing.Headers) {
+		t.Fatalf("expected headers do not match: %s", cmp.Diff(expected, publishing.Headers))
+	}
+
+	if !ack.acked {
+		t.Fatalf("expected message to be acknowledged")
+	}
+}
+
+func TestAttempts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		headers  amqp.Table
+		expected int
+	}{
+		{"OK: missing", nil, 0},
+		{"OK: int32", amqp.Table{rabbitmq.HeaderAttempts: int32(3)}, 3},
+		{"OK: int64", amqp.Table{rabbitmq.HeaderAttempts: int64(4)}, 4},
+		{"OK: unknown type", amqp.Table{rabbitmq.HeaderAttempts: "5"}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if actual := rabbitmq.Attempts(amqp.Delivery{Headers: tt.headers}); actual != tt.expected {
+				t.Fatalf("expected %d, got %d", tt.expected, actual)
+			}
+		})
+	}
+}
+
+// fakeAcknowledger records whether the message was acknowledged.
+type fakeAcknowledger struct {
+	acked bool
+}
+
+func (f *fakeAcknowledger) Ack(uint64, bool) error {
+	f.acked = true
+
+	return nil
+}
+
+func (f *fakeAcknowledger) Nack(uint64, bool, bool) error { return nil }
+
+func (f *fakeAcknowledger) Reject(uint64, bool) error { return nil }
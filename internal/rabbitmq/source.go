@@ -0,0 +1,106 @@
+package rabbitmq
+
+import (
+	"context"
+
+	"github.com/streadway/amqp"
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/indexer"
+)
+
+// Source implements "indexer.Source" consuming the queue bound to the "tasks" exchange, messages failing to be
+// consumed are retried, and dead-lettered, using Retry.
+type Source struct {
+	logger   *zap.Logger
+	conn     *amqp.Connection
+	ch       *amqp.Channel
+	retry    *Retry
+	queue    string
+	consumer string
+}
+
+// NewSource instantiates the Source, retry must use the same queue.
+func NewSource(logger *zap.Logger, conn *amqp.Connection, channel *amqp.Channel, retry *Retry,
+	queue, consumer string,
+) *Source {
+	return &Source{
+		logger:   logger,
+		conn:     conn,
+		ch:       channel,
+		retry:    retry,
+		queue:    queue,
+		consumer: consumer,
+	}
+}
+
+// Consume declares the queue and calls handler for each message until ctx is done.
+func (s *Source) Consume(ctx context.Context, handler indexer.Handler) error {
+	if err := s.retry.Declare("tasks", "tasks.event.*"); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "retry.Declare")
+	}
+
+	msgs, err := s.ch.Consume(
+		s.queue,    // queue
+		s.consumer, // consumer
+		false,      // auto-ack
+		false,      // exclusive
+		false,      // no-local
+		false,      // no-wait
+		nil,        // args
+	)
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "ch.Consume")
+	}
+
+	// Canceling the consumer closes msgs once the messages already delivered are received.
+	go func() {
+		<-ctx.Done()
+
+		_ = s.ch.Cancel(s.consumer, false)
+	}()
+
+	for msg := range msgs {
+		// The trace started by the request publishing the event continues while indexing it.
+		handler(Extract(context.Background(), msg.Headers), s.message(msg))
+	}
+
+	if ctx.Err() == nil {
+		return internal.NewErrorf(internal.ErrorCodeUnknown, "channel closed")
+	}
+
+	return nil
+}
+
+// Ping indicates whether the connection is open.
+func (s *Source) Ping(_ context.Context) error {
+	if s.conn.IsClosed() {
+		return internal.NewErrorf(internal.ErrorCodeUnknown, "connection closed")
+	}
+
+	return nil
+}
+
+func (s *Source) message(msg amqp.Delivery) indexer.Message {
+	return indexer.Message{
+		ID:          msg.MessageId,
+		Type:        RoutingKey(msg),
+		ContentType: msg.ContentType,
+		Data:        msg.Body,
+		Ack: func() {
+			if err := msg.Ack(false); err != nil {
+				s.logger.Error("Couldn't acknowledge message", zap.Error(err))
+			}
+		},
+		// Messages failing to be indexed are retried later, and dead-lettered after the maximum number of attempts.
+		Retry: func(cause error) {
+			if err := s.retry.Retry(msg, cause); err != nil {
+				s.logger.Error("Couldn't retry message", zap.Error(err))
+			}
+		},
+		DeadLetter: func(cause error) error {
+			return s.retry.PublishDeadLetter(msg, cause)
+		},
+	}
+}
@@ -1,50 +1,61 @@
 package rabbitmq
 
 import (
-	"bytes"
 	"context"
-	"encoding/gob"
-	"time"
 
 	"github.com/streadway/amqp"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/events"
 )
 
 const otelName = "github.com/MarioCarrion/todo-api/internal/rabbitmq"
 
 // Task represents the repository used for publishing Task records.
 type Task struct {
-	ch *amqp.Channel
+	ch    *amqp.Channel
+	codec events.Codec
 }
 
 // NewTask instantiates the Task repository.
-func NewTask(channel *amqp.Channel) (*Task, error) {
+func NewTask(channel *amqp.Channel, codec events.Codec) (*Task, error) {
 	return &Task{
-		ch: channel,
+		ch:    channel,
+		codec: codec,
 	}, nil
 }
 
 // Created publishes a message indicating a task was created.
 func (t *Task) Created(ctx context.Context, task internal.Task) error {
-	return t.publish(ctx, "Task.Created", "tasks.event.created", task)
+	return t.publish(ctx, "Task.Created", events.TypeTaskCreated, task)
 }
 
 // Deleted publishes a message indicating a task was deleted.
-func (t *Task) Deleted(ctx context.Context, id string) error {
-	return t.publish(ctx, "Task.Deleted", "tasks.event.deleted", id)
+func (t *Task) Deleted(ctx context.Context, id string, version int64) error {
+	return t.publish(ctx, "Task.Deleted", events.TypeTaskDeleted, internal.Task{ID: id, Version: version})
 }
 
 // Updated publishes a message indicating a task was updated.
 func (t *Task) Updated(ctx context.Context, task internal.Task) error {
-	return t.publish(ctx, "Task.Updated", "tasks.event.updated", task)
+	return t.publish(ctx, "Task.Updated", events.TypeTaskUpdated, task)
 }
 
-func (t *Task) publish(ctx context.Context, spanName, routingKey string, event interface{}) error {
-	_, span := otel.Tracer(otelName).Start(ctx, spanName)
+// DueSoon publishes a message indicating a task is due soon.
+func (t *Task) DueSoon(ctx context.Context, task internal.Task) error {
+	return t.publish(ctx, "Task.DueSoon", events.TypeTaskDueSoon, task)
+}
+
+// Overdue publishes a message indicating a task is overdue.
+func (t *Task) Overdue(ctx context.Context, task internal.Task) error {
+	return t.publish(ctx, "Task.Overdue", events.TypeTaskOverdue, task)
+}
+
+func (t *Task) publish(ctx context.Context, spanName, routingKey string, task internal.Task) error {
+	ctx, span := otel.Tracer(otelName).Start(ctx, spanName, trace.WithSpanKind(trace.SpanKindProducer))
 	defer span.End()
 
 	span.SetAttributes(
@@ -60,22 +71,30 @@ func (t *Task) publish(ctx context.Context, spanName, routingKey string, event i
 
 	//-
 
-	var b bytes.Buffer
+	evt := events.NewTaskEvent(routingKey, task)
 
-	if err := gob.NewEncoder(&b).Encode(event); err != nil {
-		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "gob.Encode")
+	b, err := t.codec.Marshal(evt)
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "codec.Marshal")
 	}
 
-	err := t.ch.Publish(
+	headers := amqp.Table{}
+
+	inject(ctx, headers)
+
+	err = t.ch.Publish(
 		"tasks",    // exchange
 		routingKey, // routing key
 		false,      // mandatory
 		false,      // immediate
 		amqp.Publishing{
 			AppId:       "tasks-rest-server",
-			ContentType: "application/x-encoding-gob", // XXX: We will revisit this in future episodes
-			Body:        b.Bytes(),
-			Timestamp:   time.Now(),
+			Headers:     headers,
+			ContentType: t.codec.ContentType(),
+			MessageId:   evt.ID,
+			Type:        evt.Type,
+			Body:        b,
+			Timestamp:   evt.Time,
 		})
 	if err != nil {
 		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "ch.Publish")
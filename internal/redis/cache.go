@@ -0,0 +1,87 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/MarioCarrion/todo-api/internal"
+)
+
+// Cache represents the repository used for caching values, it implements "memcached.Cache".
+type Cache struct {
+	client *redis.Client
+}
+
+// NewCache instantiates the Cache repository.
+func NewCache(client *redis.Client) *Cache {
+	return &Cache{
+		client: client,
+	}
+}
+
+// Get returns the value, or an error with the code internal.ErrorCodeNotFound when missing.
+func (c *Cache) Get(ctx context.Context, key string) ([]byte, error) {
+	defer newOTELSpan(ctx, "Cache.Get", "GET").End()
+
+	//-
+
+	val, err := c.client.Get(ctx, key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "client.Get")
+		}
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "client.Get")
+	}
+
+	return val, nil
+}
+
+// Set stores the value, a zero TTL means the value does not expire.
+func (c *Cache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	defer newOTELSpan(ctx, "Cache.Set", "SET").End()
+
+	//-
+
+	if err := c.client.Set(ctx, key, value, ttl).Err(); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "client.Set")
+	}
+
+	return nil
+}
+
+// Delete ...
+func (c *Cache) Delete(ctx context.Context, key string) error {
+	defer newOTELSpan(ctx, "Cache.Delete", "DEL").End()
+
+	//-
+
+	if err := c.client.Del(ctx, key).Err(); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "client.Del")
+	}
+
+	return nil
+}
+
+//-
+
+func newOTELSpan(ctx context.Context, name, statement string) trace.Span {
+	_, span := otel.Tracer(otelName).Start(ctx, name)
+
+	span.SetAttributes(
+		semconv.DBSystemRedis,
+		attribute.KeyValue{
+			Key:   semconv.DBStatementKey,
+			Value: attribute.StringValue(statement),
+		},
+	)
+
+	return span
+}
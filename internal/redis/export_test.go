@@ -0,0 +1,4 @@
+package redis
+
+// ParseAutoClaim exports parseAutoClaim for testing, the replies depend on the Redis version.
+var ParseAutoClaim = parseAutoClaim //nolint: gochecknoglobals
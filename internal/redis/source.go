@@ -0,0 +1,98 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/indexer"
+)
+
+// DeadLetterStream is the stream the entries that can't be consumed are added to.
+const DeadLetterStream = Stream + ".dlq"
+
+// fieldError is the field of the dead-lettered entries indicating the error.
+const fieldError = "error"
+
+// StreamSource implements "indexer.Source" using a StreamConsumer.
+//
+// Entries failing to be consumed are not acknowledged, they stay pending and are claimed and consumed again after
+// the StreamConsumer's minIdle, until they are dead-lettered after its maxDeliveries.
+type StreamSource struct {
+	logger   *zap.Logger
+	client   *redis.Client
+	consumer *StreamConsumer
+	count    int64
+}
+
+// NewStreamSource instantiates the StreamSource, count is the maximum number of entries read at once.
+func NewStreamSource(logger *zap.Logger, client *redis.Client, consumer *StreamConsumer, count int64) *StreamSource {
+	return &StreamSource{
+		logger:   logger,
+		client:   client,
+		consumer: consumer,
+		count:    count,
+	}
+}
+
+// Consume calls handler for each entry until ctx is done.
+func (s *StreamSource) Consume(ctx context.Context, handler indexer.Handler) error {
+	if err := s.consumer.Init(ctx); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "consumer.Init")
+	}
+
+	for ctx.Err() == nil {
+		// Blocking for less than a second lets the loop exit promptly after ctx is done.
+		msgs, err := s.consumer.Read(ctx, s.count, 500*time.Millisecond)
+		if err != nil {
+			if ctx.Err() == nil {
+				s.logger.Error("Couldn't read stream", zap.Error(err))
+
+				time.Sleep(time.Second)
+			}
+
+			continue
+		}
+
+		for _, msg := range msgs {
+			handler(context.Background(), s.message(msg))
+		}
+	}
+
+	return nil
+}
+
+// Ping pings the Redis server.
+func (s *StreamSource) Ping(ctx context.Context) error {
+	if err := s.client.Ping(ctx).Err(); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "client.Ping")
+	}
+
+	return nil
+}
+
+func (s *StreamSource) message(msg redis.XMessage) indexer.Message {
+	typ, _ := msg.Values[fieldType].(string)
+	contentType, _ := msg.Values[fieldContentType].(string)
+	data, _ := msg.Values[fieldData].(string)
+
+	return indexer.Message{
+		ID:          msg.ID,
+		Type:        typ,
+		ContentType: contentType,
+		Data:        []byte(data),
+		Ack: func() {
+			if err := s.consumer.Ack(context.Background(), msg.ID); err != nil {
+				s.logger.Error("Couldn't acknowledge message", zap.Error(err))
+			}
+		},
+		// Not acknowledged, the entry stays pending until it's claimed again or dead-lettered by the consumer.
+		Retry: func(error) {},
+		DeadLetter: func(cause error) error {
+			return s.consumer.deadLetter(context.Background(), msg, cause)
+		},
+	}
+}
@@ -0,0 +1,365 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/events"
+)
+
+const otelName = "github.com/MarioCarrion/todo-api/internal/redis"
+
+// Stream is the name of the stream the Task events are added to.
+const Stream = "tasks.events"
+
+// Fields of the stream entries.
+const (
+	fieldType        = "type"
+	fieldContentType = "content_type"
+	fieldData        = "data"
+)
+
+// TaskStream represents the repository used for publishing Task records to a Redis Stream, the events are kept
+// until the consumers acknowledge them.
+type TaskStream struct {
+	client *redis.Client
+	codec  events.Codec
+	maxLen int64
+}
+
+// NewTaskStream instantiates the TaskStream repository, the stream is trimmed to approximately maxLen entries.
+func NewTaskStream(client *redis.Client, codec events.Codec, maxLen int64) *TaskStream {
+	return &TaskStream{
+		client: client,
+		codec:  codec,
+		maxLen: maxLen,
+	}
+}
+
+// Created publishes a message indicating a task was created.
+func (t *TaskStream) Created(ctx context.Context, task internal.Task) error {
+	return t.add(ctx, "TaskStream.Created", events.TypeTaskCreated, task)
+}
+
+// Deleted publishes a message indicating a task was deleted.
+func (t *TaskStream) Deleted(ctx context.Context, id string, version int64) error {
+	return t.add(ctx, "TaskStream.Deleted", events.TypeTaskDeleted, internal.Task{ID: id, Version: version})
+}
+
+// Updated publishes a message indicating a task was updated.
+func (t *TaskStream) Updated(ctx context.Context, task internal.Task) error {
+	return t.add(ctx, "TaskStream.Updated", events.TypeTaskUpdated, task)
+}
+
+// DueSoon publishes a message indicating a task is due soon.
+func (t *TaskStream) DueSoon(ctx context.Context, task internal.Task) error {
+	return t.add(ctx, "TaskStream.DueSoon", events.TypeTaskDueSoon, task)
+}
+
+// Overdue publishes a message indicating a task is overdue.
+func (t *TaskStream) Overdue(ctx context.Context, task internal.Task) error {
+	return t.add(ctx, "TaskStream.Overdue", events.TypeTaskOverdue, task)
+}
+
+func (t *TaskStream) add(ctx context.Context, spanName, typ string, task internal.Task) error {
+	ctx, span := otel.Tracer(otelName).Start(ctx, spanName, trace.WithSpanKind(trace.SpanKindProducer))
+	defer span.End()
+
+	span.SetAttributes(
+		semconv.DBSystemRedis,
+		attribute.KeyValue{
+			Key:   semconv.DBStatementKey,
+			Value: attribute.StringValue("XADD"),
+		},
+	)
+
+	//-
+
+	evt := events.NewTaskEvent(typ, task)
+
+	events.Inject(ctx, &evt)
+
+	b, err := t.codec.Marshal(evt)
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "codec.Marshal")
+	}
+
+	// Trimming exactly is expensive, "~" lets Redis remove whole nodes of the stream instead.
+	res := t.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: Stream,
+		MaxLen: t.maxLen,
+		Approx: true,
+		Values: map[string]interface{}{
+			fieldType:        typ,
+			fieldContentType: t.codec.ContentType(),
+			fieldData:        b,
+		},
+	})
+	if err := res.Err(); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "client.XAdd")
+	}
+
+	return nil
+}
+
+// StreamConsumer consumes the Task events added to the stream as a member of a consumer group, each entry is
+// delivered to only one consumer of the group; that way multiple replicas of the same process can consume the
+// stream concurrently.
+//
+// Entries stay pending until they are acknowledged, the entries pending for longer than minIdle, because the
+// consumer they were delivered to exited or failed to process them, are claimed by any of the consumers and
+// delivered again. Entries already delivered maxDeliveries times are added to DeadLetterStream and acknowledged
+// instead.
+type StreamConsumer struct {
+	client        *redis.Client
+	group         string
+	consumer      string
+	minIdle       time.Duration
+	maxDeliveries int64
+	claimStart    string
+	claimedAt     time.Time
+}
+
+// NewStreamConsumer instantiates the StreamConsumer, consumer must be unique in the group. maxDeliveries is the
+// number of times an entry is delivered before dead-lettering it, 0 delivers it indefinitely.
+func NewStreamConsumer(client *redis.Client, group, consumer string, minIdle time.Duration,
+	maxDeliveries int64,
+) *StreamConsumer {
+	return &StreamConsumer{
+		client:        client,
+		group:         group,
+		consumer:      consumer,
+		minIdle:       minIdle,
+		maxDeliveries: maxDeliveries,
+		claimStart:    "0-0",
+	}
+}
+
+// Init creates the stream and the consumer group if they don't exist, new groups consume the entries
+// already in the stream.
+func (c *StreamConsumer) Init(ctx context.Context) error {
+	err := c.client.XGroupCreateMkStream(ctx, Stream, c.group, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "client.XGroupCreateMkStream")
+	}
+
+	return nil
+}
+
+// Read returns up to count entries, blocking for up to block when there are none. Pending entries idle for
+// longer than minIdle are claimed before reading new ones.
+//
+// Read is not safe for concurrent use.
+func (c *StreamConsumer) Read(ctx context.Context, count int64, block time.Duration) ([]redis.XMessage, error) {
+	if time.Since(c.claimedAt) >= c.minIdle {
+		msgs, err := c.claim(ctx, count)
+		if err != nil {
+			return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "claim")
+		}
+
+		if len(msgs) > 0 {
+			return msgs, nil
+		}
+	}
+
+	res, err := c.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    c.group,
+		Consumer: c.consumer,
+		Streams:  []string{Stream, ">"},
+		Count:    count,
+		Block:    block,
+	}).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "client.XReadGroup")
+	}
+
+	var msgs []redis.XMessage
+
+	for _, stream := range res {
+		msgs = append(msgs, stream.Messages...)
+	}
+
+	return msgs, nil
+}
+
+// Ack acknowledges the entries, they are removed from the pending entries list of the group.
+func (c *StreamConsumer) Ack(ctx context.Context, ids ...string) error {
+	if err := c.client.XAck(ctx, Stream, c.group, ids...).Err(); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "client.XAck")
+	}
+
+	return nil
+}
+
+// claim transfers the ownership of the pending entries idle for longer than minIdle to this consumer, the pending
+// entries list is scanned incrementally: the cursor returned by XAUTOCLAIM is used in the next call.
+//
+// XXX: "client.XAutoClaim" can't be used, it fails parsing the replies of Redis 7 because they include a third
+// element: the IDs of the deleted entries.
+func (c *StreamConsumer) claim(ctx context.Context, count int64) ([]redis.XMessage, error) {
+	res, err := c.client.Do(ctx, "xautoclaim", Stream, c.group, c.consumer,
+		c.minIdle.Milliseconds(), c.claimStart, "count", count).Slice()
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "client.Do XAUTOCLAIM")
+	}
+
+	start, msgs, err := parseAutoClaim(res)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "parseAutoClaim")
+	}
+
+	c.claimStart = start
+
+	// Once the whole list is scanned, claiming again waits for other entries to become idle.
+	if start == "0-0" {
+		c.claimedAt = time.Now()
+	}
+
+	if c.maxDeliveries <= 0 || len(msgs) == 0 {
+		return msgs, nil
+	}
+
+	deliveries, err := c.deliveries(ctx, msgs)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "deliveries")
+	}
+
+	claimed := make([]redis.XMessage, 0, len(msgs))
+
+	for i, msg := range msgs {
+		// The delivery count includes this one, XAUTOCLAIM increments it.
+		if deliveries[i] <= c.maxDeliveries {
+			claimed = append(claimed, msg)
+
+			continue
+		}
+
+		cause := internal.NewErrorf(internal.ErrorCodeUnknown, "delivered %d times", deliveries[i]-1)
+
+		if err := c.deadLetter(ctx, msg, cause); err != nil {
+			return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "deadLetter")
+		}
+
+		if err := c.Ack(ctx, msg.ID); err != nil {
+			return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "Ack")
+		}
+	}
+
+	return claimed, nil
+}
+
+// deliveries returns the number of times each of the claimed entries was delivered, using XPENDING.
+func (c *StreamConsumer) deliveries(ctx context.Context, msgs []redis.XMessage) ([]int64, error) {
+	pipe := c.client.Pipeline()
+
+	cmds := make([]*redis.XPendingExtCmd, len(msgs))
+
+	for i, msg := range msgs {
+		cmds[i] = pipe.XPendingExt(ctx, &redis.XPendingExtArgs{
+			Stream:   Stream,
+			Group:    c.group,
+			Start:    msg.ID,
+			End:      msg.ID,
+			Count:    1,
+			Consumer: c.consumer,
+		})
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "pipe.Exec")
+	}
+
+	res := make([]int64, len(msgs))
+
+	for i, cmd := range cmds {
+		if pending := cmd.Val(); len(pending) > 0 {
+			res[i] = pending[0].RetryCount
+		}
+	}
+
+	return res, nil
+}
+
+// deadLetter adds the entry to DeadLetterStream, including the error in its fields.
+func (c *StreamConsumer) deadLetter(ctx context.Context, msg redis.XMessage, cause error) error {
+	values := make(map[string]interface{}, len(msg.Values)+1)
+
+	for key, val := range msg.Values {
+		values[key] = val
+	}
+
+	values[fieldError] = cause.Error()
+
+	if err := c.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: DeadLetterStream,
+		Values: values,
+	}).Err(); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "client.XAdd")
+	}
+
+	return nil
+}
+
+// parseAutoClaim parses the XAUTOCLAIM reply: the cursor and the claimed entries, entries deleted from the stream
+// are nil in Redis 6.2.
+func parseAutoClaim(res []interface{}) (string, []redis.XMessage, error) {
+	if len(res) < 2 {
+		return "", nil, internal.NewErrorf(internal.ErrorCodeUnknown, "invalid reply length %d", len(res))
+	}
+
+	start, _ := res[0].(string)
+	entries, _ := res[1].([]interface{})
+
+	msgs := make([]redis.XMessage, 0, len(entries))
+
+	for _, entry := range entries {
+		fields, ok := entry.([]interface{})
+		if !ok || len(fields) != 2 {
+			continue
+		}
+
+		id, _ := fields[0].(string)
+		values, _ := fields[1].([]interface{})
+
+		msg := redis.XMessage{
+			ID:     id,
+			Values: make(map[string]interface{}, len(values)/2),
+		}
+
+		for i := 0; i+1 < len(values); i += 2 {
+			key, _ := values[i].(string)
+			msg.Values[key] = values[i+1]
+		}
+
+		msgs = append(msgs, msg)
+	}
+
+	return start, msgs, nil
+}
+
+// DecodeMessage decodes the event in the stream entry.
+func DecodeMessage(msg redis.XMessage) (events.Event, error) {
+	typ, _ := msg.Values[fieldType].(string)
+	contentType, _ := msg.Values[fieldContentType].(string)
+	data, _ := msg.Values[fieldData].(string)
+
+	evt, err := events.Decode(typ, contentType, []byte(data))
+	if err != nil {
+		return events.Event{}, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "events.Decode")
+	}
+
+	return evt, nil
+}
@@ -0,0 +1,106 @@
+package redis_test
+
+import (
+	"testing"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/go-cmp/cmp"
+
+	iredis "github.com/MarioCarrion/todo-api/internal/redis"
+)
+
+func TestParseAutoClaim(t *testing.T) {
+	t.Parallel()
+
+	type output struct {
+		start   string
+		msgs    []redis.XMessage
+		withErr bool
+	}
+
+	entry := []interface{}{
+		"1-0",
+		[]interface{}{"type", "tasks.event.created", "content_type", "application/json", "data", "{}"},
+	}
+
+	claimed := redis.XMessage{
+		ID: "1-0",
+		Values: map[string]interface{}{
+			"type":         "tasks.event.created",
+			"content_type": "application/json",
+			"data":         "{}",
+		},
+	}
+
+	tests := []struct {
+		name   string
+		input  []interface{}
+		output output
+	}{
+		{
+			"OK: Redis 6.2",
+			[]interface{}{"2-0", []interface{}{entry}},
+			output{
+				start: "2-0",
+				msgs:  []redis.XMessage{claimed},
+			},
+		},
+		{
+			"OK: Redis 6.2 deleted entry",
+			[]interface{}{"0-0", []interface{}{nil, entry}},
+			output{
+				start: "0-0",
+				msgs:  []redis.XMessage{claimed},
+			},
+		},
+		{
+			"OK: Redis 6.2 deleted entry fields",
+			[]interface{}{"0-0", []interface{}{[]interface{}{"1-0", nil}}},
+			output{
+				start: "0-0",
+				msgs:  []redis.XMessage{{ID: "1-0", Values: map[string]interface{}{}}},
+			},
+		},
+		{
+			"OK: Redis 7",
+			[]interface{}{"0-0", []interface{}{entry}, []interface{}{}},
+			output{
+				start: "0-0",
+				msgs:  []redis.XMessage{claimed},
+			},
+		},
+		{
+			"OK: Redis 7 deleted entries",
+			[]interface{}{"0-0", []interface{}{}, []interface{}{"3-0", "4-0"}},
+			output{
+				start: "0-0",
+				msgs:  []redis.XMessage{},
+			},
+		},
+		{
+			"ERR: invalid reply",
+			[]interface{}{"0-0"},
+			output{
+				withErr: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			start, msgs, err := iredis.ParseAutoClaim(tt.input)
+
+			actual := output{
+				start:   start,
+				msgs:    msgs,
+				withErr: err != nil,
+			}
+
+			if !cmp.Equal(tt.output, actual, cmp.AllowUnexported(output{})) {
+				t.Fatalf("expected result does not match: %s", cmp.Diff(tt.output, actual, cmp.AllowUnexported(output{})))
+			}
+		})
+	}
+}
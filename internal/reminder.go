@@ -0,0 +1,25 @@
+package internal
+
+import (
+	"time"
+)
+
+// ReminderType indicates why a Reminder is sent.
+type ReminderType string
+
+const (
+	// ReminderDueSoon indicates the Task is due within one of its CreateParams.Reminders.
+	ReminderDueSoon ReminderType = "due_soon"
+
+	// ReminderOverdue indicates the due date of the Task passed before it was done.
+	ReminderOverdue ReminderType = "overdue"
+)
+
+// Reminder is a notification about a Task approaching, or past, its due date. Reminders are sent once per due
+// date, changing the due date of the Task sends them again.
+type Reminder struct {
+	Type ReminderType
+	Task Task
+	// Before is the reminder triggering the notification, zero for ReminderOverdue.
+	Before time.Duration
+}
@@ -0,0 +1,169 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/ical"
+)
+
+// maxCalendarSize is the maximum size of the imported calendar files.
+const maxCalendarSize = 1 << 20
+
+//counterfeiter:generate -o resttesting/calendar_service.gen.go . CalendarService
+
+// CalendarService ...
+type CalendarService interface {
+	Import(ctx context.Context, params []internal.ImportParams) ([]internal.Task, error)
+	Tasks(ctx context.Context, fn func(internal.Task) error) error
+}
+
+// CalendarHandler ...
+type CalendarHandler struct {
+	svc   CalendarService
+	token string
+}
+
+// NewCalendarHandler instantiates the handler, token is the secret included in the calendar feed URL because
+// calendar applications can't authenticate; the feed is disabled when it's empty.
+func NewCalendarHandler(svc CalendarService, token string) *CalendarHandler {
+	return &CalendarHandler{
+		svc:   svc,
+		token: token,
+	}
+}
+
+// Register connects the handlers to the router.
+func (h *CalendarHandler) Register(r *chi.Mux) {
+	r.Get("/calendar/{token}.ics", h.feed)
+	r.Post("/import/ics", h.importICS)
+}
+
+func (h *CalendarHandler) feed(w http.ResponseWriter, r *http.Request) {
+	// NOTE: Safe to ignore error, because it's always defined.
+	token := chi.URLParam(r, "token")
+
+	if h.token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) != 1 {
+		renderErrorResponse(w, r, "calendar not found",
+			internal.NewErrorf(internal.ErrorCodeNotFound, "invalid token"))
+
+		return
+	}
+
+	component := ical.ComponentTodo
+
+	if val := r.URL.Query().Get("component"); val != "" {
+		component = ical.Component(strings.ToUpper(val))
+
+		if err := component.Validate(); err != nil {
+			renderErrorResponse(w, r, "invalid request", err)
+
+			return
+		}
+	}
+
+	// The calendar is encoded before responding so failures are rendered as errors.
+	var buf bytes.Buffer
+
+	enc := ical.NewEncoder(&buf, "Tasks", component, time.Now())
+
+	if err := h.svc.Tasks(r.Context(), enc.Encode); err != nil {
+		renderErrorResponse(w, r, "calendar failed", err)
+
+		return
+	}
+
+	if err := enc.Close(); err != nil {
+		renderErrorResponse(w, r, "calendar failed", err)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", ical.ContentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf.Bytes())
+}
+
+// ImportCalendarResponse defines the response returned back after importing a calendar.
+type ImportCalendarResponse struct {
+	Tasks []Task `json:"tasks"`
+}
+
+// importICS creates the tasks included in the calendar file, uploaded as the request body or as the "file" field
+// of a multipart form. Entries without priority use the "priority" query parameter.
+func (h *CalendarHandler) importICS(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	priority := priorityNone
+
+	if val := r.URL.Query().Get("priority"); val != "" {
+		priority = Priority(val)
+
+		if err := priority.Validate(); err != nil {
+			renderErrorResponse(w, r, "invalid request", err)
+
+			return
+		}
+	}
+
+	var body io.Reader = http.MaxBytesReader(w, r.Body, maxCalendarSize)
+
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "multipart/form-data" {
+		r.Body = http.MaxBytesReader(w, r.Body, maxCalendarSize)
+
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			renderErrorResponse(w, r, "invalid request",
+				internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "r.FormFile"))
+
+			return
+		}
+
+		defer file.Close()
+
+		body = file
+	}
+
+	params, err := ical.Decode(body)
+	if err != nil {
+		renderErrorResponse(w, r, "invalid request", err)
+
+		return
+	}
+
+	for i := range params {
+		if params[i].Priority == internal.PriorityNone {
+			params[i].Priority = priority.Convert()
+		}
+	}
+
+	res, err := h.svc.Import(r.Context(), params)
+	if err != nil {
+		renderErrorResponse(w, r, "import failed", err)
+
+		return
+	}
+
+	tasks := make([]Task, len(res))
+
+	for i, task := range res {
+		tasks[i] = Task{
+			ID:          task.ID,
+			Description: task.Description,
+			Priority:    NewPriority(task.Priority),
+			Dates:       NewDates(task.Dates),
+			IsDone:      task.IsDone,
+		}
+	}
+
+	renderResponse(w, r, &ImportCalendarResponse{Tasks: tasks}, http.StatusCreated)
+}
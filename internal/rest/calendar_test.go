@@ -0,0 +1,292 @@
+package rest_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/rest"
+	"github.com/MarioCarrion/todo-api/internal/rest/resttesting"
+)
+
+const calendarToken = "0123456789abcdef"
+
+func TestCalendar_Feed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		token          string
+		path           string
+		tasksErr       error
+		expectedStatus int
+		expected       []string
+	}{
+		{
+			"OK: todo",
+			calendarToken,
+			"/calendar/" + calendarToken + ".ics",
+			nil,
+			http.StatusOK,
+			[]string{"BEGIN:VCALENDAR", "BEGIN:VTODO", "UID:1@todo-api", "DUE:20261003T173000Z", "PRIORITY:1"},
+		},
+		{
+			"OK: event",
+			calendarToken,
+			"/calendar/" + calendarToken + ".ics?component=vevent",
+			nil,
+			http.StatusOK,
+			[]string{"BEGIN:VEVENT", "DTSTART:20261003T173000Z"},
+		},
+		{
+			"ERR: 400",
+			calendarToken,
+			"/calendar/" + calendarToken + ".ics?component=vjournal",
+			nil,
+			http.StatusBadRequest,
+			nil,
+		},
+		{
+			"ERR: 404 token",
+			calendarToken,
+			"/calendar/fedcba9876543210.ics",
+			nil,
+			http.StatusNotFound,
+			nil,
+		},
+		{
+			"ERR: 404 disabled",
+			"",
+			"/calendar/.ics",
+			nil,
+			http.StatusNotFound,
+			nil,
+		},
+		{
+			"ERR: 500",
+			calendarToken,
+			"/calendar/" + calendarToken + ".ics",
+			errors.New("service error"),
+			http.StatusInternalServerError,
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			router := newRouter()
+
+			svc := &resttesting.FakeCalendarService{}
+			svc.TasksStub = func(_ context.Context, fn func(internal.Task) error) error {
+				if tt.tasksErr != nil {
+					return tt.tasksErr
+				}
+
+				return fn(internal.Task{
+					ID:          "1",
+					Description: "Pay rent",
+					Priority:    internal.PriorityHigh,
+					Dates:       internal.Dates{Due: time.Date(2026, 10, 3, 17, 30, 0, 0, time.UTC)},
+				})
+			}
+
+			rest.NewCalendarHandler(svc, tt.token).Register(router)
+
+			//-
+
+			res := doRequest(router, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			defer res.Body.Close()
+
+			//-
+
+			if tt.expectedStatus != res.StatusCode {
+				t.Fatalf("expected code %d, actual %d", tt.expectedStatus, res.StatusCode)
+			}
+
+			if tt.expected == nil {
+				return
+			}
+
+			if actual := res.Header.Get("Content-Type"); actual != "text/calendar; charset=utf-8" {
+				t.Fatalf("expected calendar content type, got %s", actual)
+			}
+
+			body, _ := io.ReadAll(res.Body)
+
+			for _, line := range tt.expected {
+				if !strings.Contains(string(body), line+"\r\n") {
+					t.Fatalf("expected %q in calendar, got %s", line, body)
+				}
+			}
+		})
+	}
+}
+
+func TestCalendar_Import(t *testing.T) {
+	t.Parallel()
+
+	calendar := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VTODO",
+		"SUMMARY:Pay rent",
+		"DUE:20261003T173000Z",
+		"END:VTODO",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	multipartBody := func() (string, []byte) {
+		var buf bytes.Buffer
+
+		mw := multipart.NewWriter(&buf)
+		w, _ := mw.CreateFormFile("file", "tasks.ics")
+		_, _ = w.Write([]byte(calendar))
+		_ = mw.Close()
+
+		return mw.FormDataContentType(), buf.Bytes()
+	}
+
+	type output struct {
+		expectedStatus int
+		expected       interface{}
+		target         interface{}
+	}
+
+	tests := []struct {
+		name        string
+		setup       func(*resttesting.FakeCalendarService)
+		path        string
+		contentType string
+		input       []byte
+		output      output
+	}{
+		{
+			"OK: 201",
+			func(s *resttesting.FakeCalendarService) {
+				s.ImportReturns([]internal.Task{{ID: "1", Description: "Pay rent", Priority: internal.PriorityLow}}, nil)
+			},
+			"/import/ics?priority=low",
+			"text/calendar",
+			[]byte(calendar),
+			output{
+				http.StatusCreated,
+				&rest.ImportCalendarResponse{
+					Tasks: []rest.Task{{ID: "1", Description: "Pay rent", Priority: "low"}},
+				},
+				&rest.ImportCalendarResponse{},
+			},
+		},
+		{
+			"OK: 201 multipart",
+			func(s *resttesting.FakeCalendarService) {
+				s.ImportReturns([]internal.Task{{ID: "1", Description: "Pay rent", Priority: internal.PriorityLow}}, nil)
+			},
+			"/import/ics?priority=low",
+			"",
+			nil,
+			output{
+				http.StatusCreated,
+				&rest.ImportCalendarResponse{
+					Tasks: []rest.Task{{ID: "1", Description: "Pay rent", Priority: "low"}},
+				},
+				&rest.ImportCalendarResponse{},
+			},
+		},
+		{
+			"ERR: 400 calendar",
+			func(s *resttesting.FakeCalendarService) {},
+			"/import/ics",
+			"text/calendar",
+			[]byte("BEGIN:VTODO\r\nEND:VTODO\r\n"),
+			output{
+				http.StatusBadRequest,
+				&rest.ErrorResponse{
+					Error: "invalid request",
+				},
+				&rest.ErrorResponse{},
+			},
+		},
+		{
+			"ERR: 400 priority",
+			func(s *resttesting.FakeCalendarService) {},
+			"/import/ics?priority=urgent",
+			"text/calendar",
+			[]byte(calendar),
+			output{
+				http.StatusBadRequest,
+				&rest.ErrorResponse{
+					Error: "invalid request",
+				},
+				&rest.ErrorResponse{},
+			},
+		},
+		{
+			"ERR: 500",
+			func(s *resttesting.FakeCalendarService) {
+				s.ImportReturns(nil, errors.New("service error"))
+			},
+			"/import/ics?priority=low",
+			"text/calendar",
+			[]byte(calendar),
+			output{
+				http.StatusInternalServerError,
+				&rest.ErrorResponse{
+					Error: "internal error",
+				},
+				&rest.ErrorResponse{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			router := newRouter()
+
+			svc := &resttesting.FakeCalendarService{}
+			tt.setup(svc)
+
+			rest.NewCalendarHandler(svc, calendarToken).Register(router)
+
+			//-
+
+			contentType, input := tt.contentType, tt.input
+			if input == nil {
+				contentType, input = multipartBody()
+			}
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, bytes.NewReader(input))
+			req.Header.Set("Content-Type", contentType)
+
+			res := doRequest(router, req)
+
+			//-
+
+			assertResponse(t, res, test{tt.output.expected, tt.output.target})
+
+			if tt.output.expectedStatus != res.StatusCode {
+				t.Fatalf("expected code %d, actual %d", tt.output.expectedStatus, res.StatusCode)
+			}
+
+			if svc.ImportCallCount() == 1 {
+				if _, params := svc.ImportArgsForCall(0); len(params) != 1 || params[0].Priority != internal.PriorityLow {
+					t.Fatalf("expected default priority, got %v", params)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package rest
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/MarioCarrion/todo-api/internal"
+)
+
+// Duration is a period of time encoded using its string representation, for example "24h" or "1h30m".
+type Duration time.Duration
+
+// MarshalJSON ...
+func (d Duration) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal(time.Duration(d).String())
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "json.Marshal")
+	}
+
+	return b, nil
+}
+
+// UnmarshalJSON ...
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "json.Unmarshal")
+	}
+
+	val, err := time.ParseDuration(str)
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "time.ParseDuration")
+	}
+
+	*d = Duration(val)
+
+	return nil
+}
+
+// convertDurations returns the durations using the internal representation.
+func convertDurations(durations []Duration) []time.Duration {
+	if len(durations) == 0 {
+		return nil
+	}
+
+	res := make([]time.Duration, len(durations))
+
+	for i, d := range durations {
+		res[i] = time.Duration(d)
+	}
+
+	return res
+}
@@ -0,0 +1,79 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/MarioCarrion/todo-api/internal/rest"
+)
+
+func TestDuration_MarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	actualRes, actualErr := json.Marshal(rest.Duration(90 * time.Minute))
+	if actualErr != nil {
+		t.Fatalf("expected no error, got %s", actualErr)
+	}
+
+	if expected := []byte(`"1h30m0s"`); !cmp.Equal(expected, actualRes) {
+		t.Fatalf("expected output do not match\n%s", cmp.Diff(expected, actualRes))
+	}
+}
+
+func TestDuration_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	type output struct {
+		res     rest.Duration
+		withErr bool
+	}
+
+	tests := []struct {
+		name   string
+		input  []byte
+		output output
+	}{
+		{
+			"OK",
+			[]byte(`"24h"`),
+			output{
+				res: rest.Duration(24 * time.Hour),
+			},
+		},
+		{
+			"ERR: unit",
+			[]byte(`"1d"`),
+			output{
+				withErr: true,
+			},
+		},
+		{
+			"ERR: number",
+			[]byte(`3600`),
+			output{
+				withErr: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var actualRes rest.Duration
+
+			actualErr := json.Unmarshal(tt.input, &actualRes)
+
+			if (actualErr != nil) != tt.output.withErr {
+				t.Fatalf("expected error %t, actual %s", tt.output.withErr, actualErr)
+			}
+
+			if !cmp.Equal(tt.output.res, actualRes) {
+				t.Fatalf("expected output do not match\n%s", cmp.Diff(tt.output.res, actualRes))
+			}
+		})
+	}
+}
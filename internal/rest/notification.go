@@ -0,0 +1,173 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MarioCarrion/todo-api/internal"
+)
+
+//counterfeiter:generate -o resttesting/notification_service.gen.go . NotificationService
+
+// NotificationService ...
+type NotificationService interface {
+	Delete(ctx context.Context, email string) error
+	List(ctx context.Context) ([]internal.NotificationPreference, error)
+	Preference(ctx context.Context, email string) (internal.NotificationPreference, error)
+	Save(ctx context.Context, params internal.SaveNotificationPreferenceParams) (internal.NotificationPreference, error)
+}
+
+// NotificationHandler ...
+type NotificationHandler struct {
+	svc NotificationService
+}
+
+// NewNotificationHandler ...
+func NewNotificationHandler(svc NotificationService) *NotificationHandler {
+	return &NotificationHandler{
+		svc: svc,
+	}
+}
+
+// Register connects the handlers to the router.
+func (h *NotificationHandler) Register(r *chi.Mux) {
+	r.Get("/notifications/preferences", h.list)
+	r.Put("/notifications/preferences/{email}", h.save)
+	r.Get("/notifications/preferences/{email}", h.preference)
+	r.Delete("/notifications/preferences/{email}", h.delete)
+}
+
+// NotificationPreference indicates the task events notified by email to a user.
+//
+//nolint:tagliatelle
+type NotificationPreference struct {
+	Email      string    `json:"email"`
+	EventTypes []string  `json:"event_types"`
+	Digest     bool      `json:"digest"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// SaveNotificationPreferencesRequest defines the request used for creating, or replacing, notification
+// preferences.
+//
+//nolint:tagliatelle
+type SaveNotificationPreferencesRequest struct {
+	EventTypes []string `json:"event_types"`
+	Digest     bool     `json:"digest"`
+}
+
+// ReadNotificationPreferencesResponse defines the response returned back after saving or searching one
+// notification preference.
+type ReadNotificationPreferencesResponse struct {
+	Preference NotificationPreference `json:"preference"`
+}
+
+func (h *NotificationHandler) save(w http.ResponseWriter, r *http.Request) {
+	var req SaveNotificationPreferencesRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		renderErrorResponse(w, r, "invalid request",
+			internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "json decoder"))
+
+		return
+	}
+
+	defer r.Body.Close()
+
+	// NOTE: Safe to ignore error, because it's always defined.
+	email := chi.URLParam(r, "email")
+
+	preference, err := h.svc.Save(r.Context(), internal.SaveNotificationPreferenceParams{
+		Email:      email,
+		EventTypes: req.EventTypes,
+		Digest:     req.Digest,
+	})
+	if err != nil {
+		renderErrorResponse(w, r, "save failed", err)
+
+		return
+	}
+
+	renderResponse(w, r,
+		&ReadNotificationPreferencesResponse{
+			Preference: newNotificationPreference(preference),
+		},
+		http.StatusOK)
+}
+
+func (h *NotificationHandler) delete(w http.ResponseWriter, r *http.Request) {
+	// NOTE: Safe to ignore error, because it's always defined.
+	email := chi.URLParam(r, "email")
+
+	if err := h.svc.Delete(r.Context(), email); err != nil {
+		renderErrorResponse(w, r, "delete failed", err)
+
+		return
+	}
+
+	renderResponse(w, r, struct{}{}, http.StatusOK)
+}
+
+// ListNotificationPreferencesResponse defines the response returned back after listing notification preferences.
+type ListNotificationPreferencesResponse struct {
+	Preferences []NotificationPreference `json:"preferences"`
+}
+
+func (h *NotificationHandler) list(w http.ResponseWriter, r *http.Request) {
+	res, err := h.svc.List(r.Context())
+	if err != nil {
+		renderErrorResponse(w, r, "list failed", err)
+
+		return
+	}
+
+	preferences := make([]NotificationPreference, len(res))
+
+	for i, preference := range res {
+		preferences[i] = newNotificationPreference(preference)
+	}
+
+	renderResponse(w, r,
+		&ListNotificationPreferencesResponse{
+			Preferences: preferences,
+		},
+		http.StatusOK)
+}
+
+func (h *NotificationHandler) preference(w http.ResponseWriter, r *http.Request) {
+	// NOTE: Safe to ignore error, because it's always defined.
+	email := chi.URLParam(r, "email")
+
+	preference, err := h.svc.Preference(r.Context(), email)
+	if err != nil {
+		renderErrorResponse(w, r, "find failed", err)
+
+		return
+	}
+
+	renderResponse(w, r,
+		&ReadNotificationPreferencesResponse{
+			Preference: newNotificationPreference(preference),
+		},
+		http.StatusOK)
+}
+
+func newNotificationPreference(preference internal.NotificationPreference) NotificationPreference {
+	eventTypes := preference.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+
+	return NotificationPreference{
+		Email:      preference.Email,
+		EventTypes: eventTypes,
+		Digest:     preference.Digest,
+		CreatedAt:  preference.CreatedAt,
+		UpdatedAt:  preference.UpdatedAt,
+	}
+}
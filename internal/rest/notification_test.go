@@ -0,0 +1,146 @@
+package rest_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/rest"
+	"github.com/MarioCarrion/todo-api/internal/rest/resttesting"
+)
+
+func TestNotificationPreferences_Put(t *testing.T) {
+	t.Parallel()
+
+	type output struct {
+		expectedStatus int
+		expected       interface{}
+		target         interface{}
+	}
+
+	tests := []struct {
+		name   string
+		setup  func(*resttesting.FakeNotificationService)
+		input  []byte
+		output output
+	}{
+		{
+			"OK: 200",
+			func(s *resttesting.FakeNotificationService) {
+				s.SaveReturns(
+					internal.NotificationPreference{
+						Email:  "user@example.com",
+						Digest: true,
+					},
+					nil)
+			},
+			func() []byte {
+				b, _ := json.Marshal(&rest.SaveNotificationPreferencesRequest{
+					Digest: true,
+				})
+
+				return b
+			}(),
+			output{
+				http.StatusOK,
+				&rest.ReadNotificationPreferencesResponse{
+					Preference: rest.NotificationPreference{
+						Email:      "user@example.com",
+						EventTypes: []string{},
+						Digest:     true,
+					},
+				},
+				&rest.ReadNotificationPreferencesResponse{},
+			},
+		},
+		{
+			"ERR: 400",
+			func(s *resttesting.FakeNotificationService) {
+				s.SaveReturns(internal.NotificationPreference{},
+					internal.NewErrorf(internal.ErrorCodeInvalidArgument, "invalid values"))
+			},
+			[]byte(`{}`),
+			output{
+				http.StatusBadRequest,
+				&rest.ErrorResponse{
+					Error: "invalid request",
+				},
+				&rest.ErrorResponse{},
+			},
+		},
+		{
+			"ERR: 500",
+			func(s *resttesting.FakeNotificationService) {
+				s.SaveReturns(internal.NotificationPreference{},
+					errors.New("service error"))
+			},
+			[]byte(`{}`),
+			output{
+				http.StatusInternalServerError,
+				&rest.ErrorResponse{
+					Error: "internal error",
+				},
+				&rest.ErrorResponse{},
+			},
+		},
+	}
+
+	//-
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			router := newRouter()
+
+			svc := &resttesting.FakeNotificationService{}
+			tt.setup(svc)
+
+			rest.NewNotificationHandler(svc).Register(router)
+
+			//-
+
+			res := doRequest(router,
+				httptest.NewRequest(http.MethodPut, "/notifications/preferences/user@example.com", bytes.NewReader(tt.input)))
+
+			//-
+
+			assertResponse(t, res, test{tt.output.expected, tt.output.target})
+
+			if tt.output.expectedStatus != res.StatusCode {
+				t.Fatalf("expected code %d, actual %d", tt.output.expectedStatus, res.StatusCode)
+			}
+
+			if svc.SaveCallCount() == 1 {
+				if _, params := svc.SaveArgsForCall(0); params.Email != "user@example.com" {
+					t.Fatalf("expected email from path, got %q", params.Email)
+				}
+			}
+		})
+	}
+}
+
+func TestNotificationPreferences_Get(t *testing.T) {
+	t.Parallel()
+
+	svc := &resttesting.FakeNotificationService{}
+	svc.PreferenceReturns(internal.NotificationPreference{},
+		internal.NewErrorf(internal.ErrorCodeNotFound, "not found"))
+
+	router := newRouter()
+
+	rest.NewNotificationHandler(svc).Register(router)
+
+	res := doRequest(router,
+		httptest.NewRequest(http.MethodGet, "/notifications/preferences/user@example.com", nil))
+
+	assertResponse(t, res, test{&rest.ErrorResponse{Error: "find failed"}, &rest.ErrorResponse{}})
+
+	if res.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected code %d, actual %d", http.StatusNotFound, res.StatusCode)
+	}
+}
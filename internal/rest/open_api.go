@@ -4,9 +4,7 @@ import (
 	"net/http"
 
 	"github.com/getkin/kin-openapi/openapi3"
-
 	"github.com/ghodss/yaml"
-
 	"github.com/go-chi/chi/v5"
 )
 
@@ -53,6 +51,14 @@ func NewOpenAPI3() openapi3.T {
 				WithProperty("due", openapi3.NewStringSchema().
 					WithFormat("date-time").
 					WithNullable())),
+		"NotificationPreference": openapi3.NewSchemaRef("",
+			openapi3.NewObjectSchema().
+				WithProperty("email", openapi3.NewStringSchema()).
+				WithProperty("event_types", openapi3.NewArraySchema().
+					WithItems(openapi3.NewStringSchema())).
+				WithProperty("digest", openapi3.NewBoolSchema()).
+				WithProperty("created_at", openapi3.NewDateTimeSchema()).
+				WithProperty("updated_at", openapi3.NewDateTimeSchema())),
 		"Task": openapi3.NewSchemaRef("",
 			openapi3.NewObjectSchema().
 				WithProperty("id", openapi3.NewUUIDSchema()).
@@ -64,12 +70,29 @@ func NewOpenAPI3() openapi3.T {
 				WithPropertyRef("dates", &openapi3.SchemaRef{
 					Ref: "#/components/schemas/Dates",
 				})),
+		"Webhook": openapi3.NewSchemaRef("",
+			openapi3.NewObjectSchema().
+				WithProperty("id", openapi3.NewUUIDSchema()).
+				WithProperty("url", openapi3.NewStringSchema()).
+				WithProperty("event_types", openapi3.NewArraySchema().
+					WithItems(openapi3.NewStringSchema())).
+				WithProperty("created_at", openapi3.NewDateTimeSchema())),
+		"WebhookDelivery": openapi3.NewSchemaRef("",
+			openapi3.NewObjectSchema().
+				WithProperty("id", openapi3.NewUUIDSchema()).
+				WithProperty("event_id", openapi3.NewStringSchema()).
+				WithProperty("event_type", openapi3.NewStringSchema()).
+				WithProperty("attempt", openapi3.NewInt64Schema()).
+				WithProperty("status_code", openapi3.NewInt64Schema()).
+				WithProperty("error", openapi3.NewStringSchema()).
+				WithProperty("duration_ms", openapi3.NewInt64Schema()).
+				WithProperty("created_at", openapi3.NewDateTimeSchema())),
 	}
 
 	swagger.Components.RequestBodies = openapi3.RequestBodies{
 		"CreateTasksRequest": &openapi3.RequestBodyRef{
 			Value: openapi3.NewRequestBody().
-				WithDescription("Request used for creating a task.").
+				WithDescription(`Request used for creating a task, reminders are times before the due date like "24h".`).
 				WithRequired(true).
 				WithJSONSchema(openapi3.NewSchema().
 					WithProperty("description", openapi3.NewStringSchema().
@@ -79,7 +102,9 @@ func NewOpenAPI3() openapi3.T {
 					}).
 					WithPropertyRef("dates", &openapi3.SchemaRef{
 						Ref: "#/components/schemas/Dates",
-					})),
+					}).
+					WithProperty("reminders", openapi3.NewArraySchema().
+						WithItems(openapi3.NewStringSchema()))),
 		},
 		"UpdateTasksRequest": &openapi3.RequestBodyRef{
 			Value: openapi3.NewRequestBody().
@@ -116,6 +141,56 @@ func NewOpenAPI3() openapi3.T {
 					WithProperty("size", openapi3.NewInt64Schema().
 						WithDefault(10))),
 		},
+		"SaveNotificationPreferencesRequest": &openapi3.RequestBodyRef{
+			Value: openapi3.NewRequestBody().
+				WithDescription("Request used for saving notification preferences, all the events are notified when event_types is empty.").
+				WithRequired(true).
+				WithJSONSchema(openapi3.NewSchema().
+					WithProperty("event_types", openapi3.NewArraySchema().
+						WithItems(openapi3.NewStringSchema().
+							WithPattern(`^tasks\.event\.[a-z_]+$`))).
+					WithProperty("digest", openapi3.NewBoolSchema().
+						WithDefault(false))),
+		},
+		"CreateWebhooksRequest": &openapi3.RequestBodyRef{
+			Value: openapi3.NewRequestBody().
+				WithDescription("Request used for creating a webhook, all the task events are sent when event_types is empty.").
+				WithRequired(true).
+				WithJSONSchema(openapi3.NewSchema().
+					WithProperty("url", openapi3.NewStringSchema().
+						WithMinLength(1).
+						WithMaxLength(2048)).
+					WithProperty("event_types", openapi3.NewArraySchema().
+						WithItems(openapi3.NewStringSchema().
+							WithPattern(`^tasks\.event\.[a-z_]+$`))).
+					WithProperty("secret", openapi3.NewStringSchema().
+						WithMinLength(16).
+						WithMaxLength(256))),
+		},
+		"ImportCalendarRequest": &openapi3.RequestBodyRef{
+			Value: openapi3.NewRequestBody().
+				WithDescription("iCalendar file, up to 1MB, the to-dos and events are imported as tasks.").
+				WithRequired(true).
+				WithContent(openapi3.Content{
+					"text/calendar": openapi3.NewMediaType().
+						WithSchema(openapi3.NewStringSchema()),
+					"multipart/form-data": openapi3.NewMediaType().
+						WithSchema(openapi3.NewObjectSchema().
+							WithProperty("file", openapi3.NewStringSchema().
+								WithFormat("binary"))),
+				}),
+		},
+		"ImportTasksRequest": &openapi3.RequestBodyRef{
+			Value: openapi3.NewRequestBody().
+				WithDescription("CSV or newline delimited JSON file, up to 10MB, using the format of the exported tasks.").
+				WithRequired(true).
+				WithContent(openapi3.Content{
+					"text/csv": openapi3.NewMediaType().
+						WithSchema(openapi3.NewStringSchema()),
+					"application/x-ndjson": openapi3.NewMediaType().
+						WithSchema(openapi3.NewStringSchema()),
+				}),
+		},
 	}
 
 	swagger.Components.Responses = openapi3.Responses{
@@ -155,6 +230,117 @@ func NewOpenAPI3() openapi3.T {
 					}).
 					WithProperty("total", openapi3.NewInt64Schema()))),
 		},
+		"SuggestTasksResponse": &openapi3.ResponseRef{
+			Value: openapi3.NewResponse().
+				WithDescription("Response returned back after suggesting task descriptions.").
+				WithContent(openapi3.NewContentWithJSONSchema(openapi3.NewSchema().
+					WithPropertyRef("suggestions", &openapi3.SchemaRef{
+						Value: &openapi3.Schema{
+							Type: "array",
+							Items: openapi3.NewSchemaRef("",
+								openapi3.NewObjectSchema().
+									WithProperty("id", openapi3.NewUUIDSchema()).
+									WithProperty("description", openapi3.NewStringSchema())),
+						},
+					}))),
+		},
+		"ReadNotificationPreferencesResponse": &openapi3.ResponseRef{
+			Value: openapi3.NewResponse().
+				WithDescription("Response returned back after saving or searching one notification preference.").
+				WithContent(openapi3.NewContentWithJSONSchema(openapi3.NewSchema().
+					WithPropertyRef("preference", &openapi3.SchemaRef{
+						Ref: "#/components/schemas/NotificationPreference",
+					}))),
+		},
+		"ImportCalendarResponse": &openapi3.ResponseRef{
+			Value: openapi3.NewResponse().
+				WithDescription("Response returned back after importing a calendar.").
+				WithContent(openapi3.NewContentWithJSONSchema(openapi3.NewSchema().
+					WithPropertyRef("tasks", &openapi3.SchemaRef{
+						Value: &openapi3.Schema{
+							Type: "array",
+							Items: &openapi3.SchemaRef{
+								Ref: "#/components/schemas/Task",
+							},
+						},
+					}))),
+		},
+		"ImportTasksResponse": &openapi3.ResponseRef{
+			Value: openapi3.NewResponse().
+				WithDescription("Response returned back after importing tasks.").
+				WithContent(openapi3.NewContentWithJSONSchema(openapi3.NewSchema().
+					WithProperty("dry_run", openapi3.NewBoolSchema()).
+					WithProperty("created", openapi3.NewInt64Schema()).
+					WithProperty("updated", openapi3.NewInt64Schema()).
+					WithProperty("unchanged", openapi3.NewInt64Schema()).
+					WithPropertyRef("errors", &openapi3.SchemaRef{
+						Value: &openapi3.Schema{
+							Type: "array",
+							Items: openapi3.NewSchemaRef("",
+								openapi3.NewObjectSchema().
+									WithProperty("row", openapi3.NewIntegerSchema()).
+									WithProperty("external_id", openapi3.NewStringSchema()).
+									WithProperty("error", openapi3.NewStringSchema()).
+									WithProperty("validations", openapi3.NewObjectSchema().
+										WithAdditionalProperties(openapi3.NewStringSchema()))),
+						},
+					}))),
+		},
+		"ListNotificationPreferencesResponse": &openapi3.ResponseRef{
+			Value: openapi3.NewResponse().
+				WithDescription("Response returned back after listing notification preferences.").
+				WithContent(openapi3.NewContentWithJSONSchema(openapi3.NewSchema().
+					WithPropertyRef("preferences", &openapi3.SchemaRef{
+						Value: &openapi3.Schema{
+							Type: "array",
+							Items: &openapi3.SchemaRef{
+								Ref: "#/components/schemas/NotificationPreference",
+							},
+						},
+					}))),
+		},
+		"CreateWebhooksResponse": &openapi3.ResponseRef{
+			Value: openapi3.NewResponse().
+				WithDescription("Response returned back after creating webhooks.").
+				WithContent(openapi3.NewContentWithJSONSchema(openapi3.NewSchema().
+					WithPropertyRef("webhook", &openapi3.SchemaRef{
+						Ref: "#/components/schemas/Webhook",
+					}))),
+		},
+		"ReadWebhooksResponse": &openapi3.ResponseRef{
+			Value: openapi3.NewResponse().
+				WithDescription("Response returned back after searching one webhook.").
+				WithContent(openapi3.NewContentWithJSONSchema(openapi3.NewSchema().
+					WithPropertyRef("webhook", &openapi3.SchemaRef{
+						Ref: "#/components/schemas/Webhook",
+					}))),
+		},
+		"ListWebhooksResponse": &openapi3.ResponseRef{
+			Value: openapi3.NewResponse().
+				WithDescription("Response returned back after listing webhooks.").
+				WithContent(openapi3.NewContentWithJSONSchema(openapi3.NewSchema().
+					WithPropertyRef("webhooks", &openapi3.SchemaRef{
+						Value: &openapi3.Schema{
+							Type: "array",
+							Items: &openapi3.SchemaRef{
+								Ref: "#/components/schemas/Webhook",
+							},
+						},
+					}))),
+		},
+		"ReadWebhookDeliveriesResponse": &openapi3.ResponseRef{
+			Value: openapi3.NewResponse().
+				WithDescription("Response returned back after listing the deliveries of a webhook.").
+				WithContent(openapi3.NewContentWithJSONSchema(openapi3.NewSchema().
+					WithPropertyRef("deliveries", &openapi3.SchemaRef{
+						Value: &openapi3.Schema{
+							Type: "array",
+							Items: &openapi3.SchemaRef{
+								Ref: "#/components/schemas/WebhookDelivery",
+							},
+						},
+					}))),
+		},
 	}
 
 	swagger.Paths = openapi3.Paths{
@@ -264,13 +450,350 @@ func NewOpenAPI3() openapi3.T {
 				},
 			},
 		},
+		"/search/tasks/suggest": &openapi3.PathItem{
+			Get: &openapi3.Operation{
+				OperationID: "SuggestTask",
+				Parameters: []*openapi3.ParameterRef{
+					{
+						Value: openapi3.NewQueryParameter("q").
+							WithRequired(true).
+							WithSchema(openapi3.NewStringSchema().
+								WithMinLength(1).
+								WithMaxLength(100)),
+					},
+					{
+						Value: openapi3.NewQueryParameter("size").
+							WithSchema(openapi3.NewInt64Schema().
+								WithMin(0).
+								WithMax(20).
+								WithDefault(5)),
+					},
+				},
+				Responses: openapi3.Responses{
+					"200": &openapi3.ResponseRef{
+						Ref: "#/components/responses/SuggestTasksResponse",
+					},
+					"400": &openapi3.ResponseRef{
+						Ref: "#/components/responses/ErrorResponse",
+					},
+					"500": &openapi3.ResponseRef{
+						Ref: "#/components/responses/ErrorResponse",
+					},
+				},
+			},
+		},
+		"/calendar/{token}.ics": &openapi3.PathItem{
+			Get: &openapi3.Operation{
+				OperationID: "ReadCalendar",
+				Parameters: []*openapi3.ParameterRef{
+					{
+						Value: openapi3.NewPathParameter("token").
+							WithSchema(openapi3.NewStringSchema()),
+					},
+					{
+						Value: openapi3.NewQueryParameter("component").
+							WithSchema(openapi3.NewStringSchema().
+								WithEnum("vtodo", "vevent").
+								WithDefault("vtodo")),
+					},
+				},
+				Responses: openapi3.Responses{
+					"200": &openapi3.ResponseRef{
+						Value: openapi3.NewResponse().
+							WithDescription("iCalendar feed including the tasks with start or due dates").
+							WithContent(openapi3.NewContentWithSchema(openapi3.NewStringSchema(), []string{"text/calendar"})),
+					},
+					"400": &openapi3.ResponseRef{
+						Ref: "#/components/responses/ErrorResponse",
+					},
+					"404": &openapi3.ResponseRef{
+						Value: openapi3.NewResponse().WithDescription("Calendar not found"),
+					},
+					"500": &openapi3.ResponseRef{
+						Ref: "#/components/responses/ErrorResponse",
+					},
+				},
+			},
+		},
+		"/import/ics": &openapi3.PathItem{
+			Post: &openapi3.Operation{
+				OperationID: "ImportCalendarTasks",
+				Parameters: []*openapi3.ParameterRef{
+					{
+						Value: &openapi3.Parameter{
+							Name:        "priority",
+							In:          openapi3.ParameterInQuery,
+							Description: "Priority of the entries without one",
+							Schema: &openapi3.SchemaRef{
+								Ref: "#/components/schemas/Priority",
+							},
+						},
+					},
+				},
+				RequestBody: &openapi3.RequestBodyRef{
+					Ref: "#/components/requestBodies/ImportCalendarRequest",
+				},
+				Responses: openapi3.Responses{
+					"201": &openapi3.ResponseRef{
+						Ref: "#/components/responses/ImportCalendarResponse",
+					},
+					"400": &openapi3.ResponseRef{
+						Ref: "#/components/responses/ErrorResponse",
+					},
+					"500": &openapi3.ResponseRef{
+						Ref: "#/components/responses/ErrorResponse",
+					},
+				},
+			},
+		},
+		"/export/tasks": &openapi3.PathItem{
+			Get: &openapi3.Operation{
+				OperationID: "ExportTasks",
+				Parameters: []*openapi3.ParameterRef{
+					{
+						Value: openapi3.NewQueryParameter("format").
+							WithSchema(openapi3.NewStringSchema().
+								WithEnum("csv", "ndjson").
+								WithDefault("csv")),
+					},
+				},
+				Responses: openapi3.Responses{
+					"200": &openapi3.ResponseRef{
+						Value: openapi3.NewResponse().
+							WithDescription("All the tasks sorted by id, streamed while being read").
+							WithContent(openapi3.NewContentWithSchema(openapi3.NewStringSchema(),
+								[]string{"text/csv", "application/x-ndjson"})),
+					},
+					"400": &openapi3.ResponseRef{
+						Ref: "#/components/responses/ErrorResponse",
+					},
+					"500": &openapi3.ResponseRef{
+						Ref: "#/components/responses/ErrorResponse",
+					},
+				},
+			},
+		},
+		"/import/tasks": &openapi3.PathItem{
+			Post: &openapi3.Operation{
+				OperationID: "UpsertTasks",
+				Parameters: []*openapi3.ParameterRef{
+					{
+						Value: openapi3.NewQueryParameter("format").
+							WithSchema(openapi3.NewStringSchema().
+								WithEnum("csv", "ndjson").
+								WithDefault("csv")),
+					},
+					{
+						Value: openapi3.NewQueryParameter("dry_run").
+							WithDescription("Validates the tasks and reports the changes without making them").
+							WithSchema(openapi3.NewBoolSchema().
+								WithDefault(false)),
+					},
+				},
+				RequestBody: &openapi3.RequestBodyRef{
+					Ref: "#/components/requestBodies/ImportTasksRequest",
+				},
+				Responses: openapi3.Responses{
+					"200": &openapi3.ResponseRef{
+						Ref: "#/components/responses/ImportTasksResponse",
+					},
+					"400": &openapi3.ResponseRef{
+						Ref: "#/components/responses/ErrorResponse",
+					},
+					"500": &openapi3.ResponseRef{
+						Ref: "#/components/responses/ErrorResponse",
+					},
+				},
+			},
+		},
+		"/notifications/preferences": &openapi3.PathItem{
+			Get: &openapi3.Operation{
+				OperationID: "ListNotificationPreference",
+				Responses: openapi3.Responses{
+					"200": &openapi3.ResponseRef{
+						Ref: "#/components/responses/ListNotificationPreferencesResponse",
+					},
+					"500": &openapi3.ResponseRef{
+						Ref: "#/components/responses/ErrorResponse",
+					},
+				},
+			},
+		},
+		"/notifications/preferences/{email}": &openapi3.PathItem{
+			Delete: &openapi3.Operation{
+				OperationID: "DeleteNotificationPreference",
+				Parameters: []*openapi3.ParameterRef{
+					{
+						Value: openapi3.NewPathParameter("email").
+							WithSchema(openapi3.NewStringSchema()),
+					},
+				},
+				Responses: openapi3.Responses{
+					"200": &openapi3.ResponseRef{
+						Value: openapi3.NewResponse().WithDescription("Notification preference deleted"),
+					},
+					"404": &openapi3.ResponseRef{
+						Value: openapi3.NewResponse().WithDescription("Notification preference not found"),
+					},
+					"500": &openapi3.ResponseRef{
+						Ref: "#/components/responses/ErrorResponse",
+					},
+				},
+			},
+			Get: &openapi3.Operation{
+				OperationID: "ReadNotificationPreference",
+				Parameters: []*openapi3.ParameterRef{
+					{
+						Value: openapi3.NewPathParameter("email").
+							WithSchema(openapi3.NewStringSchema()),
+					},
+				},
+				Responses: openapi3.Responses{
+					"200": &openapi3.ResponseRef{
+						Ref: "#/components/responses/ReadNotificationPreferencesResponse",
+					},
+					"404": &openapi3.ResponseRef{
+						Value: openapi3.NewResponse().WithDescription("Notification preference not found"),
+					},
+					"500": &openapi3.ResponseRef{
+						Ref: "#/components/responses/ErrorResponse",
+					},
+				},
+			},
+			Put: &openapi3.Operation{
+				OperationID: "SaveNotificationPreference",
+				Parameters: []*openapi3.ParameterRef{
+					{
+						Value: openapi3.NewPathParameter("email").
+							WithSchema(openapi3.NewStringSchema()),
+					},
+				},
+				RequestBody: &openapi3.RequestBodyRef{
+					Ref: "#/components/requestBodies/SaveNotificationPreferencesRequest",
+				},
+				Responses: openapi3.Responses{
+					"200": &openapi3.ResponseRef{
+						Ref: "#/components/responses/ReadNotificationPreferencesResponse",
+					},
+					"400": &openapi3.ResponseRef{
+						Ref: "#/components/responses/ErrorResponse",
+					},
+					"500": &openapi3.ResponseRef{
+						Ref: "#/components/responses/ErrorResponse",
+					},
+				},
+			},
+		},
+		"/webhooks": &openapi3.PathItem{
+			Get: &openapi3.Operation{
+				OperationID: "ListWebhook",
+				Responses: openapi3.Responses{
+					"200": &openapi3.ResponseRef{
+						Ref: "#/components/responses/ListWebhooksResponse",
+					},
+					"500": &openapi3.ResponseRef{
+						Ref: "#/components/responses/ErrorResponse",
+					},
+				},
+			},
+			Post: &openapi3.Operation{
+				OperationID: "CreateWebhook",
+				RequestBody: &openapi3.RequestBodyRef{
+					Ref: "#/components/requestBodies/CreateWebhooksRequest",
+				},
+				Responses: openapi3.Responses{
+					"201": &openapi3.ResponseRef{
+						Ref: "#/components/responses/CreateWebhooksResponse",
+					},
+					"400": &openapi3.ResponseRef{
+						Ref: "#/components/responses/ErrorResponse",
+					},
+					"500": &openapi3.ResponseRef{
+						Ref: "#/components/responses/ErrorResponse",
+					},
+				},
+			},
+		},
+		"/webhooks/{webhookId}": &openapi3.PathItem{
+			Delete: &openapi3.Operation{
+				OperationID: "DeleteWebhook",
+				Parameters: []*openapi3.ParameterRef{
+					{
+						Value: openapi3.NewPathParameter("webhookId").
+							WithSchema(openapi3.NewUUIDSchema()),
+					},
+				},
+				Responses: openapi3.Responses{
+					"200": &openapi3.ResponseRef{
+						Value: openapi3.NewResponse().WithDescription("Webhook deleted"),
+					},
+					"404": &openapi3.ResponseRef{
+						Value: openapi3.NewResponse().WithDescription("Webhook not found"),
+					},
+					"500": &openapi3.ResponseRef{
+						Ref: "#/components/responses/ErrorResponse",
+					},
+				},
+			},
+			Get: &openapi3.Operation{
+				OperationID: "ReadWebhook",
+				Parameters: []*openapi3.ParameterRef{
+					{
+						Value: openapi3.NewPathParameter("webhookId").
+							WithSchema(openapi3.NewUUIDSchema()),
+					},
+				},
+				Responses: openapi3.Responses{
+					"200": &openapi3.ResponseRef{
+						Ref: "#/components/responses/ReadWebhooksResponse",
+					},
+					"404": &openapi3.ResponseRef{
+						Value: openapi3.NewResponse().WithDescription("Webhook not found"),
+					},
+					"500": &openapi3.ResponseRef{
+						Ref: "#/components/responses/ErrorResponse",
+					},
+				},
+			},
+		},
+		"/webhooks/{webhookId}/deliveries": &openapi3.PathItem{
+			Get: &openapi3.Operation{
+				OperationID: "ListWebhookDeliveries",
+				Parameters: []*openapi3.ParameterRef{
+					{
+						Value: openapi3.NewPathParameter("webhookId").
+							WithSchema(openapi3.NewUUIDSchema()),
+					},
+					{
+						Value: openapi3.NewQueryParameter("size").
+							WithSchema(openapi3.NewInt64Schema().
+								WithMin(0).
+								WithMax(100).
+								WithDefault(100)),
+					},
+				},
+				Responses: openapi3.Responses{
+					"200": &openapi3.ResponseRef{
+						Ref: "#/components/responses/ReadWebhookDeliveriesResponse",
+					},
+					"400": &openapi3.ResponseRef{
+						Ref: "#/components/responses/ErrorResponse",
+					},
+					"404": &openapi3.ResponseRef{
+						Value: openapi3.NewResponse().WithDescription("Webhook not found"),
+					},
+					"500": &openapi3.ResponseRef{
+						Ref: "#/components/responses/ErrorResponse",
+					},
+				},
+			},
+		},
 	}
 
 	return swagger
 }
 
-
-
+// RegisterOpenAPI serves the OpenAPI 3 document using JSON and YAML.
 func RegisterOpenAPI(router *chi.Mux) {
 	swagger := NewOpenAPI3()
 
@@ -279,14 +802,11 @@ func RegisterOpenAPI(router *chi.Mux) {
 	})
 
 	router.Get("/openapi3.yaml", func(w http.ResponseWriter, _ *http.Request) {
-		w.Header().Set("Content-Type", "application/x-yaml")
-
-	router.GET("/openapi3.yaml", func(r echo.Context) error {
 		data, _ := yaml.Marshal(&swagger)
 
+		w.Header().Set("Content-Type", "application/x-yaml")
+		w.WriteHeader(http.StatusOK)
 
 		_, _ = w.Write(data)
-
-		w.WriteHeader(http.StatusOK)
 	})
 }
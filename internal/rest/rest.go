@@ -4,16 +4,18 @@ import (
 	"errors"
 	"net/http"
 
-
 	"github.com/go-chi/render"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
-	"github.com/labstack/echo/v4"
 	"go.opentelemetry.io/otel"
 
 	"github.com/MarioCarrion/todo-api/internal"
 )
 
-const otelName = "github.com/MarioCarrion/todo-api/internal/rest"
+const (
+	otelName = "github.com/MarioCarrion/todo-api/internal/rest"
+
+	uuidRegEx = `[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`
+)
 
 // ErrorResponse represents a response containing an error message.
 type ErrorResponse struct {
@@ -21,8 +23,6 @@ type ErrorResponse struct {
 	Validations validation.Errors `json:"validations,omitempty"`
 }
 
-
-
 func renderErrorResponse(w http.ResponseWriter, r *http.Request, msg string, err error) {
 	resp := ErrorResponse{Error: msg}
 	status := http.StatusInternalServerError
@@ -51,8 +51,6 @@ func renderErrorResponse(w http.ResponseWriter, r *http.Request, msg string, err
 	}
 
 	if err != nil {
-
-
 		_, span := otel.Tracer(otelName).Start(r.Context(), "renderErrorResponse")
 		defer span.End()
 
@@ -61,8 +59,6 @@ func renderErrorResponse(w http.ResponseWriter, r *http.Request, msg string, err
 
 	// XXX fmt.Printf("Error: %v\n", err)
 
-
-
 	render.Status(r, status)
 	render.JSON(w, r, &resp)
 }
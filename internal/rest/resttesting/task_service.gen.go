@@ -50,6 +50,20 @@ type FakeTaskService struct {
 	deleteReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SuggestStub        func(context.Context, internal.SuggestParams) ([]internal.Suggestion, error)
+	suggestMutex       sync.RWMutex
+	suggestArgsForCall []struct {
+		arg1 context.Context
+		arg2 internal.SuggestParams
+	}
+	suggestReturns struct {
+		result1 []internal.Suggestion
+		result2 error
+	}
+	suggestReturnsOnCall map[int]struct {
+		result1 []internal.Suggestion
+		result2 error
+	}
 	TaskStub        func(context.Context, string) (internal.Task, error)
 	taskMutex       sync.RWMutex
 	taskArgsForCall []struct {
@@ -276,6 +290,71 @@ func (fake *FakeTaskService) DeleteReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeTaskService) Suggest(arg1 context.Context, arg2 internal.SuggestParams) ([]internal.Suggestion, error) {
+	fake.suggestMutex.Lock()
+	ret, specificReturn := fake.suggestReturnsOnCall[len(fake.suggestArgsForCall)]
+	fake.suggestArgsForCall = append(fake.suggestArgsForCall, struct {
+		arg1 context.Context
+		arg2 internal.SuggestParams
+	}{arg1, arg2})
+	stub := fake.SuggestStub
+	fakeReturns := fake.suggestReturns
+	fake.recordInvocation("Suggest", []interface{}{arg1, arg2})
+	fake.suggestMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTaskService) SuggestCallCount() int {
+	fake.suggestMutex.RLock()
+	defer fake.suggestMutex.RUnlock()
+	return len(fake.suggestArgsForCall)
+}
+
+func (fake *FakeTaskService) SuggestCalls(stub func(context.Context, internal.SuggestParams) ([]internal.Suggestion, error)) {
+	fake.suggestMutex.Lock()
+	defer fake.suggestMutex.Unlock()
+	fake.SuggestStub = stub
+}
+
+func (fake *FakeTaskService) SuggestArgsForCall(i int) (context.Context, internal.SuggestParams) {
+	fake.suggestMutex.RLock()
+	defer fake.suggestMutex.RUnlock()
+	argsForCall := fake.suggestArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTaskService) SuggestReturns(result1 []internal.Suggestion, result2 error) {
+	fake.suggestMutex.Lock()
+	defer fake.suggestMutex.Unlock()
+	fake.SuggestStub = nil
+	fake.suggestReturns = struct {
+		result1 []internal.Suggestion
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTaskService) SuggestReturnsOnCall(i int, result1 []internal.Suggestion, result2 error) {
+	fake.suggestMutex.Lock()
+	defer fake.suggestMutex.Unlock()
+	fake.SuggestStub = nil
+	if fake.suggestReturnsOnCall == nil {
+		fake.suggestReturnsOnCall = make(map[int]struct {
+			result1 []internal.Suggestion
+			result2 error
+		})
+	}
+	fake.suggestReturnsOnCall[i] = struct {
+		result1 []internal.Suggestion
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTaskService) Task(arg1 context.Context, arg2 string) (internal.Task, error) {
 	fake.taskMutex.Lock()
 	ret, specificReturn := fake.taskReturnsOnCall[len(fake.taskArgsForCall)]
@@ -416,6 +495,8 @@ func (fake *FakeTaskService) Invocations() map[string][][]interface{} {
 	defer fake.createMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
+	fake.suggestMutex.RLock()
+	defer fake.suggestMutex.RUnlock()
 	fake.taskMutex.RLock()
 	defer fake.taskMutex.RUnlock()
 	fake.updateMutex.RLock()
@@ -2,8 +2,10 @@ package rest
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
-
+	"strconv"
 
 	"github.com/go-chi/chi/v5"
 
@@ -20,6 +22,7 @@ type TaskService interface {
 	Create(ctx context.Context, params internal.CreateParams) (internal.Task, error)
 	Delete(ctx context.Context, id string) error
 	Task(ctx context.Context, id string) (internal.Task, error)
+	Suggest(ctx context.Context, args internal.SuggestParams) ([]internal.Suggestion, error)
 	Update(ctx context.Context, id string, description string, priority internal.Priority, dates internal.Dates, isDone bool) error
 }
 
@@ -36,14 +39,13 @@ func NewTaskHandler(svc TaskService) *TaskHandler {
 }
 
 // Register connects the handlers to the router.
-
-
 func (t *TaskHandler) Register(r *chi.Mux) {
 	r.Post("/tasks", t.create)
 	r.Get(fmt.Sprintf("/tasks/{id:%s}", uuidRegEx), t.task)
 	r.Put(fmt.Sprintf("/tasks/{id:%s}", uuidRegEx), t.update)
 	r.Delete(fmt.Sprintf("/tasks/{id:%s}", uuidRegEx), t.delete)
 	r.Post("/search/tasks", t.search)
+	r.Get("/search/tasks/suggest", t.suggest)
 }
 
 // Task is an activity that needs to be completed within a period of time.
@@ -59,9 +61,10 @@ type Task struct {
 
 // CreateTasksRequest defines the request used for creating tasks.
 type CreateTasksRequest struct {
-	Description string   `json:"description"`
-	Priority    Priority `json:"priority"`
-	Dates       Dates    `json:"dates"`
+	Description string     `json:"description"`
+	Priority    Priority   `json:"priority"`
+	Dates       Dates      `json:"dates"`
+	Reminders   []Duration `json:"reminders"`
 }
 
 // CreateTasksResponse defines the response returned back after creating tasks.
@@ -69,8 +72,7 @@ type CreateTasksResponse struct {
 	Task Task `json:"task"`
 }
 
-
-func (t *TaskHandler) create(router *gin.Context) {
+func (t *TaskHandler) create(w http.ResponseWriter, r *http.Request) {
 	var req CreateTasksRequest
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -80,20 +82,18 @@ func (t *TaskHandler) create(router *gin.Context) {
 		return
 	}
 
-	task, err := t.svc.Create(router, internal.CreateParams{
+	task, err := t.svc.Create(r.Context(), internal.CreateParams{
 		Description: req.Description,
 		Priority:    req.Priority.Convert(),
 		Dates:       req.Dates.Convert(),
+		Reminders:   convertDurations(req.Reminders),
 	})
 	if err != nil {
-
-
 		renderErrorResponse(w, r, "create failed", err)
 
 		return
 	}
 
-
 	renderResponse(w, r,
 		&CreateTasksResponse{
 			Task: Task{
@@ -102,11 +102,10 @@ func (t *TaskHandler) create(router *gin.Context) {
 				Priority:    NewPriority(task.Priority),
 				Dates:       NewDates(task.Dates),
 			},
-		})
+		},
+		http.StatusCreated)
 }
 
-
-
 func (t *TaskHandler) delete(w http.ResponseWriter, r *http.Request) {
 	// NOTE: Safe to ignore error, because it's always defined.
 	id := chi.URLParam(r, "id")
@@ -114,13 +113,9 @@ func (t *TaskHandler) delete(w http.ResponseWriter, r *http.Request) {
 	if err := t.svc.Delete(r.Context(), id); err != nil {
 		renderErrorResponse(w, r, "delete failed", err)
 
-
-	if err := t.svc.Delete(router.Request().Context(), id); err != nil {
-		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "delete failed")
+		return
 	}
 
-
-
 	renderResponse(w, r, struct{}{}, http.StatusOK)
 }
 
@@ -129,21 +124,17 @@ type ReadTasksResponse struct {
 	Task Task `json:"task"`
 }
 
-
-
 func (t *TaskHandler) task(w http.ResponseWriter, r *http.Request) {
 	// NOTE: Safe to ignore error, because it's always defined.
 	id := chi.URLParam(r, "id")
 
-	task, err := t.svc.Task(c, id)
+	task, err := t.svc.Task(r.Context(), id)
 	if err != nil {
-
 		renderErrorResponse(w, r, "find failed", err)
 
 		return
 	}
 
-
 	renderResponse(w, r,
 		&ReadTasksResponse{
 			Task: Task{
@@ -153,7 +144,8 @@ func (t *TaskHandler) task(w http.ResponseWriter, r *http.Request) {
 				Dates:       NewDates(task.Dates),
 				IsDone:      task.IsDone,
 			},
-		})
+		},
+		http.StatusOK)
 }
 
 // UpdateTasksRequest defines the request used for updating a task.
@@ -166,8 +158,7 @@ type UpdateTasksRequest struct {
 	Dates       Dates    `json:"dates"`
 }
 
-
-func (t *TaskHandler) update(router *gin.Context) {
+func (t *TaskHandler) update(w http.ResponseWriter, r *http.Request) {
 	var req UpdateTasksRequest
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -177,20 +168,16 @@ func (t *TaskHandler) update(router *gin.Context) {
 		return
 	}
 
-	id := router.Param("id")
-
 	// NOTE: Safe to ignore error, because it's always defined.
 	id := chi.URLParam(r, "id")
 
-	err := t.svc.Update(router.Request().Context(), id, req.Description, req.Priority.Convert(), req.Dates.Convert(), req.IsDone)
+	err := t.svc.Update(r.Context(), id, req.Description, req.Priority.Convert(), req.Dates.Convert(), req.IsDone)
 	if err != nil {
-
 		renderErrorResponse(w, r, "update failed", err)
 
 		return
 	}
 
-
 	renderResponse(w, r, &struct{}{}, http.StatusOK)
 }
 
@@ -211,8 +198,7 @@ type SearchTasksResponse struct {
 	Total int64  `json:"total"`
 }
 
-
-func (t *TaskHandler) search(router *gin.Context) {
+func (t *TaskHandler) search(w http.ResponseWriter, r *http.Request) {
 	var req SearchTasksRequest
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -229,7 +215,7 @@ func (t *TaskHandler) search(router *gin.Context) {
 		priority = &res
 	}
 
-	res, err := t.svc.By(router, internal.SearchParams{
+	res, err := t.svc.By(r.Context(), internal.SearchParams{
 		Description: req.Description,
 		Priority:    priority,
 		IsDone:      req.IsDone,
@@ -237,7 +223,6 @@ func (t *TaskHandler) search(router *gin.Context) {
 		Size:        req.Size,
 	})
 	if err != nil {
-
 		renderErrorResponse(w, r, "search failed", err)
 
 		return
@@ -252,11 +237,60 @@ func (t *TaskHandler) search(router *gin.Context) {
 		tasks[i].Dates = NewDates(task.Dates)
 	}
 
-
-
 	renderResponse(w, r,
 		&SearchTasksResponse{
 			Tasks: tasks,
 			Total: res.Total,
-		})
+		},
+		http.StatusOK)
+}
+
+// Suggestion defines a task description matching the text typed so far.
+type Suggestion struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+}
+
+// SuggestTasksResponse defines the response returned back after suggesting task descriptions.
+type SuggestTasksResponse struct {
+	Suggestions []Suggestion `json:"suggestions"`
+}
+
+func (t *TaskHandler) suggest(w http.ResponseWriter, r *http.Request) {
+	var size int64
+
+	if val := r.URL.Query().Get("size"); val != "" {
+		res, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			renderErrorResponse(w, r, "invalid request",
+				internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "strconv.ParseInt"))
+
+			return
+		}
+
+		size = res
+	}
+
+	res, err := t.svc.Suggest(r.Context(), internal.SuggestParams{
+		Query: r.URL.Query().Get("q"),
+		Size:  size,
+	})
+	if err != nil {
+		renderErrorResponse(w, r, "suggest failed", err)
+
+		return
+	}
+
+	suggestions := make([]Suggestion, len(res))
+
+	for i, suggestion := range res {
+		suggestions[i].ID = suggestion.ID
+		suggestions[i].Description = suggestion.Description
+	}
+
+	renderResponse(w, r,
+		&SuggestTasksResponse{
+			Suggestions: suggestions,
+		},
+		http.StatusOK)
 }
@@ -6,28 +6,19 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
-	"os"
 	"testing"
 	"time"
 
-
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 
-
 	"github.com/MarioCarrion/todo-api/internal"
 	"github.com/MarioCarrion/todo-api/internal/rest"
 	"github.com/MarioCarrion/todo-api/internal/rest/resttesting"
 )
 
-func TestMain(m *testing.M) {
-	gin.SetMode(gin.TestMode)
-
-	os.Exit(m.Run())
-}
-
 func TestTasks_Delete(t *testing.T) {
 	t.Parallel()
 
@@ -83,7 +74,6 @@ func TestTasks_Delete(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-
 			router := newRouter()
 			svc := &resttesting.FakeTaskService{}
 			tt.setup(svc)
@@ -189,8 +179,6 @@ func TestTasks_Post(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-
-
 			router := newRouter()
 
 			svc := &resttesting.FakeTaskService{}
@@ -288,7 +276,6 @@ func TestTasks_Read(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-
 			router := newRouter()
 			svc := &resttesting.FakeTaskService{}
 			tt.setup(svc)
@@ -311,6 +298,129 @@ func TestTasks_Read(t *testing.T) {
 	}
 }
 
+func TestTasks_Suggest(t *testing.T) {
+	t.Parallel()
+
+	type output struct {
+		expectedStatus int
+		expected       interface{}
+		target         interface{}
+	}
+
+	tests := []struct {
+		name   string
+		setup  func(*resttesting.FakeTaskService)
+		target string
+		query  string
+		output output
+	}{
+		{
+			"OK: 200",
+			func(s *resttesting.FakeTaskService) {
+				s.SuggestReturns(
+					[]internal.Suggestion{
+						{
+							ID:          "a-b-c",
+							Description: "buy milk",
+						},
+					},
+					nil)
+			},
+			"/search/tasks/suggest?q=bu&size=1",
+			"bu",
+			output{
+				http.StatusOK,
+				&rest.SuggestTasksResponse{
+					Suggestions: []rest.Suggestion{
+						{
+							ID:          "a-b-c",
+							Description: "buy milk",
+						},
+					},
+				},
+				&rest.SuggestTasksResponse{},
+			},
+		},
+		{
+			"OK: 200 multiple words",
+			func(s *resttesting.FakeTaskService) {
+				s.SuggestReturns([]internal.Suggestion{}, nil)
+			},
+			"/search/tasks/suggest?q=buy+mi",
+			"buy mi",
+			output{
+				http.StatusOK,
+				&rest.SuggestTasksResponse{
+					Suggestions: []rest.Suggestion{},
+				},
+				&rest.SuggestTasksResponse{},
+			},
+		},
+		{
+			"ERR: 400",
+			func(_ *resttesting.FakeTaskService) {},
+			"/search/tasks/suggest?q=bu&size=x",
+			"",
+			output{
+				http.StatusBadRequest,
+				&rest.ErrorResponse{
+					Error: "invalid request",
+				},
+				&rest.ErrorResponse{},
+			},
+		},
+		{
+			"ERR: 500",
+			func(s *resttesting.FakeTaskService) {
+				s.SuggestReturns(nil, errors.New("service error"))
+			},
+			"/search/tasks/suggest?q=bu",
+			"bu",
+			output{
+				http.StatusInternalServerError,
+				&rest.ErrorResponse{
+					Error: "internal error",
+				},
+				&rest.ErrorResponse{},
+			},
+		},
+	}
+
+	//-
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			router := newRouter()
+			svc := &resttesting.FakeTaskService{}
+			tt.setup(svc)
+
+			rest.NewTaskHandler(svc).Register(router)
+
+			//-
+
+			res := doRequest(router, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			//-
+
+			assertResponse(t, res, test{tt.output.expected, tt.output.target})
+
+			if tt.output.expectedStatus != res.StatusCode {
+				t.Fatalf("expected code %d, actual %d", tt.output.expectedStatus, res.StatusCode)
+			}
+
+			if svc.SuggestCallCount() == 0 {
+				return
+			}
+
+			if _, args := svc.SuggestArgsForCall(0); args.Query != tt.query {
+				t.Fatalf("expected query %q, actual %q", tt.query, args.Query)
+			}
+		})
+	}
+}
+
 func TestTasks_Update(t *testing.T) {
 	t.Parallel()
 
@@ -425,12 +535,7 @@ type test struct {
 	target   interface{}
 }
 
-
-
 func doRequest(router *chi.Mux, req *http.Request) *http.Response {
-	rr := httptest.NewRecorder()
-
-
 	rr := httptest.NewRecorder()
 	router.ServeHTTP(rr, req)
 
@@ -450,7 +555,6 @@ func assertResponse(t *testing.T, res *http.Response, test test) {
 	}
 }
 
-
 func newRouter() *chi.Mux {
 	r := chi.NewRouter()
 	r.Use(render.SetContentType(render.ContentTypeJSON))
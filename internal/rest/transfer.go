@@ -0,0 +1,198 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/transfer"
+)
+
+const (
+	// maxImportSize is the maximum size of the imported files.
+	maxImportSize = 10 << 20
+
+	// exportFlushSize is the number of tasks written to the client at a time when exporting them.
+	exportFlushSize = 100
+)
+
+//counterfeiter:generate -o resttesting/transfer_service.gen.go . TransferService
+
+// TransferService ...
+type TransferService interface {
+	Export(ctx context.Context, fn func(internal.Task) error) error
+	Import(ctx context.Context, rows []internal.ImportRow, dryRun bool) (internal.ImportResults, error)
+}
+
+// TransferHandler ...
+type TransferHandler struct {
+	svc TransferService
+}
+
+// NewTransferHandler ...
+func NewTransferHandler(svc TransferService) *TransferHandler {
+	return &TransferHandler{
+		svc: svc,
+	}
+}
+
+// Register connects the handlers to the router.
+func (h *TransferHandler) Register(r *chi.Mux) {
+	r.Get("/export/tasks", h.export)
+	r.Post("/import/tasks", h.importTasks)
+}
+
+// export streams all the tasks, they are written to the client while being read instead of encoding all of them
+// before responding.
+func (h *TransferHandler) export(w http.ResponseWriter, r *http.Request) {
+	format, err := parseFormat(r)
+	if err != nil {
+		renderErrorResponse(w, r, "invalid request", err)
+
+		return
+	}
+
+	// The status is sent before encoding any task, the encoder writes them to the client once its buffer is full.
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", `attachment; filename="tasks.`+string(format)+`"`)
+	w.WriteHeader(http.StatusOK)
+
+	var (
+		enc   = transfer.NewEncoder(w, format)
+		count int
+	)
+
+	flush := func() error {
+		if err := enc.Flush(); err != nil {
+			return err //nolint: wrapcheck
+		}
+
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+
+		return nil
+	}
+
+	err = h.svc.Export(r.Context(), func(task internal.Task) error {
+		if err := enc.Encode(task); err != nil {
+			return err //nolint: wrapcheck
+		}
+
+		if count++; count%exportFlushSize != 0 {
+			return nil
+		}
+
+		return flush()
+	})
+	if err == nil {
+		err = flush()
+	}
+
+	if err != nil {
+		// The status was already sent, aborting the connection lets clients know the file is incomplete.
+		panic(http.ErrAbortHandler)
+	}
+}
+
+// ImportTasksResponse defines the response returned back after importing tasks.
+type ImportTasksResponse struct {
+	DryRun    bool              `json:"dry_run"`
+	Created   int64             `json:"created"`
+	Updated   int64             `json:"updated"`
+	Unchanged int64             `json:"unchanged"`
+	Errors    []ImportTaskError `json:"errors"`
+}
+
+// ImportTaskError defines the reason why a row was not imported.
+type ImportTaskError struct {
+	Row         int               `json:"row"`
+	ExternalID  string            `json:"external_id,omitempty"`
+	Error       string            `json:"error"`
+	Validations validation.Errors `json:"validations,omitempty"`
+}
+
+// importTasks upserts the tasks included in the request body, the rows with errors are skipped and reported back.
+func (h *TransferHandler) importTasks(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	format, err := parseFormat(r)
+	if err != nil {
+		renderErrorResponse(w, r, "invalid request", err)
+
+		return
+	}
+
+	var dryRun bool
+
+	if val := r.URL.Query().Get("dry_run"); val != "" {
+		res, err := strconv.ParseBool(val)
+		if err != nil {
+			renderErrorResponse(w, r, "invalid request",
+				internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "strconv.ParseBool"))
+
+			return
+		}
+
+		dryRun = res
+	}
+
+	rows, err := transfer.Decode(http.MaxBytesReader(w, r.Body, maxImportSize), format)
+	if err != nil {
+		renderErrorResponse(w, r, "invalid request", err)
+
+		return
+	}
+
+	res, err := h.svc.Import(r.Context(), rows, dryRun)
+	if err != nil {
+		renderErrorResponse(w, r, "import failed", err)
+
+		return
+	}
+
+	resp := ImportTasksResponse{
+		DryRun:    dryRun,
+		Created:   res.Created,
+		Updated:   res.Updated,
+		Unchanged: res.Unchanged,
+		Errors:    make([]ImportTaskError, len(res.Errors)),
+	}
+
+	for i, ierr := range res.Errors {
+		resp.Errors[i] = ImportTaskError{
+			Row:        ierr.Row,
+			ExternalID: ierr.ExternalID,
+			Error:      ierr.Err.Error(),
+		}
+
+		var verrs validation.Errors
+		if errors.As(ierr.Err, &verrs) {
+			resp.Errors[i].Error = "invalid values"
+			resp.Errors[i].Validations = verrs
+		}
+	}
+
+	renderResponse(w, r, &resp, http.StatusOK)
+}
+
+// parseFormat returns the format indicated by the "format" query parameter, CSV is the default one.
+func parseFormat(r *http.Request) (transfer.Format, error) {
+	format := transfer.FormatCSV
+
+	if val := r.URL.Query().Get("format"); val != "" {
+		format = transfer.Format(strings.ToLower(val))
+
+		if err := format.Validate(); err != nil {
+			return "", err //nolint: wrapcheck
+		}
+	}
+
+	return format, nil
+}
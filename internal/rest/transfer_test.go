@@ -0,0 +1,355 @@
+package rest_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/rest"
+	"github.com/MarioCarrion/todo-api/internal/rest/resttesting"
+)
+
+func TestTransfer_Export(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                string
+		path                string
+		size                int
+		expectedStatus      int
+		expectedContentType string
+		expectedLines       int
+	}{
+		{
+			"OK: csv",
+			"/export/tasks",
+			250,
+			http.StatusOK,
+			"text/csv; charset=utf-8",
+			251,
+		},
+		{
+			"OK: ndjson",
+			"/export/tasks?format=NDJSON",
+			2,
+			http.StatusOK,
+			"application/x-ndjson",
+			2,
+		},
+		{
+			"OK: empty",
+			"/export/tasks?format=csv",
+			0,
+			http.StatusOK,
+			"text/csv; charset=utf-8",
+			1,
+		},
+		{
+			"ERR: 400",
+			"/export/tasks?format=xml",
+			0,
+			http.StatusBadRequest,
+			"application/json",
+			1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			router := newRouter()
+
+			svc := &resttesting.FakeTransferService{}
+			svc.ExportStub = func(_ context.Context, fn func(internal.Task) error) error {
+				for i := 0; i < tt.size; i++ {
+					if err := fn(internal.Task{ID: strconv.Itoa(i), Description: "task"}); err != nil {
+						return err
+					}
+				}
+
+				return nil
+			}
+
+			rest.NewTransferHandler(svc).Register(router)
+
+			//-
+
+			res := doRequest(router, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			defer res.Body.Close()
+
+			//-
+
+			if tt.expectedStatus != res.StatusCode {
+				t.Fatalf("expected code %d, actual %d", tt.expectedStatus, res.StatusCode)
+			}
+
+			if actual := res.Header.Get("Content-Type"); !strings.HasPrefix(actual, tt.expectedContentType) {
+				t.Fatalf("expected %s content type, got %s", tt.expectedContentType, actual)
+			}
+
+			body, _ := io.ReadAll(res.Body)
+
+			if actual := strings.Count(string(body), "\n"); actual != tt.expectedLines {
+				t.Fatalf("expected %d lines, got %d", tt.expectedLines, actual)
+			}
+		})
+	}
+}
+
+func TestTransfer_Export_Large(t *testing.T) {
+	t.Parallel()
+
+	router := newRouter()
+
+	svc := &resttesting.FakeTransferService{}
+	svc.ExportStub = func(_ context.Context, fn func(internal.Task) error) error {
+		// The tasks don't fit in the buffer of the encoder, they are written before the first flush.
+		for i := 0; i < 50; i++ {
+			if err := fn(internal.Task{ID: strconv.Itoa(i), Description: strings.Repeat("a", 200)}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	rest.NewTransferHandler(svc).Register(router)
+
+	//-
+
+	res := doRequest(router, httptest.NewRequest(http.MethodGet, "/export/tasks", nil))
+	defer res.Body.Close()
+
+	//-
+
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected code %d, actual %d", http.StatusOK, res.StatusCode)
+	}
+
+	if actual := res.Header.Get("Content-Type"); actual != "text/csv; charset=utf-8" {
+		t.Fatalf("expected csv content type, got %s", actual)
+	}
+
+	if actual := res.Header.Get("Content-Disposition"); actual != `attachment; filename="tasks.csv"` {
+		t.Fatalf("expected attachment, got %s", actual)
+	}
+
+	body, _ := io.ReadAll(res.Body)
+
+	if len(body) <= 4<<10 {
+		t.Fatalf("expected more than 4 KiB, got %d bytes", len(body))
+	}
+
+	if actual := strings.Count(string(body), "\n"); actual != 51 {
+		t.Fatalf("expected 51 lines, got %d", actual)
+	}
+}
+
+func TestTransfer_Export_Aborted(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		size int
+	}{
+		{
+			"ERR: before encoding",
+			0,
+		},
+		{
+			"ERR: after flushing",
+			150,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			router := newRouter()
+
+			svc := &resttesting.FakeTransferService{}
+			svc.ExportStub = func(_ context.Context, fn func(internal.Task) error) error {
+				for i := 0; i < tt.size; i++ {
+					if err := fn(internal.Task{ID: strconv.Itoa(i)}); err != nil {
+						return err
+					}
+				}
+
+				return errors.New("service error")
+			}
+
+			rest.NewTransferHandler(svc).Register(router)
+
+			defer func() {
+				if err, _ := recover().(error); !errors.Is(err, http.ErrAbortHandler) {
+					t.Fatalf("expected aborted response, got %v", err)
+				}
+			}()
+
+			_ = doRequest(router, httptest.NewRequest(http.MethodGet, "/export/tasks", nil))
+
+			t.Fatalf("expected aborted response")
+		})
+	}
+}
+
+func TestTransfer_Import(t *testing.T) {
+	t.Parallel()
+
+	csv := "external_id,description,priority\n1,Pay rent,high\n2,,low\n"
+
+	tests := []struct {
+		name           string
+		setup          func(*resttesting.FakeTransferService)
+		path           string
+		input          string
+		expectedStatus int
+		expectedRows   int
+		expectedDryRun bool
+		expected       string
+	}{
+		{
+			"OK: csv",
+			func(s *resttesting.FakeTransferService) {
+				s.ImportReturns(internal.ImportResults{
+					Created: 1,
+					Errors: []internal.ImportError{
+						{
+							Row:        3,
+							ExternalID: "2",
+							Err:        validation.Errors{"Description": errors.New("cannot be blank")},
+						},
+						{
+							Row: 4,
+							Err: internal.NewErrorf(internal.ErrorCodeInvalidArgument, "expected 3 columns, got 2"),
+						},
+					},
+				}, nil)
+			},
+			"/import/tasks",
+			csv,
+			http.StatusOK,
+			2,
+			false,
+			`{"dry_run":false,"created":1,"updated":0,"unchanged":0,"errors":[` +
+				`{"row":3,"external_id":"2","error":"invalid values","validations":{"Description":"cannot be blank"}},` +
+				`{"row":4,"error":"expected 3 columns, got 2"}]}`,
+		},
+		{
+			"OK: ndjson dry run",
+			func(s *resttesting.FakeTransferService) {
+				s.ImportReturns(internal.ImportResults{Updated: 1}, nil)
+			},
+			"/import/tasks?format=ndjson&dry_run=true",
+			`{"external_id":"1","description":"Pay rent","priority":"high"}`,
+			http.StatusOK,
+			1,
+			true,
+			`{"dry_run":true,"created":0,"updated":1,"unchanged":0,"errors":[]}`,
+		},
+		{
+			"ERR: 400 format",
+			func(s *resttesting.FakeTransferService) {},
+			"/import/tasks?format=xml",
+			csv,
+			http.StatusBadRequest,
+			0,
+			false,
+			`{"error":"invalid request"}`,
+		},
+		{
+			"ERR: 400 dry run",
+			func(s *resttesting.FakeTransferService) {},
+			"/import/tasks?dry_run=maybe",
+			csv,
+			http.StatusBadRequest,
+			0,
+			false,
+			`{"error":"invalid request"}`,
+		},
+		{
+			"ERR: 400 header",
+			func(s *resttesting.FakeTransferService) {},
+			"/import/tasks",
+			"description,notes\nPay rent,x\n",
+			http.StatusBadRequest,
+			0,
+			false,
+			`{"error":"invalid request"}`,
+		},
+		{
+			"ERR: 500",
+			func(s *resttesting.FakeTransferService) {
+				s.ImportReturns(internal.ImportResults{}, errors.New("service error"))
+			},
+			"/import/tasks",
+			csv,
+			http.StatusInternalServerError,
+			2,
+			false,
+			`{"error":"internal error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			router := newRouter()
+
+			svc := &resttesting.FakeTransferService{}
+			tt.setup(svc)
+
+			rest.NewTransferHandler(svc).Register(router)
+
+			//-
+
+			res := doRequest(router, httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.input)))
+			defer res.Body.Close()
+
+			//-
+
+			if tt.expectedStatus != res.StatusCode {
+				t.Fatalf("expected code %d, actual %d", tt.expectedStatus, res.StatusCode)
+			}
+
+			var expected, actual interface{}
+
+			_ = json.Unmarshal([]byte(tt.expected), &expected)
+
+			if err := json.NewDecoder(res.Body).Decode(&actual); err != nil {
+				t.Fatalf("couldn't decode %s", err)
+			}
+
+			if !cmp.Equal(expected, actual) {
+				t.Fatalf("expected results don't match: %s", cmp.Diff(expected, actual))
+			}
+
+			if svc.ImportCallCount() == 0 {
+				if tt.expectedRows != 0 {
+					t.Fatalf("expected import")
+				}
+
+				return
+			}
+
+			_, rows, dryRun := svc.ImportArgsForCall(0)
+			if len(rows) != tt.expectedRows || dryRun != tt.expectedDryRun {
+				t.Fatalf("expected %d rows and dry run %t, got %d and %t", tt.expectedRows, tt.expectedDryRun,
+					len(rows), dryRun)
+			}
+		})
+	}
+}
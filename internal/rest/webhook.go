@@ -0,0 +1,242 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MarioCarrion/todo-api/internal"
+)
+
+//counterfeiter:generate -o resttesting/webhook_service.gen.go . WebhookService
+
+// WebhookService ...
+type WebhookService interface {
+	Create(ctx context.Context, params internal.CreateWebhookParams) (internal.Webhook, error)
+	Delete(ctx context.Context, id string) error
+	Deliveries(ctx context.Context, id string, size int32) ([]internal.WebhookDelivery, error)
+	List(ctx context.Context) ([]internal.Webhook, error)
+	Webhook(ctx context.Context, id string) (internal.Webhook, error)
+}
+
+// WebhookHandler ...
+type WebhookHandler struct {
+	svc WebhookService
+}
+
+// NewWebhookHandler ...
+func NewWebhookHandler(svc WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		svc: svc,
+	}
+}
+
+// Register connects the handlers to the router.
+func (h *WebhookHandler) Register(r *chi.Mux) {
+	r.Post("/webhooks", h.create)
+	r.Get("/webhooks", h.list)
+	r.Get(fmt.Sprintf("/webhooks/{id:%s}", uuidRegEx), h.webhook)
+	r.Delete(fmt.Sprintf("/webhooks/{id:%s}", uuidRegEx), h.delete)
+	r.Get(fmt.Sprintf("/webhooks/{id:%s}/deliveries", uuidRegEx), h.deliveries)
+}
+
+// Webhook is a subscription to task events, the secret used for signing them is never returned.
+//
+//nolint:tagliatelle
+type Webhook struct {
+	ID         string    `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// WebhookDelivery is an attempt to send an event to a webhook.
+//
+//nolint:tagliatelle
+type WebhookDelivery struct {
+	ID         string    `json:"id"`
+	EventID    string    `json:"event_id"`
+	EventType  string    `json:"event_type"`
+	Attempt    int       `json:"attempt"`
+	StatusCode int       `json:"status_code"`
+	Error      string    `json:"error,omitempty"`
+	DurationMs int64     `json:"duration_ms"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// CreateWebhooksRequest defines the request used for creating webhooks.
+//
+//nolint:tagliatelle
+type CreateWebhooksRequest struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Secret     string   `json:"secret"`
+}
+
+// CreateWebhooksResponse defines the response returned back after creating webhooks.
+type CreateWebhooksResponse struct {
+	Webhook Webhook `json:"webhook"`
+}
+
+func (h *WebhookHandler) create(w http.ResponseWriter, r *http.Request) {
+	var req CreateWebhooksRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		renderErrorResponse(w, r, "invalid request",
+			internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "json decoder"))
+
+		return
+	}
+
+	defer r.Body.Close()
+
+	webhook, err := h.svc.Create(r.Context(), internal.CreateWebhookParams{
+		URL:        req.URL,
+		EventTypes: req.EventTypes,
+		Secret:     req.Secret,
+	})
+	if err != nil {
+		renderErrorResponse(w, r, "create failed", err)
+
+		return
+	}
+
+	renderResponse(w, r,
+		&CreateWebhooksResponse{
+			Webhook: newWebhook(webhook),
+		},
+		http.StatusCreated)
+}
+
+func (h *WebhookHandler) delete(w http.ResponseWriter, r *http.Request) {
+	// NOTE: Safe to ignore error, because it's always defined.
+	id := chi.URLParam(r, "id")
+
+	if err := h.svc.Delete(r.Context(), id); err != nil {
+		renderErrorResponse(w, r, "delete failed", err)
+
+		return
+	}
+
+	renderResponse(w, r, struct{}{}, http.StatusOK)
+}
+
+// ReadWebhookDeliveriesResponse defines the response returned back after listing the deliveries of a webhook.
+type ReadWebhookDeliveriesResponse struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+}
+
+func (h *WebhookHandler) deliveries(w http.ResponseWriter, r *http.Request) {
+	// NOTE: Safe to ignore error, because it's always defined.
+	id := chi.URLParam(r, "id")
+
+	var size int64
+
+	if val := r.URL.Query().Get("size"); val != "" {
+		res, err := strconv.ParseInt(val, 10, 32)
+		if err != nil {
+			renderErrorResponse(w, r, "invalid request",
+				internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "strconv.ParseInt"))
+
+			return
+		}
+
+		size = res
+	}
+
+	res, err := h.svc.Deliveries(r.Context(), id, int32(size))
+	if err != nil {
+		renderErrorResponse(w, r, "find failed", err)
+
+		return
+	}
+
+	deliveries := make([]WebhookDelivery, len(res))
+
+	for i, delivery := range res {
+		deliveries[i] = WebhookDelivery{
+			ID:         delivery.ID,
+			EventID:    delivery.EventID,
+			EventType:  delivery.EventType,
+			Attempt:    delivery.Attempt,
+			StatusCode: delivery.StatusCode,
+			Error:      delivery.Error,
+			DurationMs: delivery.Duration.Milliseconds(),
+			CreatedAt:  delivery.CreatedAt,
+		}
+	}
+
+	renderResponse(w, r,
+		&ReadWebhookDeliveriesResponse{
+			Deliveries: deliveries,
+		},
+		http.StatusOK)
+}
+
+// ListWebhooksResponse defines the response returned back after listing webhooks.
+type ListWebhooksResponse struct {
+	Webhooks []Webhook `json:"webhooks"`
+}
+
+func (h *WebhookHandler) list(w http.ResponseWriter, r *http.Request) {
+	res, err := h.svc.List(r.Context())
+	if err != nil {
+		renderErrorResponse(w, r, "list failed", err)
+
+		return
+	}
+
+	webhooks := make([]Webhook, len(res))
+
+	for i, webhook := range res {
+		webhooks[i] = newWebhook(webhook)
+	}
+
+	renderResponse(w, r,
+		&ListWebhooksResponse{
+			Webhooks: webhooks,
+		},
+		http.StatusOK)
+}
+
+// ReadWebhooksResponse defines the response returned back after searching one webhook.
+type ReadWebhooksResponse struct {
+	Webhook Webhook `json:"webhook"`
+}
+
+func (h *WebhookHandler) webhook(w http.ResponseWriter, r *http.Request) {
+	// NOTE: Safe to ignore error, because it's always defined.
+	id := chi.URLParam(r, "id")
+
+	webhook, err := h.svc.Webhook(r.Context(), id)
+	if err != nil {
+		renderErrorResponse(w, r, "find failed", err)
+
+		return
+	}
+
+	renderResponse(w, r,
+		&ReadWebhooksResponse{
+			Webhook: newWebhook(webhook),
+		},
+		http.StatusOK)
+}
+
+func newWebhook(webhook internal.Webhook) Webhook {
+	eventTypes := webhook.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+
+	return Webhook{
+		ID:         webhook.ID,
+		URL:        webhook.URL,
+		EventTypes: eventTypes,
+		CreatedAt:  webhook.CreatedAt,
+	}
+}
@@ -0,0 +1,230 @@
+package rest_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/rest"
+	"github.com/MarioCarrion/todo-api/internal/rest/resttesting"
+)
+
+func TestWebhooks_Post(t *testing.T) {
+	t.Parallel()
+
+	type output struct {
+		expectedStatus int
+		expected       interface{}
+		target         interface{}
+	}
+
+	tests := []struct {
+		name   string
+		setup  func(*resttesting.FakeWebhookService)
+		input  []byte
+		output output
+	}{
+		{
+			"OK: 201",
+			func(s *resttesting.FakeWebhookService) {
+				s.CreateReturns(
+					internal.Webhook{
+						ID:     "1-2-3",
+						URL:    "https://example.com/hooks",
+						Secret: "0123456789abcdef",
+					},
+					nil)
+			},
+			func() []byte {
+				b, _ := json.Marshal(&rest.CreateWebhooksRequest{
+					URL:    "https://example.com/hooks",
+					Secret: "0123456789abcdef",
+				})
+
+				return b
+			}(),
+			output{
+				http.StatusCreated,
+				&rest.CreateWebhooksResponse{
+					Webhook: rest.Webhook{
+						ID:         "1-2-3",
+						URL:        "https://example.com/hooks",
+						EventTypes: []string{},
+					},
+				},
+				&rest.CreateWebhooksResponse{},
+			},
+		},
+		{
+			"ERR: 400",
+			func(s *resttesting.FakeWebhookService) {
+				s.CreateReturns(internal.Webhook{},
+					internal.NewErrorf(internal.ErrorCodeInvalidArgument, "invalid values"))
+			},
+			[]byte(`{}`),
+			output{
+				http.StatusBadRequest,
+				&rest.ErrorResponse{
+					Error: "invalid request",
+				},
+				&rest.ErrorResponse{},
+			},
+		},
+		{
+			"ERR: 500",
+			func(s *resttesting.FakeWebhookService) {
+				s.CreateReturns(internal.Webhook{},
+					errors.New("service error"))
+			},
+			[]byte(`{}`),
+			output{
+				http.StatusInternalServerError,
+				&rest.ErrorResponse{
+					Error: "internal error",
+				},
+				&rest.ErrorResponse{},
+			},
+		},
+	}
+
+	//-
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			router := newRouter()
+
+			svc := &resttesting.FakeWebhookService{}
+			tt.setup(svc)
+
+			rest.NewWebhookHandler(svc).Register(router)
+
+			//-
+
+			res := doRequest(router,
+				httptest.NewRequest(http.MethodPost, "/webhooks", bytes.NewReader(tt.input)))
+
+			//-
+
+			assertResponse(t, res, test{tt.output.expected, tt.output.target})
+
+			if tt.output.expectedStatus != res.StatusCode {
+				t.Fatalf("expected code %d, actual %d", tt.output.expectedStatus, res.StatusCode)
+			}
+		})
+	}
+}
+
+func TestWebhooks_Deliveries(t *testing.T) {
+	t.Parallel()
+
+	type output struct {
+		expectedStatus int
+		expected       interface{}
+		target         interface{}
+	}
+
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		setup  func(*resttesting.FakeWebhookService)
+		target string
+		output output
+	}{
+		{
+			"OK: 200",
+			func(s *resttesting.FakeWebhookService) {
+				s.DeliveriesReturns(
+					[]internal.WebhookDelivery{
+						{
+							ID:         "a-b-c",
+							EventID:    "d-e-f",
+							EventType:  "tasks.event.created",
+							Attempt:    1,
+							StatusCode: http.StatusOK,
+							Duration:   time.Second,
+							CreatedAt:  createdAt,
+						},
+					},
+					nil)
+			},
+			"/webhooks/54b5d5ec-3f2e-4d0b-8c84-9a54d5c7e5a8/deliveries?size=1",
+			output{
+				http.StatusOK,
+				&rest.ReadWebhookDeliveriesResponse{
+					Deliveries: []rest.WebhookDelivery{
+						{
+							ID:         "a-b-c",
+							EventID:    "d-e-f",
+							EventType:  "tasks.event.created",
+							Attempt:    1,
+							StatusCode: http.StatusOK,
+							DurationMs: 1000,
+							CreatedAt:  createdAt,
+						},
+					},
+				},
+				&rest.ReadWebhookDeliveriesResponse{},
+			},
+		},
+		{
+			"ERR: 400",
+			func(_ *resttesting.FakeWebhookService) {},
+			"/webhooks/54b5d5ec-3f2e-4d0b-8c84-9a54d5c7e5a8/deliveries?size=x",
+			output{
+				http.StatusBadRequest,
+				&rest.ErrorResponse{
+					Error: "invalid request",
+				},
+				&rest.ErrorResponse{},
+			},
+		},
+		{
+			"ERR: 404",
+			func(s *resttesting.FakeWebhookService) {
+				s.DeliveriesReturns(nil, internal.NewErrorf(internal.ErrorCodeNotFound, "not found"))
+			},
+			"/webhooks/54b5d5ec-3f2e-4d0b-8c84-9a54d5c7e5a8/deliveries",
+			output{
+				http.StatusNotFound,
+				&rest.ErrorResponse{
+					Error: "find failed",
+				},
+				&rest.ErrorResponse{},
+			},
+		},
+	}
+
+	//-
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			router := newRouter()
+			svc := &resttesting.FakeWebhookService{}
+			tt.setup(svc)
+
+			rest.NewWebhookHandler(svc).Register(router)
+
+			//-
+
+			res := doRequest(router, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			//-
+
+			assertResponse(t, res, test{tt.output.expected, tt.output.target})
+
+			if tt.output.expectedStatus != res.StatusCode {
+				t.Fatalf("expected code %d, actual %d", tt.output.expectedStatus, res.StatusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+// Package scheduler implements the scheduler publishing the Reminders of the Tasks due soon, or overdue, as Task
+// events.
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/worker"
+)
+
+const otelName = "github.com/MarioCarrion/todo-api/internal/scheduler"
+
+// Repository defines the datastore handling the Reminder records.
+type Repository interface {
+	DueSoon(ctx context.Context, now time.Time, size int32) ([]internal.Reminder, error)
+	Overdue(ctx context.Context, now time.Time, size int32) ([]internal.Reminder, error)
+	Sent(ctx context.Context, reminder internal.Reminder) error
+}
+
+// Publisher defines the message broker publishing the Reminders.
+type Publisher interface {
+	DueSoon(ctx context.Context, task internal.Task) error
+	Overdue(ctx context.Context, task internal.Task) error
+}
+
+// Locker defines the lock electing the leader, only the replica holding it publishes the Reminders.
+type Locker interface {
+	// TryLock acquires the lock without waiting, it indicates whether the lock is held.
+	TryLock(ctx context.Context) (bool, error)
+	Unlock(ctx context.Context) error
+}
+
+// Options defines how often the Scheduler runs, zero values use the defaults.
+type Options struct {
+	// Interval is the time between runs, followers try to become the leader as often; defaults to 1m.
+	Interval time.Duration
+	// BatchSize is the number of Reminders read from the Repository at once, defaults to 100.
+	BatchSize int32
+}
+
+// Scheduler publishes the Reminders of the Tasks due soon, or overdue, every Options.Interval:
+//
+//   - All the replicas try to acquire the lock, only the leader publishes the Reminders.
+//   - Reminders are recorded as sent after being published, so they are published at least once; the ones
+//     failing to be published are published again in the next run.
+//   - Reminders of the same Task passing at the same time, for example when the Scheduler was not running, are
+//     published once.
+type Scheduler struct {
+	logger    *zap.Logger
+	lock      Locker
+	repo      Repository
+	publisher Publisher
+	opts      Options
+
+	sent   metric.Int64Counter
+	failed metric.Int64Counter
+
+	lifecycle *worker.Lifecycle
+
+	mu     sync.Mutex
+	leader bool
+}
+
+// NewScheduler instantiates the Scheduler.
+func NewScheduler(logger *zap.Logger, lock Locker, repo Repository, publisher Publisher, opts Options) *Scheduler {
+	if opts.Interval <= 0 {
+		opts.Interval = time.Minute
+	}
+
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 100
+	}
+
+	meter := otel.Meter(otelName)
+
+	return &Scheduler{
+		logger:    logger,
+		lock:      lock,
+		repo:      repo,
+		publisher: publisher,
+		opts:      opts,
+		sent:      worker.NewCounter(meter, "scheduler.sent", "Reminders published"),
+		failed:    worker.NewCounter(meter, "scheduler.failed", "Reminders failing to be published"),
+		lifecycle: worker.NewLifecycle(),
+	}
+}
+
+// ListenAndServe runs the Scheduler every Options.Interval until Shutdown is called.
+func (s *Scheduler) ListenAndServe() error {
+	defer s.lifecycle.Start()()
+
+	ticker := time.NewTicker(s.opts.Interval)
+	defer ticker.Stop()
+
+	ctx := s.lifecycle.Context()
+
+	for {
+		s.Run(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Shutdown stops running the Scheduler and releases the lock, so another replica becomes the leader.
+func (s *Scheduler) Shutdown(ctx context.Context) error {
+	s.logger.Info("Shutting down scheduler")
+
+	if err := s.lifecycle.Stop(ctx); err != nil {
+		return err //nolint: wrapcheck
+	}
+
+	if err := s.lock.Unlock(ctx); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "lock.Unlock")
+	}
+
+	return nil
+}
+
+// Health indicates whether the Scheduler is running, followers are healthy as well.
+func (s *Scheduler) Health(_ context.Context) error {
+	return s.lifecycle.Health() //nolint: wrapcheck
+}
+
+// Run publishes the Reminders passed as of now, only when holding the lock.
+func (s *Scheduler) Run(ctx context.Context) {
+	ctx, span := otel.Tracer(otelName).Start(ctx, "Scheduler.Run")
+	defer span.End()
+
+	//-
+
+	leader, err := s.lock.TryLock(ctx)
+	if err != nil {
+		s.logger.Error("Couldn't acquire lock", zap.Error(err))
+	}
+
+	s.setLeader(leader)
+
+	if !leader {
+		return
+	}
+
+	now := time.Now()
+
+	s.publish(ctx, now, s.repo.DueSoon)
+	s.publish(ctx, now, s.repo.Overdue)
+}
+
+// publish publishes the Reminders returned by list in batches, it stops when one of them fails to be published.
+func (s *Scheduler) publish(ctx context.Context, now time.Time,
+	list func(ctx context.Context, now time.Time, size int32) ([]internal.Reminder, error),
+) {
+	var last internal.Reminder
+
+	for {
+		reminders, err := list(ctx, now, s.opts.BatchSize)
+		if err != nil {
+			s.logger.Error("Couldn't list reminders", zap.Error(err))
+
+			return
+		}
+
+		for _, reminder := range reminders {
+			attrs := metric.WithAttributes(attribute.String("type", string(reminder.Type)))
+
+			// Reminders are sorted by Task, the closest one to the due date first.
+			duplicated := reminder.Task.ID == last.Task.ID && reminder.Type == last.Type
+
+			if err := s.send(ctx, reminder, duplicated); err != nil {
+				s.logger.Error("Couldn't publish reminder",
+					zap.String("task", reminder.Task.ID),
+					zap.String("type", string(reminder.Type)),
+					zap.Error(err))
+
+				s.failed.Add(ctx, 1, attrs)
+
+				return
+			}
+
+			if !duplicated {
+				s.sent.Add(ctx, 1, attrs)
+			}
+
+			last = reminder
+		}
+
+		if len(reminders) < int(s.opts.BatchSize) {
+			return
+		}
+	}
+}
+
+// send publishes the reminder, unless it's duplicated, and records it was sent.
+func (s *Scheduler) send(ctx context.Context, reminder internal.Reminder, duplicated bool) error {
+	if !duplicated {
+		var err error
+
+		switch reminder.Type {
+		case internal.ReminderDueSoon:
+			err = s.publisher.DueSoon(ctx, reminder.Task)
+		case internal.ReminderOverdue:
+			err = s.publisher.Overdue(ctx, reminder.Task)
+		default:
+			return internal.NewErrorf(internal.ErrorCodeInvalidArgument, "unknown reminder type %q", reminder.Type)
+		}
+
+		if err != nil {
+			return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "publisher")
+		}
+	}
+
+	if err := s.repo.Sent(ctx, reminder); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "repo.Sent")
+	}
+
+	return nil
+}
+
+func (s *Scheduler) setLeader(leader bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.leader == leader {
+		return
+	}
+
+	s.leader = leader
+
+	if leader {
+		s.logger.Info("Acquired lock, publishing reminders")
+	} else {
+		s.logger.Info("Lost lock, waiting to acquire it again")
+	}
+}
@@ -0,0 +1,208 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/scheduler"
+)
+
+func TestScheduler_Run(t *testing.T) {
+	t.Parallel()
+
+	dueSoon := func(id string, before time.Duration) internal.Reminder {
+		return internal.Reminder{Type: internal.ReminderDueSoon, Task: internal.Task{ID: id}, Before: before}
+	}
+
+	overdue := func(id string) internal.Reminder {
+		return internal.Reminder{Type: internal.ReminderOverdue, Task: internal.Task{ID: id}}
+	}
+
+	tests := []struct {
+		name              string
+		locked            bool
+		publishErr        error
+		dueSoon           []internal.Reminder
+		overdue           []internal.Reminder
+		expectedPublished []string
+		expectedSent      int
+	}{
+		{
+			"OK",
+			true,
+			nil,
+			[]internal.Reminder{dueSoon("1", time.Hour), dueSoon("1", 24*time.Hour), dueSoon("2", time.Hour)},
+			[]internal.Reminder{overdue("3")},
+			[]string{"due_soon 1", "due_soon 2", "overdue 3"},
+			4,
+		},
+		{
+			"OK: follower",
+			false,
+			nil,
+			[]internal.Reminder{dueSoon("1", time.Hour)},
+			[]internal.Reminder{overdue("3")},
+			nil,
+			0,
+		},
+		{
+			"ERR: publishing",
+			true,
+			errors.New("failed"),
+			[]internal.Reminder{dueSoon("1", time.Hour), dueSoon("2", time.Hour)},
+			[]internal.Reminder{overdue("3")},
+			[]string{"due_soon 1", "overdue 3"},
+			0,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := &fakeRepository{dueSoon: tt.dueSoon, overdue: tt.overdue}
+			publisher := &fakePublisher{err: tt.publishErr}
+
+			sched := scheduler.NewScheduler(zap.NewNop(), &fakeLock{locked: tt.locked}, repo, publisher,
+				scheduler.Options{BatchSize: 2})
+
+			sched.Run(context.Background())
+
+			if !cmp.Equal(tt.expectedPublished, publisher.published) {
+				t.Fatalf("expected published don't match: %s", cmp.Diff(tt.expectedPublished, publisher.published))
+			}
+
+			if actual := len(repo.sent); actual != tt.expectedSent {
+				t.Fatalf("expected %d sent, got %d", tt.expectedSent, actual)
+			}
+		})
+	}
+}
+
+func TestScheduler_Shutdown(t *testing.T) {
+	t.Parallel()
+
+	lock := &fakeLock{locked: true}
+
+	sched := scheduler.NewScheduler(zap.NewNop(), lock, &fakeRepository{}, &fakePublisher{}, scheduler.Options{})
+
+	errC := make(chan error, 1)
+
+	go func() {
+		errC <- sched.ListenAndServe()
+	}()
+
+	for sched.Health(context.Background()) != nil {
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := sched.Shutdown(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if err := <-errC; err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if !lock.unlocked {
+		t.Fatalf("expected lock to be released")
+	}
+}
+
+//-
+
+type fakeLock struct {
+	locked   bool
+	unlocked bool
+}
+
+func (f *fakeLock) TryLock(_ context.Context) (bool, error) {
+	return f.locked, nil
+}
+
+func (f *fakeLock) Unlock(_ context.Context) error {
+	f.unlocked = true
+
+	return nil
+}
+
+// fakeRepository returns the Reminders not sent yet, the same way the PostgreSQL repository does.
+type fakeRepository struct {
+	dueSoon []internal.Reminder
+	overdue []internal.Reminder
+
+	mu   sync.Mutex
+	sent []internal.Reminder
+}
+
+func (f *fakeRepository) DueSoon(_ context.Context, _ time.Time, size int32) ([]internal.Reminder, error) {
+	return f.pending(f.dueSoon, size), nil
+}
+
+func (f *fakeRepository) Overdue(_ context.Context, _ time.Time, size int32) ([]internal.Reminder, error) {
+	return f.pending(f.overdue, size), nil
+}
+
+func (f *fakeRepository) Sent(_ context.Context, reminder internal.Reminder) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.sent = append(f.sent, reminder)
+
+	return nil
+}
+
+func (f *fakeRepository) pending(reminders []internal.Reminder, size int32) []internal.Reminder {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var res []internal.Reminder
+
+	for _, reminder := range reminders {
+		if len(res) == int(size) {
+			break
+		}
+
+		if !f.isSent(reminder) {
+			res = append(res, reminder)
+		}
+	}
+
+	return res
+}
+
+func (f *fakeRepository) isSent(reminder internal.Reminder) bool {
+	for _, sent := range f.sent {
+		if cmp.Equal(reminder, sent) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type fakePublisher struct {
+	err       error
+	published []string
+}
+
+func (f *fakePublisher) DueSoon(_ context.Context, task internal.Task) error {
+	f.published = append(f.published, "due_soon "+task.ID)
+
+	return f.err
+}
+
+func (f *fakePublisher) Overdue(_ context.Context, task internal.Task) error {
+	f.published = append(f.published, "overdue "+task.ID)
+
+	return f.err
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/MarioCarrion/todo-api/internal"
+)
+
+const (
+	// calendarPageSize is the number of tasks read at a time when listing the calendar.
+	calendarPageSize = 100
+
+	// maxImportedTasks is the maximum number of tasks created by each import.
+	maxImportedTasks = 1_000
+)
+
+// TaskImporter defines the application service creating the imported Task records, like Task.
+type TaskImporter interface {
+	Create(ctx context.Context, params internal.CreateParams) (internal.Task, error)
+	Update(ctx context.Context, id string, description string, priority internal.Priority, dates internal.Dates, isDone bool) error
+}
+
+// Calendar defines the application service in charge of publishing Tasks to calendar applications and importing
+// the ones created by them.
+type Calendar struct {
+	repo  TaskListRepository
+	tasks TaskImporter
+}
+
+// NewCalendar ...
+func NewCalendar(repo TaskListRepository, tasks TaskImporter) *Calendar {
+	return &Calendar{
+		repo:  repo,
+		tasks: tasks,
+	}
+}
+
+// Tasks calls fn, sorted by id, with the Tasks including a start or due date; it stops when fn fails.
+func (c *Calendar) Tasks(ctx context.Context, fn func(internal.Task) error) error {
+	defer newOTELSpan(ctx, "Calendar.Tasks").End()
+
+	//-
+
+	return eachTask(ctx, c.repo, calendarPageSize, func(task internal.Task) error {
+		if task.Dates.Start.IsZero() && task.Dates.Due.IsZero() {
+			return nil
+		}
+
+		return fn(task)
+	})
+}
+
+// Import creates the Tasks, all of them are validated before creating any; the validation errors are indexed by
+// their position.
+func (c *Calendar) Import(ctx context.Context, params []internal.ImportParams) ([]internal.Task, error) {
+	defer newOTELSpan(ctx, "Calendar.Import").End()
+
+	//-
+
+	if len(params) == 0 || len(params) > maxImportedTasks {
+		return nil, internal.NewErrorf(internal.ErrorCodeInvalidArgument, "must include between 1 and %d tasks",
+			maxImportedTasks)
+	}
+
+	verrs := validation.Errors{}
+
+	for i, p := range params {
+		if err := p.Validate(); err != nil {
+			verrs[strconv.Itoa(i)] = err
+		}
+	}
+
+	if len(verrs) > 0 {
+		return nil, internal.WrapErrorf(verrs, internal.ErrorCodeInvalidArgument, "params.Validate")
+	}
+
+	// XXX: Transactions will be revisited in future episodes, tasks created before failing are kept.
+	res := make([]internal.Task, 0, len(params))
+
+	for _, p := range params {
+		task, err := c.tasks.Create(ctx, p.CreateParams)
+		if err != nil {
+			return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "tasks.Create")
+		}
+
+		if p.IsDone {
+			// Tasks are created not done, so completing them is an update.
+			if err := c.tasks.Update(ctx, task.ID, task.Description, task.Priority, task.Dates, true); err != nil {
+				return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "tasks.Update")
+			}
+
+			task.IsDone = true
+			task.Version++
+		}
+
+		res = append(res, task)
+	}
+
+	return res, nil
+}
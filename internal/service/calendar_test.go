@@ -0,0 +1,138 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/service"
+)
+
+type taskImporter struct {
+	created []internal.CreateParams
+	done    []string
+}
+
+func (i *taskImporter) Create(_ context.Context, params internal.CreateParams) (internal.Task, error) {
+	i.created = append(i.created, params)
+
+	return internal.Task{
+		ID:          strconv.Itoa(len(i.created)),
+		Description: params.Description,
+		Priority:    params.Priority,
+		Dates:       params.Dates,
+		Version:     1,
+	}, nil
+}
+
+func (i *taskImporter) Update(_ context.Context, id string, _ string, _ internal.Priority, _ internal.Dates,
+	isDone bool,
+) error {
+	if isDone {
+		i.done = append(i.done, id)
+	}
+
+	return nil
+}
+
+func TestCalendar_Tasks(t *testing.T) {
+	t.Parallel()
+
+	due := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+	var (
+		list     taskList
+		expected []string
+	)
+
+	// More than one page, only the tasks with dates are included.
+	for i := 0; i < 250; i++ {
+		task := internal.Task{ID: strconv.Itoa(1000 + i)}
+
+		if i%2 == 0 {
+			task.Dates.Due = due
+			expected = append(expected, task.ID)
+		}
+
+		list = append(list, task)
+	}
+
+	var actual []string
+
+	err := service.NewCalendar(list, &taskImporter{}).Tasks(context.Background(), func(task internal.Task) error {
+		actual = append(actual, task.ID)
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if !cmp.Equal(expected, actual) {
+		t.Fatalf("expected tasks don't match: %s", cmp.Diff(expected, actual))
+	}
+}
+
+func TestCalendar_Import(t *testing.T) {
+	t.Parallel()
+
+	valid := internal.ImportParams{
+		CreateParams: internal.CreateParams{Description: "Pay rent", Priority: internal.PriorityHigh},
+		IsDone:       true,
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+
+		importer := &taskImporter{}
+
+		tasks, err := service.NewCalendar(taskList{}, importer).Import(context.Background(),
+			[]internal.ImportParams{valid, {CreateParams: valid.CreateParams}})
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		if len(tasks) != 2 || !tasks[0].IsDone || tasks[0].Version != 2 || tasks[1].IsDone {
+			t.Fatalf("expected first task done, got %v", tasks)
+		}
+
+		if !cmp.Equal([]string{"1"}, importer.done) {
+			t.Fatalf("expected first task updated, got %v", importer.done)
+		}
+	})
+
+	t.Run("ERR: invalid", func(t *testing.T) {
+		t.Parallel()
+
+		importer := &taskImporter{}
+
+		_, err := service.NewCalendar(taskList{}, importer).Import(context.Background(),
+			[]internal.ImportParams{valid, {CreateParams: internal.CreateParams{Description: "No priority"}}})
+
+		var verrs validation.Errors
+		if !errors.As(err, &verrs) || len(verrs) != 1 || verrs["1"] == nil {
+			t.Fatalf("expected validation error for the second task, got %s", err)
+		}
+
+		if len(importer.created) != 0 {
+			t.Fatalf("expected no tasks created, got %d", len(importer.created))
+		}
+	})
+
+	t.Run("ERR: empty", func(t *testing.T) {
+		t.Parallel()
+
+		var ierr *internal.Error
+
+		_, err := service.NewCalendar(taskList{}, &taskImporter{}).Import(context.Background(), nil)
+		if !errors.As(err, &ierr) || ierr.Code() != internal.ErrorCodeInvalidArgument {
+			t.Fatalf("expected invalid argument error, got %s", err)
+		}
+	})
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+
+	"github.com/MarioCarrion/todo-api/internal"
+)
+
+// NotificationRepository defines the datastore handling persisting NotificationPreference records.
+type NotificationRepository interface {
+	Save(ctx context.Context, params internal.SaveNotificationPreferenceParams) (internal.NotificationPreference, error)
+	Delete(ctx context.Context, email string) error
+	Find(ctx context.Context, email string) (internal.NotificationPreference, error)
+	List(ctx context.Context) ([]internal.NotificationPreference, error)
+}
+
+// Notification defines the application service in charge of managing the email notification preferences.
+type Notification struct {
+	repo NotificationRepository
+}
+
+// NewNotification ...
+func NewNotification(repo NotificationRepository) *Notification {
+	return &Notification{
+		repo: repo,
+	}
+}
+
+// Save stores a new record, or replaces the existing one using the same email.
+func (n *Notification) Save(ctx context.Context, params internal.SaveNotificationPreferenceParams,
+) (internal.NotificationPreference, error) {
+	defer newOTELSpan(ctx, "Notification.Save").End()
+
+	//-
+
+	if err := params.Validate(); err != nil {
+		return internal.NotificationPreference{},
+			internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "params.Validate")
+	}
+
+	res, err := n.repo.Save(ctx, params)
+	if err != nil {
+		return internal.NotificationPreference{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "repo.Save")
+	}
+
+	return res, nil
+}
+
+// Delete removes an existing NotificationPreference from the datastore.
+func (n *Notification) Delete(ctx context.Context, email string) error {
+	defer newOTELSpan(ctx, "Notification.Delete").End()
+
+	//-
+
+	if err := n.repo.Delete(ctx, email); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "repo.Delete")
+	}
+
+	return nil
+}
+
+// List returns all the NotificationPreferences.
+func (n *Notification) List(ctx context.Context) ([]internal.NotificationPreference, error) {
+	defer newOTELSpan(ctx, "Notification.List").End()
+
+	//-
+
+	res, err := n.repo.List(ctx)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "repo.List")
+	}
+
+	return res, nil
+}
+
+// Preference gets an existing NotificationPreference from the datastore.
+func (n *Notification) Preference(ctx context.Context, email string) (internal.NotificationPreference, error) {
+	defer newOTELSpan(ctx, "Notification.Preference").End()
+
+	//-
+
+	res, err := n.repo.Find(ctx, email)
+	if err != nil {
+		return internal.NotificationPreference{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "repo.Find")
+	}
+
+	return res, nil
+}
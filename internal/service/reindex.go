@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+
+	"github.com/MarioCarrion/todo-api/internal"
+)
+
+// TaskListRepository defines the datastore listing all Task records sorted by id.
+type TaskListRepository interface {
+	ListAfter(ctx context.Context, id string, size int32) ([]internal.Task, error)
+}
+
+// eachTask calls fn with all the Tasks sorted by id, reading size of them at a time; it stops when fn fails.
+func eachTask(ctx context.Context, repo TaskListRepository, size int32, fn func(internal.Task) error) error {
+	var lastID string
+
+	for {
+		tasks, err := repo.ListAfter(ctx, lastID, size)
+		if err != nil {
+			return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "repo.ListAfter")
+		}
+
+		for _, task := range tasks {
+			if err := fn(task); err != nil {
+				return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "fn")
+			}
+		}
+
+		if len(tasks) < int(size) {
+			return nil
+		}
+
+		lastID = tasks[len(tasks)-1].ID
+	}
+}
+
+// TaskBulkSearchRepository defines the search datastore handling Task records in batches.
+type TaskBulkSearchRepository interface {
+	TaskListRepository
+	Bulk(ctx context.Context, tasks []internal.Task, deleted []internal.Task) error
+}
+
+// ReindexResults defines the drift found between the datastore and the search datastore.
+type ReindexResults struct {
+	// Total is the number of records in the datastore.
+	Total int64
+	// Missing are the ids of the records not indexed.
+	Missing []string
+	// Stale are the ids of the records indexed with outdated values.
+	Stale []string
+	// Orphaned are the ids of the indexed records no longer in the datastore.
+	Orphaned []string
+}
+
+// Reindex defines the application service in charge of synchronizing the search datastore.
+type Reindex struct {
+	repo   TaskListRepository
+	search TaskBulkSearchRepository
+	size   int32
+}
+
+// NewReindex ...
+func NewReindex(repo TaskListRepository, search TaskBulkSearchRepository, size int32) *Reindex {
+	return &Reindex{
+		repo:   repo,
+		search: search,
+		size:   size,
+	}
+}
+
+// Run compares all the records, both sources are sorted by id so they are read one page at a time. When verify
+// is true the drift is only reported, otherwise missing and stale records are indexed and orphaned ones deleted.
+//
+//nolint:cyclop
+func (r *Reindex) Run(ctx context.Context, verify bool) (ReindexResults, error) {
+	defer newOTELSpan(ctx, "Reindex.Run").End()
+
+	//-
+
+	var (
+		res       ReindexResults
+		toIndex   []internal.Task
+		toDelete  []internal.Task
+		repoCur   = cursor{list: r.repo.ListAfter, size: r.size}
+		searchCur = cursor{list: r.search.ListAfter, size: r.size}
+	)
+
+	flush := func(force bool) error {
+		if verify || (!force && len(toIndex)+len(toDelete) < int(r.size)) {
+			return nil
+		}
+
+		if err := r.search.Bulk(ctx, toIndex, toDelete); err != nil {
+			return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "search.Bulk")
+		}
+
+		toIndex, toDelete = nil, nil
+
+		return nil
+	}
+
+	for {
+		task, okRepo, err := repoCur.peek(ctx)
+		if err != nil {
+			return ReindexResults{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "repo.peek")
+		}
+
+		indexed, okSearch, err := searchCur.peek(ctx)
+		if err != nil {
+			return ReindexResults{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "search.peek")
+		}
+
+		switch {
+		case !okRepo && !okSearch:
+			if err := flush(true); err != nil {
+				return ReindexResults{}, err
+			}
+
+			return res, nil
+		case okRepo && (!okSearch || task.ID < indexed.ID):
+			res.Total++
+			res.Missing = append(res.Missing, task.ID)
+			toIndex = append(toIndex, task)
+
+			repoCur.next()
+		case okSearch && (!okRepo || indexed.ID < task.ID):
+			res.Orphaned = append(res.Orphaned, indexed.ID)
+			toDelete = append(toDelete, internal.Task{ID: indexed.ID, Version: indexed.Version + 1})
+
+			searchCur.next()
+		default:
+			res.Total++
+
+			// XXX: Stale records indexed with the same, or a newer, version are reported but not overwritten; that
+			// only happens with documents indexed before versions were introduced, migrating the index fixes them.
+			if !equalTasks(task, indexed) {
+				res.Stale = append(res.Stale, task.ID)
+				toIndex = append(toIndex, task)
+			}
+
+			repoCur.next()
+			searchCur.next()
+		}
+
+		if err := flush(false); err != nil {
+			return ReindexResults{}, err
+		}
+	}
+}
+
+//-
+
+type cursor struct {
+	list func(ctx context.Context, id string, size int32) ([]internal.Task, error)
+	size int32
+	page []internal.Task
+	pos  int
+	last string
+	done bool
+}
+
+// peek returns the current task, loading the next page when needed.
+func (c *cursor) peek(ctx context.Context) (internal.Task, bool, error) {
+	if c.pos < len(c.page) {
+		return c.page[c.pos], true, nil
+	}
+
+	if c.done {
+		return internal.Task{}, false, nil
+	}
+
+	page, err := c.list(ctx, c.last, c.size)
+	if err != nil {
+		return internal.Task{}, false, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "list")
+	}
+
+	c.page = page
+	c.pos = 0
+	c.done = len(page) < int(c.size)
+
+	if len(page) == 0 {
+		return internal.Task{}, false, nil
+	}
+
+	c.last = page[len(page)-1].ID
+
+	return page[0], true, nil
+}
+
+func (c *cursor) next() {
+	c.pos++
+}
+
+// equalTasks compares the fields that are indexed.
+func equalTasks(a, b internal.Task) bool {
+	return a.Description == b.Description &&
+		a.Priority == b.Priority &&
+		a.IsDone == b.IsDone &&
+		a.Dates.Start.UnixNano() == b.Dates.Start.UnixNano() &&
+		a.Dates.Due.UnixNano() == b.Dates.Due.UnixNano()
+}
@@ -0,0 +1,116 @@
+package service_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/service"
+)
+
+type taskList []internal.Task
+
+func (l taskList) ListAfter(_ context.Context, id string, size int32) ([]internal.Task, error) {
+	i := sort.Search(len(l), func(i int) bool { return l[i].ID > id })
+
+	end := i + int(size)
+	if end > len(l) {
+		end = len(l)
+	}
+
+	return l[i:end], nil
+}
+
+type bulkSearch struct {
+	taskList
+
+	indexed []string
+	deleted []string
+}
+
+func (b *bulkSearch) Bulk(_ context.Context, tasks []internal.Task, deleted []internal.Task) error {
+	for _, task := range tasks {
+		b.indexed = append(b.indexed, task.ID)
+	}
+
+	for _, task := range deleted {
+		b.deleted = append(b.deleted, task.ID)
+	}
+
+	return nil
+}
+
+func TestReindex_Run(t *testing.T) {
+	t.Parallel()
+
+	repo := taskList{
+		{ID: "1", Description: "one"},
+		{ID: "2", Description: "two"},
+		{ID: "3", Description: "three"},
+		{ID: "5", Description: "five"},
+	}
+
+	indexed := taskList{
+		{ID: "0", Description: "zero"},
+		{ID: "2", Description: "two"},
+		{ID: "3", Description: "three", IsDone: true},
+		{ID: "4", Description: "four"},
+	}
+
+	expected := service.ReindexResults{
+		Total:    4,
+		Missing:  []string{"1", "5"},
+		Stale:    []string{"3"},
+		Orphaned: []string{"0", "4"},
+	}
+
+	tests := []struct {
+		name            string
+		verify          bool
+		expectedIndexed []string
+		expectedDeleted []string
+	}{
+		{
+			"OK: verify",
+			true,
+			nil,
+			nil,
+		},
+		{
+			"OK: reindex",
+			false,
+			[]string{"1", "3", "5"},
+			[]string{"0", "4"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			search := &bulkSearch{taskList: indexed}
+
+			actual, err := service.NewReindex(repo, search, 2).Run(context.Background(), tt.verify)
+			if err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			if !cmp.Equal(expected, actual) {
+				t.Fatalf("expected result does not match: %s", cmp.Diff(expected, actual))
+			}
+
+			sort.Strings(search.indexed)
+
+			if !cmp.Equal(tt.expectedIndexed, search.indexed) {
+				t.Fatalf("expected indexed does not match: %s", cmp.Diff(tt.expectedIndexed, search.indexed))
+			}
+
+			if !cmp.Equal(tt.expectedDeleted, search.deleted) {
+				t.Fatalf("expected deleted does not match: %s", cmp.Diff(tt.expectedDeleted, search.deleted))
+			}
+		})
+	}
+}
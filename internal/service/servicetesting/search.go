@@ -0,0 +1,197 @@
+// Package servicetesting provides contract test suites for the repositories used by the application services.
+package servicetesting
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/service"
+)
+
+// NewTaskSearchRepository instantiates the repository being tested, it must be searchable right after returning.
+// The returned tasks are the ones received with the ids assigned by the repository.
+type NewTaskSearchRepository func(t *testing.T, tasks []internal.Task) (service.TaskSearchRepository, []internal.Task)
+
+// TestTaskSearchRepository runs the contract all the TaskSearchRepository implementations must satisfy.
+//
+//nolint:funlen
+func TestTaskSearchRepository(t *testing.T, newRepo NewTaskSearchRepository) {
+	t.Helper()
+
+	repo, tasks := newRepo(t, []internal.Task{
+		{Description: "buy milk", Priority: internal.PriorityHigh},
+		{Description: "buy bread", Priority: internal.PriorityLow, IsDone: true},
+		{Description: "walk the dog", Priority: internal.PriorityNone},
+	})
+
+	milk, bread, dog := tasks[0].ID, tasks[1].ID, tasks[2].ID
+
+	newString := func(s string) *string { return &s }
+	newPriority := func(p internal.Priority) *internal.Priority { return &p }
+	newBool := func(b bool) *bool { return &b }
+
+	tests := []struct {
+		name          string
+		input         internal.SearchParams
+		output        []string
+		expectedTotal int64
+	}{
+		{
+			"OK: empty arguments",
+			internal.SearchParams{Size: 10},
+			nil,
+			0,
+		},
+		{
+			"OK: description",
+			internal.SearchParams{Description: newString("milk"), Size: 10},
+			[]string{milk},
+			1,
+		},
+		{
+			"OK: description, any word",
+			internal.SearchParams{Description: newString("buy dog"), Size: 10},
+			[]string{bread, milk, dog},
+			3,
+		},
+		{
+			"OK: priority",
+			internal.SearchParams{Priority: newPriority(internal.PriorityHigh), Size: 10},
+			[]string{milk},
+			1,
+		},
+		{
+			"OK: is done",
+			internal.SearchParams{IsDone: newBool(true), Size: 10},
+			[]string{bread},
+			1,
+		},
+		{
+			"OK: any argument",
+			internal.SearchParams{Description: newString("dog"), Priority: newPriority(internal.PriorityLow), Size: 10},
+			[]string{bread, dog},
+			2,
+		},
+		{
+			"OK: paginated",
+			internal.SearchParams{Description: newString("buy"), Size: 1},
+			nil, // only the total is verified, sorting equally relevant tasks is up to the implementation
+			2,
+		},
+		{
+			"OK: no matches",
+			internal.SearchParams{Description: newString("cat"), Size: 10},
+			nil,
+			0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run("Search "+tt.name, func(t *testing.T) {
+			res, err := repo.Search(context.Background(), tt.input)
+			if err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			if res.Total != tt.expectedTotal {
+				t.Fatalf("expected %d total, got %d", tt.expectedTotal, res.Total)
+			}
+
+			if tt.input.Size < res.Total && int64(len(res.Tasks)) != tt.input.Size {
+				t.Fatalf("expected %d tasks, got %d", tt.input.Size, len(res.Tasks))
+			}
+
+			if tt.output == nil {
+				return
+			}
+
+			expected := append([]string{}, tt.output...)
+			sort.Strings(expected)
+
+			if diff := cmp.Diff(expected, ids(res.Tasks)); diff != "" {
+				t.Errorf("the expected result does not match: %s", diff)
+			}
+		})
+	}
+
+	t.Run("Search OK: sorted by relevance", func(t *testing.T) {
+		res, err := repo.Search(context.Background(), internal.SearchParams{
+			Description: newString("milk"),
+			Priority:    newPriority(internal.PriorityHigh),
+			IsDone:      newBool(true),
+			Size:        10,
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		if len(res.Tasks) != 2 || res.Tasks[0].ID != milk {
+			t.Fatalf("expected %q first, got %v", milk, res.Tasks)
+		}
+	})
+
+	t.Run("Suggest OK", func(t *testing.T) {
+		res, err := repo.Suggest(context.Background(), internal.SuggestParams{Query: "walk the d", Size: 5})
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		if diff := cmp.Diff([]internal.Suggestion{{ID: dog, Description: "walk the dog"}}, res); diff != "" {
+			t.Errorf("the expected result does not match: %s", diff)
+		}
+	})
+
+	t.Run("Suggest OK: size", func(t *testing.T) {
+		res, err := repo.Suggest(context.Background(), internal.SuggestParams{Query: "bu", Size: 1})
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		if len(res) != 1 {
+			t.Fatalf("expected 1 suggestion, got %d", len(res))
+		}
+	})
+
+	t.Run("Suggest OK: visible tasks", func(t *testing.T) {
+		res, err := repo.Suggest(context.Background(), internal.SuggestParams{Query: "bu", Size: 5})
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		if len(res) != 2 {
+			t.Fatalf("expected 2 suggestions, got %d", len(res))
+		}
+
+		// Suggestions are scoped to the tasks visible to the caller, the same ones returned by Search.
+		for _, suggestion := range res {
+			found, err := repo.Search(context.Background(), internal.SearchParams{
+				Description: newString(suggestion.Description),
+				Size:        10,
+			})
+			if err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			if !slices.Contains(ids(found.Tasks), suggestion.ID) {
+				t.Fatalf("expected %q to be searchable, got %v", suggestion.ID, found.Tasks)
+			}
+		}
+	})
+}
+
+func ids(tasks []internal.Task) []string {
+	res := make([]string, len(tasks))
+
+	for i, task := range tasks {
+		res[i] = task.ID
+	}
+
+	sort.Strings(res)
+
+	return res
+}
@@ -17,7 +17,7 @@ const otelName = "github.com/MarioCarrion/todo-api/internal/service"
 // TaskRepository defines the datastore handling persisting Task records.
 type TaskRepository interface {
 	Create(ctx context.Context, dates internal.CreateParams) (internal.Task, error)
-	Delete(ctx context.Context, id string) error
+	Delete(ctx context.Context, id string) (int64, error)
 	Find(ctx context.Context, id string) (internal.Task, error)
 	Update(ctx context.Context, id string, description string, priority internal.Priority, dates internal.Dates, isDone bool) error
 }
@@ -25,12 +25,13 @@ type TaskRepository interface {
 // TaskSearchRepository defines the datastore handling searching Task records.
 type TaskSearchRepository interface {
 	Search(ctx context.Context, args internal.SearchParams) (internal.SearchResults, error)
+	Suggest(ctx context.Context, args internal.SuggestParams) ([]internal.Suggestion, error)
 }
 
 // TaskMessageBrokerRepository defines the datastore handling persisting Searchable Task records.
 type TaskMessageBrokerRepository interface {
 	Created(ctx context.Context, task internal.Task) error
-	Deleted(ctx context.Context, id string) error
+	Deleted(ctx context.Context, id string, version int64) error
 	Updated(ctx context.Context, task internal.Task) error
 }
 
@@ -111,16 +112,48 @@ func (t *Task) Delete(ctx context.Context, id string) error {
 	//-
 
 	// XXX: We will revisit the number of received arguments in future episodes.
-	if err := t.repo.Delete(ctx, id); err != nil {
+	version, err := t.repo.Delete(ctx, id)
+	if err != nil {
 		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "Delete")
 	}
 
+	// Deleting is a change as well, so events published before this one are ignored by consumers.
 	// XXX: Transactions will be revisited in future episodes.
-	_ = t.msgBroker.Deleted(ctx, id) // XXX: Ignoring errors on purpose
+	_ = t.msgBroker.Deleted(ctx, id, version+1) // XXX: Ignoring errors on purpose
 
 	return nil
 }
 
+// Suggest returns Task descriptions matching the text typed so far.
+func (t *Task) Suggest(ctx context.Context, args internal.SuggestParams) (_ []internal.Suggestion, err error) {
+	defer newOTELSpan(ctx, "Task.Suggest").End()
+
+	//-
+
+	if args.Size == 0 {
+		args.Size = 5
+	}
+
+	if err := args.Validate(); err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "args.Validate")
+	}
+
+	if !t.cb.Ready() {
+		return nil, internal.NewErrorf(internal.ErrorCodeUnknown, "service not available")
+	}
+
+	defer func() {
+		err = t.cb.Done(ctx, err)
+	}()
+
+	res, err := t.search.Suggest(ctx, args)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "search.Suggest")
+	}
+
+	return res, nil
+}
+
 // Task gets an existing Task from the datastore.
 func (t *Task) Task(ctx context.Context, id string) (internal.Task, error) {
 	defer newOTELSpan(ctx, "Task.Task").End()
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/MarioCarrion/todo-api/internal"
+)
+
+const (
+	// exportPageSize is the number of tasks read at a time when exporting them.
+	exportPageSize = 500
+
+	// maxImportedRows is the maximum number of records included in each import.
+	maxImportedRows = 10_000
+)
+
+// TaskTransferRepository defines the datastore handling exporting and importing Task records.
+type TaskTransferRepository interface {
+	TaskListRepository
+	FindByExternalID(ctx context.Context, externalID string) (internal.Task, error)
+	Upsert(ctx context.Context, params internal.ImportParams) (internal.Task, internal.ImportStatus, error)
+}
+
+// Transfer defines the application service in charge of exporting and importing Tasks in bulk, for example when
+// migrating them from other applications.
+type Transfer struct {
+	repo      TaskTransferRepository
+	msgBroker TaskMessageBrokerRepository
+}
+
+// NewTransfer ...
+func NewTransfer(repo TaskTransferRepository, msgBroker TaskMessageBrokerRepository) *Transfer {
+	return &Transfer{
+		repo:      repo,
+		msgBroker: msgBroker,
+	}
+}
+
+// Export calls fn with all the Tasks sorted by id, they are read one page at a time so they are not kept in memory;
+// it stops when fn fails.
+func (t *Transfer) Export(ctx context.Context, fn func(internal.Task) error) error {
+	defer newOTELSpan(ctx, "Transfer.Export").End()
+
+	//-
+
+	return eachTask(ctx, t.repo, exportPageSize, fn)
+}
+
+// Import upserts the rows using their external id, invalid rows are skipped and included in the results. When
+// dryRun is true nothing is changed, the results indicate what importing the rows would do.
+//
+// Importing stops when a row can't be stored, the rows imported before are kept; importing them again leaves them
+// unchanged.
+func (t *Transfer) Import(ctx context.Context, rows []internal.ImportRow, dryRun bool) (internal.ImportResults, error) {
+	defer newOTELSpan(ctx, "Transfer.Import").End()
+
+	//-
+
+	if len(rows) == 0 || len(rows) > maxImportedRows {
+		return internal.ImportResults{}, internal.NewErrorf(internal.ErrorCodeInvalidArgument,
+			"must include between 1 and %d rows", maxImportedRows)
+	}
+
+	var res internal.ImportResults
+
+	// seen indexes the rows by external id, the same Task can't be imported twice.
+	seen := make(map[string]int, len(rows))
+
+	for _, row := range rows {
+		if err := validateRow(row, seen); err != nil {
+			res.Errors = append(res.Errors, internal.ImportError{
+				Row:        row.Row,
+				ExternalID: row.Params.ExternalID,
+				Err:        err,
+			})
+
+			continue
+		}
+
+		seen[row.Params.ExternalID] = row.Row
+
+		status, err := t.upsert(ctx, row.Params, dryRun)
+		if err != nil {
+			return internal.ImportResults{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "row %d", row.Row)
+		}
+
+		switch status {
+		case internal.ImportStatusCreated:
+			res.Created++
+		case internal.ImportStatusUpdated:
+			res.Updated++
+		case internal.ImportStatusUnchanged:
+			res.Unchanged++
+		}
+	}
+
+	return res, nil
+}
+
+func (t *Transfer) upsert(ctx context.Context, params internal.ImportParams, dryRun bool) (internal.ImportStatus, error) {
+	if dryRun {
+		task, err := t.repo.FindByExternalID(ctx, params.ExternalID)
+		if err != nil {
+			var ierr *internal.Error
+			if errors.As(err, &ierr) && ierr.Code() == internal.ErrorCodeNotFound {
+				return internal.ImportStatusCreated, nil
+			}
+
+			return 0, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "repo.FindByExternalID")
+		}
+
+		if params.Matches(task) {
+			return internal.ImportStatusUnchanged, nil
+		}
+
+		return internal.ImportStatusUpdated, nil
+	}
+
+	task, status, err := t.repo.Upsert(ctx, params)
+	if err != nil {
+		return 0, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "repo.Upsert")
+	}
+
+	// XXX: Transactions will be revisited in future episodes.
+	switch status {
+	case internal.ImportStatusCreated:
+		_ = t.msgBroker.Created(ctx, task) // XXX: Ignoring errors on purpose
+	case internal.ImportStatusUpdated:
+		_ = t.msgBroker.Updated(ctx, task) // XXX: Ignoring errors on purpose
+	case internal.ImportStatusUnchanged:
+	}
+
+	return status, nil
+}
+
+// validateRow indicates whether the row can be imported, seen are the rows already imported indexed by external id.
+func validateRow(row internal.ImportRow, seen map[string]int) error {
+	if row.Err != nil {
+		return row.Err
+	}
+
+	if err := row.Params.Validate(); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "params.Validate")
+	}
+
+	var err error
+
+	if prev, ok := seen[row.Params.ExternalID]; ok {
+		err = internal.NewErrorf(internal.ErrorCodeInvalidArgument, "already imported in row %d", prev)
+	} else if row.Params.ExternalID == "" {
+		err = internal.NewErrorf(internal.ErrorCodeInvalidArgument, "must be set")
+	}
+
+	if err != nil {
+		return internal.WrapErrorf(validation.Errors{"external_id": err}, internal.ErrorCodeInvalidArgument,
+			"invalid external id")
+	}
+
+	return nil
+}
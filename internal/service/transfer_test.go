@@ -0,0 +1,201 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/memory"
+	"github.com/MarioCarrion/todo-api/internal/service"
+)
+
+type msgBroker struct {
+	created []string
+	updated []string
+}
+
+func (b *msgBroker) Created(_ context.Context, task internal.Task) error {
+	b.created = append(b.created, task.ExternalID)
+
+	return nil
+}
+
+func (b *msgBroker) Deleted(_ context.Context, _ string, _ int64) error {
+	return nil
+}
+
+func (b *msgBroker) Updated(_ context.Context, task internal.Task) error {
+	b.updated = append(b.updated, task.ExternalID)
+
+	return nil
+}
+
+func TestTransfer_Export(t *testing.T) {
+	t.Parallel()
+
+	var (
+		list     taskList
+		expected []string
+	)
+
+	// More than one page.
+	for i := 0; i < 1200; i++ {
+		list = append(list, internal.Task{ID: strconv.Itoa(10000 + i)})
+		expected = append(expected, list[i].ID)
+	}
+
+	var actual []string
+
+	err := service.NewTransfer(transferList{list}, &msgBroker{}).Export(context.Background(),
+		func(task internal.Task) error {
+			actual = append(actual, task.ID)
+
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if !cmp.Equal(expected, actual) {
+		t.Fatalf("expected tasks don't match: %s", cmp.Diff(expected, actual))
+	}
+}
+
+func TestTransfer_Import(t *testing.T) {
+	t.Parallel()
+
+	newRow := func(row int, externalID string, description string) internal.ImportRow {
+		return internal.ImportRow{
+			Row: row,
+			Params: internal.ImportParams{
+				CreateParams: internal.CreateParams{Description: description, Priority: internal.PriorityLow},
+				ExternalID:   externalID,
+			},
+		}
+	}
+
+	rows := []internal.ImportRow{
+		newRow(2, "a", "Pay rent"),
+		newRow(3, "b", "Buy milk"),
+		newRow(4, "", "Missing external id"),
+		newRow(5, "a", "Duplicated"),
+		newRow(6, "c", ""),
+		{Row: 7, Err: internal.NewErrorf(internal.ErrorCodeInvalidArgument, "expected 7 columns, got 2")},
+	}
+
+	expectedErrors := map[int]string{
+		4: "external_id",
+		5: "external_id",
+		6: "Description",
+		7: "",
+	}
+
+	assertErrors := func(t *testing.T, actual []internal.ImportError) {
+		t.Helper()
+
+		if len(actual) != len(expectedErrors) {
+			t.Fatalf("expected %d errors, got %v", len(expectedErrors), actual)
+		}
+
+		for _, ierr := range actual {
+			field, ok := expectedErrors[ierr.Row]
+			if !ok {
+				t.Fatalf("unexpected error in row %d: %s", ierr.Row, ierr.Err)
+			}
+
+			var verrs validation.Errors
+			if field != "" && (!errors.As(ierr.Err, &verrs) || verrs[field] == nil) {
+				t.Fatalf("expected %s error in row %d, got %s", field, ierr.Row, ierr.Err)
+			}
+		}
+	}
+
+	repo := memory.NewTask()
+	broker := &msgBroker{}
+	svc := service.NewTransfer(repo, broker)
+
+	if _, _, err := repo.Upsert(context.Background(), rows[1].Params); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	rows[1].Params.IsDone = true
+
+	// Dry run first, so nothing is changed.
+
+	res, err := svc.Import(context.Background(), rows, true)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if res.Created != 1 || res.Updated != 1 || res.Unchanged != 0 {
+		t.Fatalf("expected 1 created and 1 updated, got %+v", res)
+	}
+
+	assertErrors(t, res.Errors)
+
+	if len(broker.created) != 0 || len(broker.updated) != 0 {
+		t.Fatalf("expected no events, got %+v", broker)
+	}
+
+	// Then import.
+
+	res, err = svc.Import(context.Background(), rows, false)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if res.Created != 1 || res.Updated != 1 || res.Unchanged != 0 {
+		t.Fatalf("expected 1 created and 1 updated, got %+v", res)
+	}
+
+	assertErrors(t, res.Errors)
+
+	if !cmp.Equal([]string{"a"}, broker.created) || !cmp.Equal([]string{"b"}, broker.updated) {
+		t.Fatalf("expected events don't match, got %+v", broker)
+	}
+
+	task, err := repo.FindByExternalID(context.Background(), "b")
+	if err != nil || !task.IsDone {
+		t.Fatalf("expected task updated, got %+v, %v", task, err)
+	}
+
+	// Importing again changes nothing.
+
+	res, err = svc.Import(context.Background(), rows, false)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if res.Created != 0 || res.Updated != 0 || res.Unchanged != 2 {
+		t.Fatalf("expected 2 unchanged, got %+v", res)
+	}
+}
+
+func TestTransfer_Import_Empty(t *testing.T) {
+	t.Parallel()
+
+	var ierr *internal.Error
+
+	_, err := service.NewTransfer(memory.NewTask(), &msgBroker{}).Import(context.Background(), nil, false)
+	if !errors.As(err, &ierr) || ierr.Code() != internal.ErrorCodeInvalidArgument {
+		t.Fatalf("expected invalid argument error, got %s", err)
+	}
+}
+
+// transferList is a TaskTransferRepository only supporting listing the tasks.
+type transferList struct {
+	taskList
+}
+
+func (transferList) FindByExternalID(_ context.Context, _ string) (internal.Task, error) {
+	return internal.Task{}, internal.NewErrorf(internal.ErrorCodeNotFound, "not found")
+}
+
+func (transferList) Upsert(_ context.Context, _ internal.ImportParams) (internal.Task, internal.ImportStatus, error) {
+	return internal.Task{}, 0, errors.New("not supported")
+}
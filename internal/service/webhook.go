@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+
+	"github.com/MarioCarrion/todo-api/internal"
+)
+
+// WebhookRepository defines the datastore handling persisting Webhook records.
+type WebhookRepository interface {
+	Create(ctx context.Context, params internal.CreateWebhookParams) (internal.Webhook, error)
+	Delete(ctx context.Context, id string) error
+	Find(ctx context.Context, id string) (internal.Webhook, error)
+	List(ctx context.Context) ([]internal.Webhook, error)
+	Deliveries(ctx context.Context, id string, size int32) ([]internal.WebhookDelivery, error)
+}
+
+const maxWebhookDeliveries = 100
+
+// Webhook defines the application service in charge of managing Webhook subscriptions.
+type Webhook struct {
+	repo WebhookRepository
+}
+
+// NewWebhook ...
+func NewWebhook(repo WebhookRepository) *Webhook {
+	return &Webhook{
+		repo: repo,
+	}
+}
+
+// Create stores a new record.
+func (w *Webhook) Create(ctx context.Context, params internal.CreateWebhookParams) (internal.Webhook, error) {
+	defer newOTELSpan(ctx, "Webhook.Create").End()
+
+	//-
+
+	if err := params.Validate(); err != nil {
+		return internal.Webhook{}, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "params.Validate")
+	}
+
+	webhook, err := w.repo.Create(ctx, params)
+	if err != nil {
+		return internal.Webhook{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "repo.Create")
+	}
+
+	return webhook, nil
+}
+
+// Delete removes an existing Webhook from the datastore.
+func (w *Webhook) Delete(ctx context.Context, id string) error {
+	defer newOTELSpan(ctx, "Webhook.Delete").End()
+
+	//-
+
+	if err := w.repo.Delete(ctx, id); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "repo.Delete")
+	}
+
+	return nil
+}
+
+// Deliveries returns the most recent attempts to send events to an existing Webhook, size is capped to 100.
+func (w *Webhook) Deliveries(ctx context.Context, id string, size int32) ([]internal.WebhookDelivery, error) {
+	defer newOTELSpan(ctx, "Webhook.Deliveries").End()
+
+	//-
+
+	if size <= 0 || size > maxWebhookDeliveries {
+		size = maxWebhookDeliveries
+	}
+
+	// XXX: Confirming the Webhook exists, otherwise an empty list is indistinguishable from an unknown Webhook.
+	if _, err := w.repo.Find(ctx, id); err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "repo.Find")
+	}
+
+	res, err := w.repo.Deliveries(ctx, id, size)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "repo.Deliveries")
+	}
+
+	return res, nil
+}
+
+// List returns all the Webhooks.
+func (w *Webhook) List(ctx context.Context) ([]internal.Webhook, error) {
+	defer newOTELSpan(ctx, "Webhook.List").End()
+
+	//-
+
+	res, err := w.repo.List(ctx)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "repo.List")
+	}
+
+	return res, nil
+}
+
+// Webhook gets an existing Webhook from the datastore.
+func (w *Webhook) Webhook(ctx context.Context, id string) (internal.Webhook, error) {
+	defer newOTELSpan(ctx, "Webhook.Webhook").End()
+
+	//-
+
+	res, err := w.repo.Find(ctx, id)
+	if err != nil {
+		return internal.Webhook{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "repo.Find")
+	}
+
+	return res, nil
+}
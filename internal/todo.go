@@ -61,6 +61,12 @@ type Task struct {
 	Dates       Dates
 	SubTasks    []Task
 	Categories  []Category
+	// ExternalID identifies the Task in the application it was imported from, it's empty for Tasks created using
+	// this API.
+	ExternalID string
+	// Version is incremented every time the task changes, including when it's deleted; consumers use it for
+	// ignoring outdated events.
+	Version int64
 }
 
 // Validate ...
@@ -0,0 +1,236 @@
+package transfer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/MarioCarrion/todo-api/internal"
+)
+
+// maxLineSize is the maximum size of each newline delimited JSON object.
+const maxLineSize = 1 << 20
+
+// Decode reads the records as the parameters used for importing Tasks, the rows are numbered using the line where
+// each record starts. Errors found in a record, like invalid dates, are included in its row; Decode fails when the
+// file can't be read, for example when the CSV header includes unknown columns.
+//
+// Records without external id use their id instead, so files exported by other instances can be imported multiple
+// times. The parameters are not validated.
+func Decode(r io.Reader, format Format) ([]internal.ImportRow, error) {
+	if err := format.Validate(); err != nil {
+		return nil, err
+	}
+
+	if format == FormatNDJSON {
+		return decodeNDJSON(r)
+	}
+
+	return decodeCSV(r)
+}
+
+func decodeCSV(r io.Reader) ([]internal.ImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	columns, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "invalid header")
+	}
+
+	for i, column := range columns {
+		// Spreadsheet applications include the UTF-8 byte order mark.
+		column = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+
+		if !slices.Contains(header, column) || slices.Contains(columns[:i], column) {
+			return nil, internal.NewErrorf(internal.ErrorCodeInvalidArgument, "invalid column %q", column)
+		}
+
+		columns[i] = column
+	}
+
+	var res []internal.ImportRow
+
+	for {
+		fields, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return res, nil
+		}
+
+		if err != nil {
+			return nil, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "csv.Read")
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		if len(fields) != len(columns) {
+			res = append(res, internal.ImportRow{
+				Row: line,
+				Err: internal.NewErrorf(internal.ErrorCodeInvalidArgument, "expected %d columns, got %d",
+					len(columns), len(fields)),
+			})
+
+			continue
+		}
+
+		rec, errs := parseFields(columns, fields)
+
+		res = append(res, newRow(line, rec, errs))
+	}
+}
+
+// parseFields converts the CSV fields to a record, the errors are indexed by column.
+func parseFields(columns []string, fields []string) (record, validation.Errors) {
+	var (
+		rec  record
+		errs = validation.Errors{}
+	)
+
+	for i, column := range columns {
+		val := strings.TrimSpace(fields[i])
+
+		var err error
+
+		switch column {
+		case "id":
+			rec.ID = val
+		case "external_id":
+			rec.ExternalID = val
+		case "description":
+			rec.Description = fields[i]
+		case "priority":
+			rec.Priority = val
+		case "start_date":
+			rec.StartDate, err = parseTime(val)
+		case "due_date":
+			rec.DueDate, err = parseTime(val)
+		case "done":
+			if val != "" {
+				rec.Done, err = strconv.ParseBool(val)
+				if err != nil {
+					err = internal.NewErrorf(internal.ErrorCodeInvalidArgument, "must be true or false")
+				}
+			}
+		}
+
+		if err != nil {
+			errs[column] = err
+		}
+	}
+
+	return rec, errs
+}
+
+func decodeNDJSON(r io.Reader) ([]internal.ImportRow, error) {
+	var res []internal.ImportRow
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxLineSize)
+
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		dec := json.NewDecoder(bytes.NewReader(scanner.Bytes()))
+		dec.DisallowUnknownFields()
+
+		var rec record
+
+		if err := dec.Decode(&rec); err != nil {
+			res = append(res, internal.ImportRow{
+				Row: line,
+				Err: internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "invalid JSON"),
+			})
+
+			continue
+		}
+
+		res = append(res, newRow(line, rec, validation.Errors{}))
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "scanner.Scan")
+	}
+
+	return res, nil
+}
+
+func newRow(line int, rec record, errs validation.Errors) internal.ImportRow {
+	priority, err := parsePriority(rec.Priority)
+	if err != nil {
+		errs["priority"] = err
+	}
+
+	row := internal.ImportRow{
+		Row: line,
+		Params: internal.ImportParams{
+			CreateParams: internal.CreateParams{
+				Description: rec.Description,
+				Priority:    priority,
+			},
+			IsDone:     rec.Done,
+			ExternalID: rec.ExternalID,
+		},
+	}
+
+	if row.Params.ExternalID == "" {
+		row.Params.ExternalID = rec.ID
+	}
+
+	if rec.StartDate != nil {
+		row.Params.Dates.Start = rec.StartDate.UTC()
+	}
+
+	if rec.DueDate != nil {
+		row.Params.Dates.Due = rec.DueDate.UTC()
+	}
+
+	if len(errs) > 0 {
+		row.Err = internal.WrapErrorf(errs, internal.ErrorCodeInvalidArgument, "invalid values")
+	}
+
+	return row
+}
+
+func parseTime(val string) (*time.Time, error) {
+	if val == "" {
+		return nil, nil //nolint: nilnil
+	}
+
+	res, err := time.Parse(time.RFC3339, val)
+	if err != nil {
+		return nil, internal.NewErrorf(internal.ErrorCodeInvalidArgument, "must be a RFC 3339 date")
+	}
+
+	return &res, nil
+}
+
+// parsePriority converts the priority, empty values are "none".
+func parsePriority(val string) (internal.Priority, error) {
+	switch strings.ToLower(val) {
+	case "", "none":
+		return internal.PriorityNone, nil
+	case "low":
+		return internal.PriorityLow, nil
+	case "medium":
+		return internal.PriorityMedium, nil
+	case "high":
+		return internal.PriorityHigh, nil
+	}
+
+	return internal.PriorityNone, internal.NewErrorf(internal.ErrorCodeInvalidArgument, "unknown value")
+}
@@ -0,0 +1,213 @@
+package transfer_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/transfer"
+)
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	due := time.Date(2026, 10, 3, 17, 30, 0, 0, time.UTC)
+
+	imported := internal.ImportParams{
+		CreateParams: internal.CreateParams{
+			Description: "Pay rent, then call",
+			Priority:    internal.PriorityHigh,
+			Dates:       internal.Dates{Due: due},
+		},
+		IsDone:     true,
+		ExternalID: "external-1",
+	}
+
+	exported := internal.ImportParams{
+		CreateParams: internal.CreateParams{
+			Description: "Buy milk",
+			Priority:    internal.PriorityLow,
+		},
+		ExternalID: "2",
+	}
+
+	type row struct {
+		Row    int
+		Params internal.ImportParams
+		// Errors are the columns with errors, "-" when the error is not indexed by column.
+		Errors []string
+	}
+
+	tests := []struct {
+		name     string
+		format   transfer.Format
+		input    string
+		expected []row
+		withErr  bool
+	}{
+		{
+			"OK: csv",
+			transfer.FormatCSV,
+			"\ufeffID,external_id,description,priority,start_date,due_date,done\n" +
+				`1,external-1,"Pay rent, then call",HIGH,,2026-10-03T12:30:00-05:00,true` + "\n" +
+				"2,,Buy milk,low,,,\n",
+			[]row{
+				{Row: 2, Params: imported},
+				{Row: 3, Params: exported},
+			},
+			false,
+		},
+		{
+			"OK: csv columns subset",
+			transfer.FormatCSV,
+			"description,priority,external_id\nBuy milk,low,2\n",
+			[]row{
+				{Row: 2, Params: exported},
+			},
+			false,
+		},
+		{
+			"OK: csv row errors",
+			transfer.FormatCSV,
+			"external_id,description,priority,due_date,done\n" +
+				"1,One,urgent,tomorrow,yes\n" +
+				"2,Two\n" +
+				"3,Three,low,,\n",
+			[]row{
+				{
+					Row: 2,
+					Params: internal.ImportParams{
+						CreateParams: internal.CreateParams{Description: "One"},
+						ExternalID:   "1",
+					},
+					Errors: []string{"done", "due_date", "priority"},
+				},
+				{Row: 3, Errors: []string{"-"}},
+				{
+					Row: 4,
+					Params: internal.ImportParams{
+						CreateParams: internal.CreateParams{Description: "Three", Priority: internal.PriorityLow},
+						ExternalID:   "3",
+					},
+				},
+			},
+			false,
+		},
+		{
+			"OK: csv empty",
+			transfer.FormatCSV,
+			"",
+			nil,
+			false,
+		},
+		{
+			"OK: ndjson",
+			transfer.FormatNDJSON,
+			`{"id":"1","external_id":"external-1","description":"Pay rent, then call","priority":"high",` +
+				`"due_date":"2026-10-03T17:30:00Z","done":true}` + "\n" +
+				"\n" +
+				`{"id":"2","description":"Buy milk","priority":"low"}` + "\n" +
+				`{"id":"3","description":"Unknown","notes":"x"}` + "\n" +
+				`{"id":"4","description":"Invalid","priority":"urgent"}`,
+			[]row{
+				{Row: 1, Params: imported},
+				{Row: 3, Params: exported},
+				{Row: 4, Errors: []string{"-"}},
+				{
+					Row: 5,
+					Params: internal.ImportParams{
+						CreateParams: internal.CreateParams{Description: "Invalid"},
+						ExternalID:   "4",
+					},
+					Errors: []string{"priority"},
+				},
+			},
+			false,
+		},
+		{
+			"ERR: csv unknown column",
+			transfer.FormatCSV,
+			"description,notes\nBuy milk,x\n",
+			nil,
+			true,
+		},
+		{
+			"ERR: csv duplicated column",
+			transfer.FormatCSV,
+			"description,description\nBuy milk,x\n",
+			nil,
+			true,
+		},
+		{
+			"ERR: csv malformed",
+			transfer.FormatCSV,
+			"description\n\"Buy \"milk\n",
+			nil,
+			true,
+		},
+		{
+			"ERR: format",
+			transfer.Format("xml"),
+			"",
+			nil,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := transfer.Decode(strings.NewReader(tt.input), tt.format)
+			if (err != nil) != tt.withErr {
+				t.Fatalf("expected error %t, got %s", tt.withErr, err)
+			}
+
+			var actual []row
+
+			for _, r := range res {
+				actual = append(actual, row{Row: r.Row, Params: r.Params, Errors: columns(t, r.Err)})
+			}
+
+			if diff := cmp.Diff(tt.expected, actual, cmpopts.EquateEmpty()); diff != "" {
+				t.Fatalf("the expected result does not match: %s", diff)
+			}
+		})
+	}
+}
+
+// columns returns the sorted columns included in the error, "-" when it's not indexed by column.
+func columns(t *testing.T, err error) []string {
+	t.Helper()
+
+	if err == nil {
+		return nil
+	}
+
+	var ierr *internal.Error
+	if !errors.As(err, &ierr) || ierr.Code() != internal.ErrorCodeInvalidArgument {
+		t.Fatalf("expected %T error, got %T : %v", ierr, err, err)
+	}
+
+	var verrs validation.Errors
+	if !errors.As(err, &verrs) {
+		return []string{"-"}
+	}
+
+	res := make([]string, 0, len(verrs))
+
+	for column := range verrs {
+		res = append(res, column)
+	}
+
+	slices.Sort(res)
+
+	return res
+}
@@ -0,0 +1,171 @@
+// Package transfer implements encoding and decoding Tasks using CSV and newline delimited JSON, used for exporting
+// and importing them in bulk.
+package transfer
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/MarioCarrion/todo-api/internal"
+)
+
+// Format indicates the file format used for encoding Tasks.
+type Format string
+
+const (
+	// FormatCSV encodes Tasks as comma-separated values, RFC 4180, the first record is the header.
+	FormatCSV Format = "csv"
+
+	// FormatNDJSON encodes Tasks as newline delimited JSON objects.
+	FormatNDJSON Format = "ndjson"
+)
+
+// Validate ...
+func (f Format) Validate() error {
+	switch f {
+	case FormatCSV, FormatNDJSON:
+		return nil
+	}
+
+	return internal.NewErrorf(internal.ErrorCodeInvalidArgument, "unknown format")
+}
+
+// ContentType returns the media type of the files using the format.
+func (f Format) ContentType() string {
+	if f == FormatNDJSON {
+		return "application/x-ndjson"
+	}
+
+	return "text/csv; charset=utf-8"
+}
+
+// header defines the CSV columns, they use the same names as the JSON fields.
+var header = []string{"id", "external_id", "description", "priority", "start_date", "due_date", "done"} //nolint: gochecknoglobals
+
+// record is a Task in both formats, dates use RFC 3339 and are omitted when zero.
+type record struct {
+	ID          string     `json:"id,omitempty"`
+	ExternalID  string     `json:"external_id,omitempty"`
+	Description string     `json:"description"`
+	Priority    string     `json:"priority"`
+	StartDate   *time.Time `json:"start_date,omitempty"`
+	DueDate     *time.Time `json:"due_date,omitempty"`
+	Done        bool       `json:"done"`
+}
+
+// Encoder writes Tasks using a format, they are buffered so Flush must be called after encoding all of them.
+type Encoder struct {
+	csv  *csv.Writer
+	json *json.Encoder
+	buf  *bufio.Writer
+}
+
+// NewEncoder instantiates the Encoder, the CSV header is written before the first Task.
+func NewEncoder(w io.Writer, format Format) *Encoder {
+	if format == FormatNDJSON {
+		buf := bufio.NewWriter(w)
+
+		return &Encoder{
+			json: json.NewEncoder(buf),
+			buf:  buf,
+		}
+	}
+
+	enc := &Encoder{csv: csv.NewWriter(w)}
+
+	// csv.Writer is buffered, errors are returned by Flush.
+	_ = enc.csv.Write(header)
+
+	return enc
+}
+
+// Encode writes the Task.
+func (e *Encoder) Encode(task internal.Task) error {
+	rec := record{
+		ID:          task.ID,
+		ExternalID:  task.ExternalID,
+		Description: task.Description,
+		Priority:    formatPriority(task.Priority),
+		StartDate:   newTime(task.Dates.Start),
+		DueDate:     newTime(task.Dates.Due),
+		Done:        task.IsDone,
+	}
+
+	if e.json != nil {
+		if err := e.json.Encode(&rec); err != nil {
+			return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "json.Encode")
+		}
+
+		return nil
+	}
+
+	if err := e.csv.Write([]string{
+		rec.ID,
+		rec.ExternalID,
+		rec.Description,
+		rec.Priority,
+		formatTime(rec.StartDate),
+		formatTime(rec.DueDate),
+		strconv.FormatBool(rec.Done),
+	}); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "csv.Write")
+	}
+
+	return nil
+}
+
+// Flush writes the buffered Tasks, it's safe to keep encoding Tasks after flushing.
+func (e *Encoder) Flush() error {
+	if e.buf != nil {
+		if err := e.buf.Flush(); err != nil {
+			return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "buf.Flush")
+		}
+
+		return nil
+	}
+
+	e.csv.Flush()
+
+	if err := e.csv.Error(); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "csv.Flush")
+	}
+
+	return nil
+}
+
+func newTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	t = t.UTC()
+
+	return &t
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+func formatPriority(p internal.Priority) string {
+	switch p {
+	case internal.PriorityNone:
+		return "none"
+	case internal.PriorityLow:
+		return "low"
+	case internal.PriorityMedium:
+		return "medium"
+	case internal.PriorityHigh:
+		return "high"
+	}
+
+	return "none"
+}
@@ -0,0 +1,96 @@
+package transfer_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/transfer"
+)
+
+func TestEncoder(t *testing.T) {
+	t.Parallel()
+
+	tasks := []internal.Task{
+		{
+			ID:          "1",
+			Description: "Pay rent, then call",
+			Priority:    internal.PriorityHigh,
+			Dates:       internal.Dates{Due: time.Date(2026, 10, 3, 17, 30, 0, 0, time.UTC)},
+			IsDone:      true,
+			ExternalID:  "external-1",
+			Version:     2,
+		},
+		{
+			ID:          "2",
+			Description: "Buy milk",
+		},
+	}
+
+	tests := []struct {
+		name     string
+		format   transfer.Format
+		tasks    []internal.Task
+		expected string
+	}{
+		{
+			"OK: csv",
+			transfer.FormatCSV,
+			tasks,
+			"id,external_id,description,priority,start_date,due_date,done\n" +
+				`1,external-1,"Pay rent, then call",high,,2026-10-03T17:30:00Z,true` + "\n" +
+				"2,,Buy milk,none,,,false\n",
+		},
+		{
+			"OK: csv header",
+			transfer.FormatCSV,
+			nil,
+			"id,external_id,description,priority,start_date,due_date,done\n",
+		},
+		{
+			"OK: ndjson",
+			transfer.FormatNDJSON,
+			tasks,
+			`{"id":"1","external_id":"external-1","description":"Pay rent, then call","priority":"high",` +
+				`"due_date":"2026-10-03T17:30:00Z","done":true}` + "\n" +
+				`{"id":"2","description":"Buy milk","priority":"none","done":false}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+
+			enc := transfer.NewEncoder(&buf, tt.format)
+
+			for _, task := range tt.tasks {
+				if err := enc.Encode(task); err != nil {
+					t.Fatalf("expected no error, got %s", err)
+				}
+			}
+
+			if err := enc.Flush(); err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			if actual := buf.String(); actual != tt.expected {
+				t.Fatalf("expected\n%s\ngot\n%s", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestFormat_Validate(t *testing.T) {
+	t.Parallel()
+
+	if err := transfer.Format("xml").Validate(); err == nil {
+		t.Fatalf("expected error")
+	}
+
+	if err := transfer.FormatNDJSON.Validate(); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+}
@@ -0,0 +1,118 @@
+package internal
+
+import (
+	"net/netip"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Webhook is a subscription to Task events, those are sent to its URL.
+type Webhook struct {
+	ID  string
+	URL string
+	// EventTypes are the types of the events sent, all of them when empty.
+	EventTypes []string
+	// Secret is used for signing the requests, it's never returned by the REST API.
+	Secret    string
+	CreatedAt time.Time
+}
+
+// Subscribed indicates whether the events of the received type are sent.
+func (w Webhook) Subscribed(eventType string) bool {
+	if len(w.EventTypes) == 0 {
+		return true
+	}
+
+	for _, typ := range w.EventTypes {
+		if typ == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CreateWebhookParams defines the arguments used for creating Webhook records.
+type CreateWebhookParams struct {
+	URL        string
+	EventTypes []string
+	Secret     string
+}
+
+// eventTypeRegEx matches the types of the Task events, see the events package.
+var eventTypeRegEx = regexp.MustCompile(`^tasks\.event\.[a-z_]+$`)
+
+// Validate indicates whether the fields are valid or not.
+func (c CreateWebhookParams) Validate() error {
+	if err := validation.ValidateStruct(&c,
+		validation.Field(&c.URL, validation.Required, validation.Length(1, 2048), validation.By(validateWebhookURL)),
+		validation.Field(&c.EventTypes, validation.Each(validation.Required, validation.Match(eventTypeRegEx))),
+		validation.Field(&c.Secret, validation.Required, validation.Length(16, 256)),
+	); err != nil {
+		return WrapErrorf(err, ErrorCodeInvalidArgument, "invalid values")
+	}
+
+	return nil
+}
+
+func validateWebhookURL(value interface{}) error {
+	val, _ := value.(string)
+
+	u, err := url.Parse(val)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return NewErrorf(ErrorCodeInvalidArgument, "must be an absolute http or https URL")
+	}
+
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return NewErrorf(ErrorCodeInvalidArgument, "must not be a local address")
+	}
+
+	// XXX: Hostnames resolving to internal addresses are rejected when sending the requests instead, the addresses
+	// may change after creating the Webhook.
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return ValidateWebhookAddr(addr)
+	}
+
+	return nil
+}
+
+// ValidateWebhookAddr indicates whether the events can be sent to the address, only public addresses are allowed
+// so Webhooks can't be used for reaching internal services: loopback, private, link-local, unspecified and
+// multicast addresses are rejected.
+func ValidateWebhookAddr(addr netip.Addr) error {
+	addr = addr.Unmap()
+
+	if !addr.IsValid() ||
+		addr.IsLoopback() ||
+		addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() ||
+		addr.IsUnspecified() {
+		return NewErrorf(ErrorCodeInvalidArgument, "must not be a local or private address")
+	}
+
+	return nil
+}
+
+// WebhookDelivery is an attempt to send an event to a Webhook.
+type WebhookDelivery struct {
+	ID        string
+	WebhookID string
+	EventID   string
+	EventType string
+	// Attempt starts at 1, it's incremented every time the event is sent again to the same Webhook.
+	Attempt int
+	// StatusCode is the HTTP status code received, zero when the request failed before receiving a response.
+	StatusCode int
+	// Error indicates why the delivery failed, empty when it succeeded.
+	Error     string
+	Duration  time.Duration
+	CreatedAt time.Time
+}
@@ -0,0 +1,509 @@
+// Package webhook implements the dispatcher sending the Task events to the URLs subscribed using Webhooks.
+//
+// Events are sent as the body of POST requests using the CloudEvents JSON format, the requests are signed using
+// the secret of the Webhook; see Sign.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/mercari/go-circuitbreaker"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/events"
+	"github.com/MarioCarrion/todo-api/internal/indexer"
+	"github.com/MarioCarrion/todo-api/internal/worker"
+)
+
+const otelName = "github.com/MarioCarrion/todo-api/internal/webhook"
+
+// Repository defines the datastore handling the Webhook records.
+type Repository interface {
+	List(ctx context.Context) ([]internal.Webhook, error)
+	LogDelivery(ctx context.Context, delivery internal.WebhookDelivery) error
+}
+
+// Options defines the retries, the queues and the circuit breaking of the Dispatcher, zero values use the defaults.
+type Options struct {
+	// RetryOptions defines how the events are sent again to a Webhook.
+	worker.RetryOptions
+	// BreakerFailures is the number of consecutive failed attempts opening the circuit breaker of a Webhook,
+	// defaults to 5.
+	BreakerFailures int64
+	// BreakerOpenTimeout is the time the circuit breaker of a Webhook is open, before sending events again,
+	// defaults to 1m.
+	BreakerOpenTimeout time.Duration
+	// QueueSize is the maximum number of events waiting to be sent to each Webhook, defaults to 100.
+	QueueSize int
+	// WebhooksTTL is the time the Webhooks are cached, created and deleted Webhooks take up to this long to be
+	// used; defaults to 30s.
+	WebhooksTTL time.Duration
+}
+
+// Dispatcher sends the Task events received from a Source to the subscribed Webhooks:
+//
+//   - Each Webhook has its own queue, events are sent to it in the order they were received, so a slow or failing
+//     Webhook doesn't delay the rest until its queue is full.
+//   - Events are acknowledged in the order they were received, after all the subscribed Webhooks received them or
+//     the Dispatcher gave up.
+//   - Failed requests, the ones failing before receiving a response or receiving 408, 429 or 5xx, are sent again
+//     using exponential backoff; other 4xx, and requests to non-public addresses when using NewTransport, are not
+//     sent again.
+//   - Each Webhook has its own circuit breaker, events are not sent while it's open.
+//   - Every attempt is logged using the Repository.
+//
+// Events are delivered at least once, receivers should use HeaderEventID for ignoring duplicates.
+type Dispatcher struct {
+	logger *zap.Logger
+	source indexer.Source
+	repo   Repository
+	client *http.Client
+	opts   Options
+
+	delivered    metric.Int64Counter
+	failed       metric.Int64Counter
+	deadLettered metric.Int64Counter
+
+	mu       sync.Mutex
+	breakers map[string]*circuitbreaker.CircuitBreaker
+	queues   map[string]*queue
+	acks     acker
+
+	// webhooks are cached, they are only used by Handle which is called sequentially.
+	webhooks []internal.Webhook
+	listedAt time.Time
+
+	lifecycle *worker.Lifecycle
+}
+
+// NewDispatcher instantiates the Dispatcher, client is used for sending the requests and it should define a
+// timeout.
+func NewDispatcher(logger *zap.Logger, source indexer.Source, repo Repository, client *http.Client, opts Options) *Dispatcher { //nolint: lll
+	opts.RetryOptions = opts.RetryOptions.WithDefaults()
+
+	if opts.BreakerFailures <= 0 {
+		opts.BreakerFailures = 5
+	}
+
+	if opts.BreakerOpenTimeout <= 0 {
+		opts.BreakerOpenTimeout = time.Minute
+	}
+
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = 100
+	}
+
+	if opts.WebhooksTTL <= 0 {
+		opts.WebhooksTTL = 30 * time.Second
+	}
+
+	meter := otel.Meter(otelName)
+
+	return &Dispatcher{
+		logger:       logger,
+		source:       source,
+		repo:         repo,
+		client:       client,
+		opts:         opts,
+		delivered:    worker.NewCounter(meter, "webhook.delivered", "Events delivered to webhooks"),
+		failed:       worker.NewCounter(meter, "webhook.failed", "Events webhooks never received"),
+		deadLettered: worker.NewCounter(meter, "webhook.dead_lettered", "Messages that can't be delivered"),
+		breakers:     make(map[string]*circuitbreaker.CircuitBreaker),
+		queues:       make(map[string]*queue),
+		lifecycle:    worker.NewLifecycle(),
+	}
+}
+
+// ListenAndServe consumes the Source until Shutdown is called, the queued events are sent before returning.
+func (d *Dispatcher) ListenAndServe() error {
+	done := d.lifecycle.Start()
+
+	defer func() {
+		d.closeQueues()
+		done()
+	}()
+
+	if err := d.source.Consume(d.lifecycle.Context(), d.Handle); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "source.Consume")
+	}
+
+	d.logger.Info("No more messages to consume. Exiting.")
+
+	return nil
+}
+
+// Shutdown stops consuming the Source and sending the queued events, those are not acknowledged so they are
+// delivered again after restarting.
+func (d *Dispatcher) Shutdown(ctx context.Context) error {
+	d.logger.Info("Shutting down dispatcher")
+
+	return d.lifecycle.Stop(ctx) //nolint: wrapcheck
+}
+
+// Health indicates whether the Dispatcher is consuming the Source.
+func (d *Dispatcher) Health(ctx context.Context) error {
+	if err := d.lifecycle.Health(); err != nil {
+		return err //nolint: wrapcheck
+	}
+
+	if err := d.source.Ping(ctx); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "source.Ping")
+	}
+
+	return nil
+}
+
+// Handle adds the event in the message to the queues of the subscribed Webhooks, it implements "indexer.Handler";
+// it waits while any of those queues is full.
+func (d *Dispatcher) Handle(ctx context.Context, msg indexer.Message) {
+	d.logger.Info("Received message", zap.String("id", msg.ID), zap.String("type", msg.Type))
+
+	evt, err := events.Decode(msg.Type, msg.ContentType, msg.Data)
+	if err != nil {
+		if indexer.DeadLetter(ctx, d.logger, d.deadLettered, msg, err) {
+			d.acks.add(msg.Ack, 0)
+		}
+
+		return
+	}
+
+	// The trace started by the request publishing the event continues while sending it, sources not supporting
+	// headers include it in the event instead.
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		ctx = events.Extract(ctx, evt)
+	}
+
+	ctx, span := otel.Tracer(otelName).Start(ctx, "Dispatcher.Handle")
+	defer span.End()
+
+	webhooks, ok := d.listWebhooks(ctx)
+	if !ok {
+		// Shutting down, not acknowledged so it's delivered again.
+		return
+	}
+
+	d.prune(webhooks)
+
+	// The Trace Context is sent using headers instead, receivers don't need it in the body.
+	evt.TraceParent, evt.TraceState = "", ""
+
+	body, err := events.JSON.Marshal(evt)
+	if err != nil {
+		if indexer.DeadLetter(ctx, d.logger, d.deadLettered, msg, err) {
+			d.acks.add(msg.Ack, 0)
+		}
+
+		return
+	}
+
+	var subscribed []internal.Webhook
+
+	for _, webhook := range webhooks {
+		if webhook.Subscribed(evt.Type) {
+			subscribed = append(subscribed, webhook)
+		}
+	}
+
+	done := d.acks.add(msg.Ack, len(subscribed))
+
+	for _, webhook := range subscribed {
+		if !d.enqueue(delivery{ctx: ctx, webhook: webhook, evt: evt, body: body, done: done}) {
+			// Shutting down, not acknowledged so it's delivered again.
+			return
+		}
+	}
+}
+
+// listWebhooks returns the cached webhooks, or lists them again after Options.WebhooksTTL; the first time it lists
+// them until it succeeds, retrying in place keeps the events in order regardless of the Source. It returns false
+// when shutting down.
+func (d *Dispatcher) listWebhooks(ctx context.Context) ([]internal.Webhook, bool) {
+	if !d.listedAt.IsZero() && time.Since(d.listedAt) < d.opts.WebhooksTTL {
+		return d.webhooks, true
+	}
+
+	backoff := d.opts.Backoff
+
+	for {
+		webhooks, err := d.repo.List(ctx)
+		if err == nil {
+			d.webhooks, d.listedAt = webhooks, time.Now()
+
+			return webhooks, true
+		}
+
+		d.logger.Error("Couldn't list webhooks", zap.Error(err))
+
+		// The cached webhooks are used until listing them succeeds again.
+		if !d.listedAt.IsZero() {
+			return d.webhooks, true
+		}
+
+		if !d.lifecycle.Wait(backoff) {
+			return nil, false
+		}
+
+		backoff = d.opts.Next(backoff)
+	}
+}
+
+// enqueue adds the delivery to the queue of its webhook, waiting while it's full; it returns false when shutting
+// down.
+func (d *Dispatcher) enqueue(del delivery) bool {
+	d.mu.Lock()
+
+	q, ok := d.queues[del.webhook.ID]
+	if !ok {
+		q = &queue{
+			deliveriesC: make(chan delivery, d.opts.QueueSize),
+			doneC:       make(chan struct{}),
+		}
+
+		d.queues[del.webhook.ID] = q
+
+		go d.sendQueued(q)
+	}
+
+	d.mu.Unlock()
+
+	select {
+	case <-d.lifecycle.Context().Done():
+		return false
+	case q.deliveriesC <- del:
+		return true
+	}
+}
+
+// sendQueued sends the events in the queue, in order, until it's closed or shutting down.
+func (d *Dispatcher) sendQueued(q *queue) {
+	defer close(q.doneC)
+
+	for del := range q.deliveriesC {
+		if !d.deliver(del.ctx, del.webhook, del.evt, del.body) {
+			// Shutting down, not acknowledged so it's delivered again.
+			return
+		}
+
+		del.done()
+	}
+}
+
+// closeQueues closes the queues and waits until the events in them are sent, or until shutting down.
+func (d *Dispatcher) closeQueues() {
+	d.mu.Lock()
+	queues := d.queues
+	d.queues = make(map[string]*queue)
+	d.mu.Unlock()
+
+	for _, q := range queues {
+		close(q.deliveriesC)
+	}
+
+	for _, q := range queues {
+		<-q.doneC
+	}
+}
+
+// deliver sends the event to the webhook until it's received, the maximum number of attempts is reached or the
+// circuit breaker is open; it returns false when it's interrupted by shutting down.
+func (d *Dispatcher) deliver(ctx context.Context, webhook internal.Webhook, evt events.Event, body []byte) bool {
+	attrs := metric.WithAttributes(attribute.String("event_type", evt.Type))
+	cb := d.breaker(webhook.ID)
+	backoff := d.opts.Backoff
+
+	for attempt := 1; ; attempt++ {
+		delivery := internal.WebhookDelivery{
+			WebhookID: webhook.ID,
+			EventID:   evt.ID,
+			EventType: evt.Type,
+			Attempt:   attempt,
+		}
+
+		if !cb.Ready() {
+			delivery.Error = "circuit breaker open"
+
+			d.logDelivery(ctx, delivery)
+			d.failed.Add(ctx, 1, attrs)
+
+			return true
+		}
+
+		start := time.Now()
+
+		statusCode, err := d.send(ctx, webhook, evt, body)
+
+		delivery.StatusCode = statusCode
+		delivery.Duration = time.Since(start)
+
+		// The endpoint is available when the request is rejected, it only trips the breaker when failing.
+		retryable := isRetryable(statusCode, err)
+		if err != nil && !retryable {
+			_ = cb.Done(ctx, circuitbreaker.MarkAsSuccess(err))
+		} else {
+			_ = cb.Done(ctx, err)
+		}
+
+		if err != nil {
+			delivery.Error = err.Error()
+		}
+
+		d.logDelivery(ctx, delivery)
+
+		if err == nil {
+			d.delivered.Add(ctx, 1, attrs)
+
+			return true
+		}
+
+		if !retryable || attempt >= d.opts.MaxAttempts {
+			d.logger.Info("Couldn't deliver event",
+				zap.String("webhook", webhook.ID),
+				zap.String("event", evt.ID),
+				zap.Int("attempts", attempt),
+				zap.Error(err))
+
+			d.failed.Add(ctx, 1, attrs)
+
+			return true
+		}
+
+		if !d.lifecycle.Wait(backoff) {
+			return false
+		}
+
+		backoff = d.opts.Next(backoff)
+	}
+}
+
+// send sends the event once, it returns the status code received, or 0 when the request failed before receiving
+// a response.
+func (d *Dispatcher) send(ctx context.Context, webhook internal.Webhook, evt events.Event, body []byte) (int, error) {
+	ctx, span := otel.Tracer(otelName).Start(ctx, "Dispatcher.send")
+	defer span.End()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "http.NewRequestWithContext")
+	}
+
+	timestamp := time.Now().Unix()
+
+	req.Header.Set("Content-Type", events.ContentTypeJSON)
+	req.Header.Set(HeaderSignature, Sign(webhook.Secret, timestamp, body))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderEventID, evt.ID)
+	req.Header.Set(HeaderEventType, evt.Type)
+
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		span.RecordError(err)
+
+		return 0, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "client.Do")
+	}
+	defer res.Body.Close()
+
+	// Draining the body allows reusing the connection.
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		err := internal.NewErrorf(internal.ErrorCodeUnknown, "unexpected status code %d", res.StatusCode)
+
+		span.RecordError(err)
+
+		return res.StatusCode, err
+	}
+
+	return res.StatusCode, nil
+}
+
+// isRetryable indicates whether the request may succeed if it's sent again, requests that can't be sent, like the
+// ones to non-public addresses, are not.
+func isRetryable(statusCode int, err error) bool {
+	var ierr *internal.Error
+	if errors.As(err, &ierr) && ierr.Code() == internal.ErrorCodeInvalidArgument {
+		return false
+	}
+
+	switch {
+	case statusCode == 0,
+		statusCode == http.StatusRequestTimeout,
+		statusCode == http.StatusTooManyRequests,
+		statusCode >= http.StatusInternalServerError:
+		return true
+	}
+
+	return false
+}
+
+func (d *Dispatcher) logDelivery(ctx context.Context, delivery internal.WebhookDelivery) {
+	// XXX: The delivery log is best effort, failing to log it doesn't affect sending the event.
+	if err := d.repo.LogDelivery(context.WithoutCancel(ctx), delivery); err != nil {
+		d.logger.Error("Couldn't log delivery", zap.String("webhook", delivery.WebhookID), zap.Error(err))
+	}
+}
+
+// breaker returns the circuit breaker of the webhook.
+func (d *Dispatcher) breaker(id string) *circuitbreaker.CircuitBreaker {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	cb, ok := d.breakers[id]
+	if !ok {
+		cb = circuitbreaker.New(
+			circuitbreaker.WithOpenTimeout(d.opts.BreakerOpenTimeout),
+			circuitbreaker.WithTripFunc(circuitbreaker.NewTripFuncConsecutiveFailures(d.opts.BreakerFailures)),
+			circuitbreaker.WithOnStateChangeHookFn(func(oldState, newState circuitbreaker.State) {
+				d.logger.Info("state changed",
+					zap.String("webhook", id),
+					zap.String("old", string(oldState)),
+					zap.String("new", string(newState)),
+				)
+			}),
+		)
+
+		d.breakers[id] = cb
+	}
+
+	return cb
+}
+
+// prune removes the circuit breakers of the deleted webhooks, and closes their queues once the events in them
+// are sent.
+func (d *Dispatcher) prune(webhooks []internal.Webhook) {
+	ids := make(map[string]struct{}, len(webhooks))
+
+	for _, webhook := range webhooks {
+		ids[webhook.ID] = struct{}{}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for id := range d.breakers {
+		if _, ok := ids[id]; !ok {
+			delete(d.breakers, id)
+		}
+	}
+
+	for id, q := range d.queues {
+		if _, ok := ids[id]; !ok {
+			close(q.deliveriesC)
+			delete(d.queues, id)
+		}
+	}
+}
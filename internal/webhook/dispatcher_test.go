@@ -0,0 +1,500 @@
+package webhook_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/events"
+	"github.com/MarioCarrion/todo-api/internal/indexer"
+	"github.com/MarioCarrion/todo-api/internal/webhook"
+	"github.com/MarioCarrion/todo-api/internal/worker"
+)
+
+const secret = "0123456789abcdef"
+
+func TestDispatcher_Handle(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		statusCodes []int
+		expected    []int
+	}{
+		{
+			"OK",
+			[]int{http.StatusOK},
+			[]int{http.StatusOK},
+		},
+		{
+			"OK: retried",
+			[]int{http.StatusInternalServerError, http.StatusTooManyRequests, http.StatusNoContent},
+			[]int{http.StatusInternalServerError, http.StatusTooManyRequests, http.StatusNoContent},
+		},
+		{
+			"ERR: rejected",
+			[]int{http.StatusBadRequest, http.StatusOK},
+			[]int{http.StatusBadRequest},
+		},
+		{
+			"ERR: max attempts",
+			[]int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway, http.StatusOK},
+			[]int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			receiver := newReceiver(t, tt.statusCodes...)
+
+			repo := &fakeRepository{
+				webhooks: []internal.Webhook{
+					{ID: "subscribed", URL: receiver.URL, EventTypes: []string{events.TypeTaskCreated}, Secret: secret},
+					{ID: "unsubscribed", URL: receiver.URL, EventTypes: []string{events.TypeTaskDeleted}, Secret: secret},
+				},
+			}
+
+			dispatcher := webhook.NewDispatcher(zap.NewNop(), nil, repo, receiver.Client(), webhook.Options{
+				RetryOptions:    worker.RetryOptions{MaxAttempts: 3, Backoff: time.Millisecond},
+				BreakerFailures: 10,
+			})
+
+			msg, acked := newMessage(t, events.TypeTaskCreated, internal.Task{ID: "1", Description: "created"})
+
+			dispatcher.Handle(context.Background(), msg)
+
+			waitAcked(t, acked)
+
+			if actual := receiver.received.Load(); actual != int64(len(tt.expected)) {
+				t.Fatalf("expected %d requests, got %d", len(tt.expected), actual)
+			}
+
+			if actual := repo.statusCodes("subscribed"); !cmp.Equal(tt.expected, actual) {
+				t.Fatalf("expected deliveries don't match: %s", cmp.Diff(tt.expected, actual))
+			}
+		})
+	}
+}
+
+func TestDispatcher_Handle_CircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	receiver := newReceiver(t, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK)
+
+	repo := &fakeRepository{
+		webhooks: []internal.Webhook{
+			{ID: "unavailable", URL: receiver.URL, Secret: secret},
+		},
+	}
+
+	dispatcher := webhook.NewDispatcher(zap.NewNop(), nil, repo, receiver.Client(), webhook.Options{
+		RetryOptions:       worker.RetryOptions{MaxAttempts: 5, Backoff: time.Millisecond},
+		BreakerFailures:    2,
+		BreakerOpenTimeout: time.Hour,
+	})
+
+	for _, typ := range []string{events.TypeTaskCreated, events.TypeTaskUpdated} {
+		msg, acked := newMessage(t, typ, internal.Task{ID: "1"})
+
+		dispatcher.Handle(context.Background(), msg)
+
+		waitAcked(t, acked)
+	}
+
+	// The breaker opens after the second failure, so the rest of the attempts and events are not sent.
+	if actual := receiver.received.Load(); actual != 2 {
+		t.Fatalf("expected 2 requests, got %d", actual)
+	}
+
+	expected := []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, 0, 0}
+
+	if actual := repo.statusCodes("unavailable"); !cmp.Equal(expected, actual) {
+		t.Fatalf("expected deliveries don't match: %s", cmp.Diff(expected, actual))
+	}
+}
+
+func TestDispatcher_Handle_DeadLetter(t *testing.T) {
+	t.Parallel()
+
+	var deadLettered, acked atomic.Bool
+
+	dispatcher := webhook.NewDispatcher(zap.NewNop(), nil, &fakeRepository{}, http.DefaultClient, webhook.Options{})
+
+	dispatcher.Handle(context.Background(), indexer.Message{
+		ID:          "invalid",
+		ContentType: events.ContentTypeJSON,
+		Data:        []byte("{"),
+		Ack:         func() { acked.Store(true) },
+		DeadLetter: func(error) error {
+			deadLettered.Store(true)
+
+			return nil
+		},
+	})
+
+	if !deadLettered.Load() || !acked.Load() {
+		t.Fatalf("expected message to be dead-lettered and acknowledged")
+	}
+}
+
+func TestDispatcher_Handle_Transport(t *testing.T) {
+	t.Parallel()
+
+	receiver := newReceiver(t, http.StatusOK)
+
+	tests := []struct {
+		name string
+		url  string
+	}{
+		{
+			"ERR: loopback address",
+			receiver.URL,
+		},
+		{
+			"ERR: hostname resolving to loopback address",
+			strings.Replace(receiver.URL, "127.0.0.1", "localhost", 1),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := &fakeRepository{
+				webhooks: []internal.Webhook{
+					{ID: "internal", URL: tt.url, Secret: secret},
+				},
+			}
+
+			client := &http.Client{Transport: webhook.NewTransport(), Timeout: time.Second}
+
+			dispatcher := webhook.NewDispatcher(zap.NewNop(), nil, repo, client, webhook.Options{
+				RetryOptions: worker.RetryOptions{Backoff: time.Millisecond},
+			})
+
+			msg, acked := newMessage(t, events.TypeTaskCreated, internal.Task{ID: "1"})
+
+			dispatcher.Handle(context.Background(), msg)
+
+			waitAcked(t, acked)
+
+			if actual := receiver.received.Load(); actual != 0 {
+				t.Fatalf("expected no requests, got %d", actual)
+			}
+
+			// Requests to internal addresses are not sent again.
+			if actual := repo.statusCodes("internal"); !cmp.Equal([]int{0}, actual) {
+				t.Fatalf("expected one failed delivery, got %v", actual)
+			}
+		})
+	}
+}
+
+func TestDispatcher_Handle_SlowWebhook(t *testing.T) {
+	t.Parallel()
+
+	releaseC := make(chan struct{})
+
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		<-releaseC
+
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	t.Cleanup(slow.Close)
+	t.Cleanup(func() {
+		select {
+		case <-releaseC:
+		default:
+			close(releaseC)
+		}
+	})
+
+	fast := newReceiver(t, http.StatusOK)
+
+	repo := &fakeRepository{
+		webhooks: []internal.Webhook{
+			{ID: "slow", URL: slow.URL, Secret: secret},
+			{ID: "fast", URL: fast.URL, Secret: secret},
+		},
+	}
+
+	dispatcher := webhook.NewDispatcher(zap.NewNop(), nil, repo, slow.Client(), webhook.Options{})
+
+	var (
+		mu    sync.Mutex
+		acked []string
+	)
+
+	for _, id := range []string{"1", "2"} {
+		msg, _ := newMessage(t, events.TypeTaskCreated, internal.Task{ID: id})
+		msg.Ack = func() {
+			mu.Lock()
+			defer mu.Unlock()
+
+			acked = append(acked, id)
+		}
+
+		dispatcher.Handle(context.Background(), msg)
+	}
+
+	// The slow webhook doesn't delay the rest.
+	for start := time.Now(); fast.received.Load() < 2; time.Sleep(time.Millisecond) {
+		if time.Since(start) > 5*time.Second {
+			t.Fatalf("expected events to be sent to the fast webhook, got %d", fast.received.Load())
+		}
+	}
+
+	mu.Lock()
+	if len(acked) != 0 {
+		t.Fatalf("expected messages not to be acknowledged before the slow webhook receives them, got %v", acked)
+	}
+	mu.Unlock()
+
+	close(releaseC)
+
+	for start := time.Now(); ; time.Sleep(time.Millisecond) {
+		mu.Lock()
+		actual := append([]string{}, acked...)
+		mu.Unlock()
+
+		if len(actual) == 2 {
+			if !cmp.Equal([]string{"1", "2"}, actual) {
+				t.Fatalf("expected messages to be acknowledged in order, got %v", actual)
+			}
+
+			break
+		}
+
+		if time.Since(start) > 5*time.Second {
+			t.Fatalf("expected messages to be acknowledged, got %v", actual)
+		}
+	}
+}
+
+func TestDispatcher_Handle_Webhooks(t *testing.T) {
+	t.Parallel()
+
+	receiver := newReceiver(t, http.StatusOK)
+
+	repo := &fakeRepository{
+		webhooks: []internal.Webhook{
+			{ID: "subscribed", URL: receiver.URL, Secret: secret},
+		},
+	}
+
+	dispatcher := webhook.NewDispatcher(zap.NewNop(), nil, repo, receiver.Client(), webhook.Options{
+		WebhooksTTL: 50 * time.Millisecond,
+	})
+
+	handle := func(id string) {
+		t.Helper()
+
+		msg, acked := newMessage(t, events.TypeTaskCreated, internal.Task{ID: id})
+
+		dispatcher.Handle(context.Background(), msg)
+
+		waitAcked(t, acked)
+	}
+
+	handle("1")
+	handle("2")
+
+	if actual := repo.lists.Load(); actual != 1 {
+		t.Fatalf("expected webhooks to be cached, listed %d times", actual)
+	}
+
+	// The cached webhooks are used when listing them again fails.
+	repo.listErr.Store(true)
+
+	time.Sleep(100 * time.Millisecond)
+
+	handle("3")
+
+	if actual := receiver.received.Load(); actual != 3 {
+		t.Fatalf("expected 3 requests, got %d", actual)
+	}
+
+	if actual := repo.lists.Load(); actual != 2 {
+		t.Fatalf("expected webhooks to be listed again, listed %d times", actual)
+	}
+}
+
+func TestDispatcher_Shutdown(t *testing.T) {
+	t.Parallel()
+
+	receiver := newReceiver(t, http.StatusInternalServerError)
+
+	repo := &fakeRepository{
+		webhooks: []internal.Webhook{
+			{ID: "failing", URL: receiver.URL, Secret: secret},
+		},
+	}
+
+	dispatcher := webhook.NewDispatcher(zap.NewNop(), nil, repo, receiver.Client(), webhook.Options{
+		RetryOptions: worker.RetryOptions{Backoff: time.Hour},
+	})
+
+	msg, acked := newMessage(t, events.TypeTaskCreated, internal.Task{ID: "1"})
+
+	doneC := make(chan struct{})
+
+	go func() {
+		defer close(doneC)
+
+		dispatcher.Handle(context.Background(), msg)
+	}()
+
+	for receiver.received.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := dispatcher.Shutdown(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	<-doneC
+
+	if acked.Load() {
+		t.Fatalf("expected message not to be acknowledged")
+	}
+}
+
+//-
+
+type receiver struct {
+	*httptest.Server
+	received atomic.Int64
+}
+
+// newReceiver starts a server verifying the signature of the requests, it responds using the status codes in
+// order, repeating the last one.
+func newReceiver(t *testing.T, statusCodes ...int) *receiver {
+	t.Helper()
+
+	rec := &receiver{}
+
+	rec.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		i := int(rec.received.Add(1)) - 1
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("couldn't read body %s", err)
+		}
+
+		timestamp, err := strconv.ParseInt(r.Header.Get(webhook.HeaderTimestamp), 10, 64)
+		if err != nil {
+			t.Errorf("invalid timestamp %s", err)
+		}
+
+		if !webhook.Verify(secret, timestamp, body, r.Header.Get(webhook.HeaderSignature)) {
+			t.Errorf("invalid signature")
+		}
+
+		evt, err := events.JSON.Unmarshal(body)
+		if err != nil {
+			t.Errorf("couldn't decode event %s", err)
+		}
+
+		if evt.ID != r.Header.Get(webhook.HeaderEventID) || evt.Type != r.Header.Get(webhook.HeaderEventType) {
+			t.Errorf("expected headers to match event, got %v", r.Header)
+		}
+
+		w.WriteHeader(statusCodes[min(i, len(statusCodes)-1)])
+	}))
+
+	t.Cleanup(rec.Close)
+
+	return rec
+}
+
+type fakeRepository struct {
+	webhooks []internal.Webhook
+	lists    atomic.Int64
+	listErr  atomic.Bool
+
+	mu         sync.Mutex
+	deliveries []internal.WebhookDelivery
+}
+
+func (f *fakeRepository) List(_ context.Context) ([]internal.Webhook, error) {
+	f.lists.Add(1)
+
+	if f.listErr.Load() {
+		return nil, errors.New("unavailable")
+	}
+
+	return f.webhooks, nil
+}
+
+func (f *fakeRepository) LogDelivery(_ context.Context, delivery internal.WebhookDelivery) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.deliveries = append(f.deliveries, delivery)
+
+	return nil
+}
+
+func (f *fakeRepository) statusCodes(webhookID string) []int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var res []int
+
+	for _, delivery := range f.deliveries {
+		if delivery.WebhookID == webhookID {
+			res = append(res, delivery.StatusCode)
+		}
+	}
+
+	return res
+}
+
+// waitAcked waits until the message is acknowledged, events are sent asynchronously.
+func waitAcked(t *testing.T, acked *atomic.Bool) {
+	t.Helper()
+
+	for start := time.Now(); !acked.Load(); time.Sleep(time.Millisecond) {
+		if time.Since(start) > 5*time.Second {
+			t.Fatalf("expected message to be acknowledged")
+		}
+	}
+}
+
+func newMessage(t *testing.T, typ string, task internal.Task) (indexer.Message, *atomic.Bool) {
+	t.Helper()
+
+	data, err := events.JSON.Marshal(events.NewTaskEvent(typ, task))
+	if err != nil {
+		t.Fatalf("couldn't marshal event %s", err)
+	}
+
+	acked := &atomic.Bool{}
+
+	return indexer.Message{
+		ID:          typ,
+		Type:        typ,
+		ContentType: events.ContentTypeJSON,
+		Data:        data,
+		Ack:         func() { acked.Store(true) },
+	}, acked
+}
@@ -0,0 +1,64 @@
+package webhook
+
+import (
+	"context"
+	"sync"
+
+	"github.com/MarioCarrion/todo-api/internal"
+	"github.com/MarioCarrion/todo-api/internal/events"
+)
+
+// delivery is an event waiting to be sent to a Webhook.
+type delivery struct {
+	ctx     context.Context //nolint: containedctx
+	webhook internal.Webhook
+	evt     events.Event
+	body    []byte
+	// done is called after the Webhook received the event or the Dispatcher gave up.
+	done func()
+}
+
+// queue holds the events waiting to be sent to one Webhook, they are sent in order by its own goroutine.
+type queue struct {
+	deliveriesC chan delivery
+	doneC       chan struct{}
+}
+
+// acker acknowledges the messages in the order they were received, once all their deliveries are done.
+type acker struct {
+	mu      sync.Mutex
+	pending []*pendingMessage
+}
+
+type pendingMessage struct {
+	ack       func()
+	remaining int
+}
+
+// add adds the message waiting for the number of deliveries, the returned function is called after each of them.
+func (a *acker) add(ack func(), deliveries int) func() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	msg := &pendingMessage{ack: ack, remaining: deliveries}
+
+	a.pending = append(a.pending, msg)
+	a.flush()
+
+	return func() {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+
+		msg.remaining--
+
+		a.flush()
+	}
+}
+
+// flush acknowledges the messages done in order, it must be called holding the lock.
+func (a *acker) flush() {
+	for len(a.pending) > 0 && a.pending[0].remaining <= 0 {
+		a.pending[0].ack()
+		a.pending = a.pending[1:]
+	}
+}
@@ -0,0 +1,50 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+)
+
+const (
+	// HeaderSignature is the header including the signature of the request, see Sign.
+	HeaderSignature = "X-Webhook-Signature"
+
+	// HeaderTimestamp is the header including the Unix time, in seconds, when the request was signed.
+	HeaderTimestamp = "X-Webhook-Timestamp"
+
+	// HeaderEventID is the header including the ID of the event, it's the same for all the attempts so receivers
+	// can use it for ignoring events already handled.
+	HeaderEventID = "X-Webhook-Id"
+
+	// HeaderEventType is the header including the type of the event, for example "tasks.event.created".
+	HeaderEventType = "X-Webhook-Event"
+
+	signaturePrefix = "sha256="
+)
+
+// Sign returns the value of HeaderSignature: the hex-encoded HMAC-SHA256 of the timestamp and the body, separated
+// by a dot, using the secret of the Webhook as key; for example "sha256=5257a869...".
+//
+// Including the timestamp allows receivers to reject old requests being replayed.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+
+	_, _ = mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	_, _ = mac.Write([]byte("."))
+	_, _ = mac.Write(body)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify indicates whether signature, the value of HeaderSignature, matches the timestamp and the body; it's
+// meant to be used by receivers.
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
@@ -0,0 +1,47 @@
+package webhook_test
+
+import (
+	"testing"
+
+	"github.com/MarioCarrion/todo-api/internal/webhook"
+)
+
+func TestSign(t *testing.T) {
+	t.Parallel()
+
+	body := []byte(`{"id":"1"}`)
+
+	// echo -n '1700000000.{"id":"1"}' | openssl dgst -sha256 -hmac 0123456789abcdef
+	signature := "sha256=d5f5834972cbc6cf5590800c46ccaa0cd6c16f19c0c73dbdf9b4c56390cbc2a3"
+
+	if actual := webhook.Sign(secret, 1700000000, body); actual != signature {
+		t.Fatalf("expected %s, got %s", signature, actual)
+	}
+
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp int64
+		body      []byte
+		signature string
+		expected  bool
+	}{
+		{"OK", secret, 1700000000, body, signature, true},
+		{"ERR: secret", "fedcba9876543210", 1700000000, body, signature, false},
+		{"ERR: timestamp", secret, 1700000001, body, signature, false},
+		{"ERR: body", secret, 1700000000, []byte(`{"id":"2"}`), signature, false},
+		{"ERR: prefix", secret, 1700000000, body, signature[len("sha256="):], false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if actual := webhook.Verify(tt.secret, tt.timestamp, tt.body, tt.signature); actual != tt.expected {
+				t.Fatalf("expected %t, got %t", tt.expected, actual)
+			}
+		})
+	}
+}
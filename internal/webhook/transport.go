@@ -0,0 +1,44 @@
+package webhook
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+
+	"github.com/MarioCarrion/todo-api/internal"
+)
+
+// NewTransport instantiates the transport used by the Dispatcher for sending the requests, it only connects to
+// public addresses; see "internal.ValidateWebhookAddr".
+//
+// Addresses are checked after resolving the hostnames, right before connecting, so Webhooks can't reach internal
+// services by resolving to them after being created. Proxies are not used for the same reason.
+func NewTransport() *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   dialControl,
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone() //nolint: forcetypeassert
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return transport
+}
+
+// dialControl rejects connecting to non-public addresses, it implements "net.Dialer.Control".
+func dialControl(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "netip.ParseAddrPort")
+	}
+
+	if err := internal.ValidateWebhookAddr(addrPort.Addr()); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "address %s", address)
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package internal_test
+
+import (
+	"errors"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/MarioCarrion/todo-api/internal"
+)
+
+func TestCreateWebhookParams_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   internal.CreateWebhookParams
+		withErr bool
+	}{
+		{
+			"OK",
+			internal.CreateWebhookParams{
+				URL:        "https://example.com/hooks",
+				EventTypes: []string{"tasks.event.created", "tasks.event.deleted"},
+				Secret:     "0123456789abcdef",
+			},
+			false,
+		},
+		{
+			"OK: all event types",
+			internal.CreateWebhookParams{
+				URL:    "http://203.0.113.10:8080",
+				Secret: "0123456789abcdef",
+			},
+			false,
+		},
+		{
+			"ERR: empty",
+			internal.CreateWebhookParams{},
+			true,
+		},
+		{
+			"ERR: relative URL",
+			internal.CreateWebhookParams{
+				URL:    "/hooks",
+				Secret: "0123456789abcdef",
+			},
+			true,
+		},
+		{
+			"ERR: unknown scheme",
+			internal.CreateWebhookParams{
+				URL:    "ftp://example.com",
+				Secret: "0123456789abcdef",
+			},
+			true,
+		},
+		{
+			"ERR: localhost URL",
+			internal.CreateWebhookParams{
+				URL:    "http://localhost:8080",
+				Secret: "0123456789abcdef",
+			},
+			true,
+		},
+		{
+			"ERR: loopback URL",
+			internal.CreateWebhookParams{
+				URL:    "http://127.0.0.1/hooks",
+				Secret: "0123456789abcdef",
+			},
+			true,
+		},
+		{
+			"ERR: IPv6 loopback URL",
+			internal.CreateWebhookParams{
+				URL:    "http://[::1]/hooks",
+				Secret: "0123456789abcdef",
+			},
+			true,
+		},
+		{
+			"ERR: IPv4-mapped loopback URL",
+			internal.CreateWebhookParams{
+				URL:    "http://[::ffff:127.0.0.1]/hooks",
+				Secret: "0123456789abcdef",
+			},
+			true,
+		},
+		{
+			"ERR: private URL",
+			internal.CreateWebhookParams{
+				URL:    "https://10.0.0.1/hooks",
+				Secret: "0123456789abcdef",
+			},
+			true,
+		},
+		{
+			"ERR: link-local URL",
+			internal.CreateWebhookParams{
+				URL:    "http://169.254.169.254/latest/meta-data",
+				Secret: "0123456789abcdef",
+			},
+			true,
+		},
+		{
+			"ERR: unspecified URL",
+			internal.CreateWebhookParams{
+				URL:    "http://0.0.0.0/hooks",
+				Secret: "0123456789abcdef",
+			},
+			true,
+		},
+		{
+			"ERR: invalid event type",
+			internal.CreateWebhookParams{
+				URL:        "https://example.com/hooks",
+				EventTypes: []string{"created"},
+				Secret:     "0123456789abcdef",
+			},
+			true,
+		},
+		{
+			"ERR: short secret",
+			internal.CreateWebhookParams{
+				URL:    "https://example.com/hooks",
+				Secret: "secret",
+			},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			actualErr := tt.input.Validate()
+			if (actualErr != nil) != tt.withErr {
+				t.Fatalf("expected error %t, got %s", tt.withErr, actualErr)
+			}
+
+			var ierr validation.Errors
+			if tt.withErr && !errors.As(actualErr, &ierr) {
+				t.Fatalf("expected %T error, got %T", ierr, actualErr)
+			}
+		})
+	}
+}
+
+func TestWebhook_Subscribed(t *testing.T) {
+	t.Parallel()
+
+	all := internal.Webhook{}
+	some := internal.Webhook{EventTypes: []string{"tasks.event.created"}}
+
+	if !all.Subscribed("tasks.event.updated") {
+		t.Fatalf("expected subscribed to all the event types")
+	}
+
+	if !some.Subscribed("tasks.event.created") || some.Subscribed("tasks.event.updated") {
+		t.Fatalf("expected subscribed only to %v", some.EventTypes)
+	}
+}
@@ -0,0 +1,150 @@
+// Package worker implements the helpers shared by the workers running until they are shut down, like the indexer
+// Consumer, the webhook Dispatcher, the Notifier and the reminder Scheduler.
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/MarioCarrion/todo-api/internal"
+)
+
+// NewCounter instantiates a counter using meter, errors are handled by the global OpenTelemetry handler.
+func NewCounter(meter metric.Meter, name, description string) metric.Int64Counter {
+	counter, err := meter.Int64Counter(name, metric.WithDescription(description))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return counter
+}
+
+// RetryOptions defines how failed operations are retried using exponential backoff, zero values use the defaults.
+type RetryOptions struct {
+	// MaxAttempts is the number of attempts before giving up, defaults to 5.
+	MaxAttempts int
+	// Backoff is the time waited before trying again, it doubles after every attempt; defaults to 1s.
+	Backoff time.Duration
+	// MaxBackoff is the maximum time waited between attempts, defaults to 30s.
+	MaxBackoff time.Duration
+}
+
+// WithDefaults returns the options using the defaults instead of the zero values.
+func (o RetryOptions) WithDefaults() RetryOptions {
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = 5
+	}
+
+	if o.Backoff <= 0 {
+		o.Backoff = time.Second
+	}
+
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = 30 * time.Second
+	}
+
+	return o
+}
+
+// Next returns the backoff following the received one.
+func (o RetryOptions) Next(backoff time.Duration) time.Duration {
+	return min(2*backoff, o.MaxBackoff)
+}
+
+// Lifecycle implements the lifecycle shared by the workers: the worker is healthy while running, and shutting it
+// down cancels its context and waits for it to stop.
+type Lifecycle struct {
+	ctx    context.Context //nolint: containedctx
+	cancel context.CancelFunc
+	doneC  chan struct{}
+
+	mu      sync.Mutex
+	running bool
+}
+
+// NewLifecycle instantiates the Lifecycle.
+func NewLifecycle() *Lifecycle {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Lifecycle{
+		ctx:    ctx,
+		cancel: cancel,
+		doneC:  make(chan struct{}),
+	}
+}
+
+// Context returns the context of the worker, it's done once Stop is called.
+func (l *Lifecycle) Context() context.Context {
+	return l.ctx
+}
+
+// Start indicates the worker is running, the returned function must be called once it stops.
+func (l *Lifecycle) Start() func() {
+	l.mu.Lock()
+	l.running = true
+	l.mu.Unlock()
+
+	return func() {
+		l.mu.Lock()
+		l.running = false
+		l.mu.Unlock()
+
+		close(l.doneC)
+	}
+}
+
+// Stop cancels the context of the worker and waits for it to stop, unless it's not running yet.
+func (l *Lifecycle) Stop(ctx context.Context) error {
+	l.mu.Lock()
+	running := l.running
+	l.running = false
+	l.mu.Unlock()
+
+	l.cancel()
+
+	if !running {
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return internal.WrapErrorf(ctx.Err(), internal.ErrorCodeUnknown, "context.Done")
+	case <-l.doneC:
+	}
+
+	return nil
+}
+
+// Running indicates whether the worker is running.
+func (l *Lifecycle) Running() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.running
+}
+
+// Health returns an error when the worker is not running.
+func (l *Lifecycle) Health() error {
+	if !l.Running() {
+		return internal.NewErrorf(internal.ErrorCodeUnknown, "not running")
+	}
+
+	return nil
+}
+
+// Wait waits for d, it returns false when the worker is stopped meanwhile.
+func (l *Lifecycle) Wait(d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-l.ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
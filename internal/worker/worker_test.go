@@ -0,0 +1,121 @@
+package worker_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/MarioCarrion/todo-api/internal/worker"
+)
+
+func TestRetryOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    worker.RetryOptions
+		expected worker.RetryOptions
+	}{
+		{
+			"OK: defaults",
+			worker.RetryOptions{},
+			worker.RetryOptions{MaxAttempts: 5, Backoff: time.Second, MaxBackoff: 30 * time.Second},
+		},
+		{
+			"OK: defined",
+			worker.RetryOptions{MaxAttempts: 2, Backoff: time.Millisecond, MaxBackoff: time.Minute},
+			worker.RetryOptions{MaxAttempts: 2, Backoff: time.Millisecond, MaxBackoff: time.Minute},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if actual := tt.input.WithDefaults(); actual != tt.expected {
+				t.Fatalf("expected %+v, got %+v", tt.expected, actual)
+			}
+		})
+	}
+
+	opts := worker.RetryOptions{}.WithDefaults()
+
+	if actual := opts.Next(time.Second); actual != 2*time.Second {
+		t.Fatalf("expected backoff to double, got %s", actual)
+	}
+
+	if actual := opts.Next(20 * time.Second); actual != 30*time.Second {
+		t.Fatalf("expected maximum backoff, got %s", actual)
+	}
+}
+
+func TestLifecycle(t *testing.T) {
+	t.Parallel()
+
+	lifecycle := worker.NewLifecycle()
+
+	if err := lifecycle.Health(); err == nil {
+		t.Fatalf("expected error before starting")
+	}
+
+	done := lifecycle.Start()
+
+	if err := lifecycle.Health(); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	stoppedC := make(chan error, 1)
+
+	go func() {
+		stoppedC <- lifecycle.Stop(context.Background())
+	}()
+
+	// Stopping cancels the context and waits until the worker is done.
+	<-lifecycle.Context().Done()
+
+	if lifecycle.Wait(time.Hour) {
+		t.Fatalf("expected waiting to be interrupted")
+	}
+
+	select {
+	case <-stoppedC:
+		t.Fatalf("expected Stop to wait for the worker")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	done()
+
+	if err := <-stoppedC; err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if err := lifecycle.Health(); err == nil {
+		t.Fatalf("expected error after stopping")
+	}
+}
+
+func TestLifecycle_Stop(t *testing.T) {
+	t.Parallel()
+
+	t.Run("OK: not running", func(t *testing.T) {
+		t.Parallel()
+
+		if err := worker.NewLifecycle().Stop(context.Background()); err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+	})
+
+	t.Run("ERR: timeout", func(t *testing.T) {
+		t.Parallel()
+
+		lifecycle := worker.NewLifecycle()
+		_ = lifecycle.Start()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		if err := lifecycle.Stop(ctx); err == nil {
+			t.Fatalf("expected error")
+		}
+	})
+}
@@ -17,12 +17,39 @@ const (
 	None   Priority = "none"
 )
 
+// Defines values for ReadCalendarParamsComponent.
+const (
+	Vevent ReadCalendarParamsComponent = "vevent"
+	Vtodo  ReadCalendarParamsComponent = "vtodo"
+)
+
+// Defines values for ExportTasksParamsFormat.
+const (
+	ExportTasksParamsFormatCsv    ExportTasksParamsFormat = "csv"
+	ExportTasksParamsFormatNdjson ExportTasksParamsFormat = "ndjson"
+)
+
+// Defines values for UpsertTasksParamsFormat.
+const (
+	UpsertTasksParamsFormatCsv    UpsertTasksParamsFormat = "csv"
+	UpsertTasksParamsFormatNdjson UpsertTasksParamsFormat = "ndjson"
+)
+
 // Dates defines model for Dates.
 type Dates struct {
 	Due   *time.Time `json:"due"`
 	Start *time.Time `json:"start"`
 }
 
+// NotificationPreference defines model for NotificationPreference.
+type NotificationPreference struct {
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+	Digest     *bool      `json:"digest,omitempty"`
+	Email      *string    `json:"email,omitempty"`
+	EventTypes *[]string  `json:"event_types,omitempty"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty"`
+}
+
 // Priority defines model for Priority.
 type Priority string
 
@@ -35,32 +62,123 @@ type Task struct {
 	Priority    *Priority           `json:"priority,omitempty"`
 }
 
+// Webhook defines model for Webhook.
+type Webhook struct {
+	CreatedAt  *time.Time          `json:"created_at,omitempty"`
+	EventTypes *[]string           `json:"event_types,omitempty"`
+	Id         *openapi_types.UUID `json:"id,omitempty"`
+	Url        *string             `json:"url,omitempty"`
+}
+
+// WebhookDelivery defines model for WebhookDelivery.
+type WebhookDelivery struct {
+	Attempt    *int64              `json:"attempt,omitempty"`
+	CreatedAt  *time.Time          `json:"created_at,omitempty"`
+	DurationMs *int64              `json:"duration_ms,omitempty"`
+	Error      *string             `json:"error,omitempty"`
+	EventId    *string             `json:"event_id,omitempty"`
+	EventType  *string             `json:"event_type,omitempty"`
+	Id         *openapi_types.UUID `json:"id,omitempty"`
+	StatusCode *int64              `json:"status_code,omitempty"`
+}
+
 // CreateTasksResponse defines model for CreateTasksResponse.
 type CreateTasksResponse struct {
 	Task *Task `json:"task,omitempty"`
 }
 
+// CreateWebhooksResponse defines model for CreateWebhooksResponse.
+type CreateWebhooksResponse struct {
+	Webhook *Webhook `json:"webhook,omitempty"`
+}
+
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse struct {
 	Error *string `json:"error,omitempty"`
 }
 
+// ImportCalendarResponse defines model for ImportCalendarResponse.
+type ImportCalendarResponse struct {
+	Tasks *[]Task `json:"tasks,omitempty"`
+}
+
+// ImportTasksResponse defines model for ImportTasksResponse.
+type ImportTasksResponse struct {
+	Created *int64 `json:"created,omitempty"`
+	DryRun  *bool  `json:"dry_run,omitempty"`
+	Errors  *[]struct {
+		Error       *string            `json:"error,omitempty"`
+		ExternalId  *string            `json:"external_id,omitempty"`
+		Row         *int               `json:"row,omitempty"`
+		Validations *map[string]string `json:"validations,omitempty"`
+	} `json:"errors,omitempty"`
+	Unchanged *int64 `json:"unchanged,omitempty"`
+	Updated   *int64 `json:"updated,omitempty"`
+}
+
+// ListNotificationPreferencesResponse defines model for ListNotificationPreferencesResponse.
+type ListNotificationPreferencesResponse struct {
+	Preferences *[]NotificationPreference `json:"preferences,omitempty"`
+}
+
+// ListWebhooksResponse defines model for ListWebhooksResponse.
+type ListWebhooksResponse struct {
+	Webhooks *[]Webhook `json:"webhooks,omitempty"`
+}
+
+// ReadNotificationPreferencesResponse defines model for ReadNotificationPreferencesResponse.
+type ReadNotificationPreferencesResponse struct {
+	Preference *NotificationPreference `json:"preference,omitempty"`
+}
+
 // ReadTasksResponse defines model for ReadTasksResponse.
 type ReadTasksResponse struct {
 	Task *Task `json:"task,omitempty"`
 }
 
+// ReadWebhookDeliveriesResponse defines model for ReadWebhookDeliveriesResponse.
+type ReadWebhookDeliveriesResponse struct {
+	Deliveries *[]WebhookDelivery `json:"deliveries,omitempty"`
+}
+
+// ReadWebhooksResponse defines model for ReadWebhooksResponse.
+type ReadWebhooksResponse struct {
+	Webhook *Webhook `json:"webhook,omitempty"`
+}
+
 // SearchTasksResponse defines model for SearchTasksResponse.
 type SearchTasksResponse struct {
 	Tasks *[]Task `json:"tasks,omitempty"`
 	Total *int64  `json:"total,omitempty"`
 }
 
+// SuggestTasksResponse defines model for SuggestTasksResponse.
+type SuggestTasksResponse struct {
+	Suggestions *[]struct {
+		Description *string             `json:"description,omitempty"`
+		Id          *openapi_types.UUID `json:"id,omitempty"`
+	} `json:"suggestions,omitempty"`
+}
+
 // CreateTasksRequest defines model for CreateTasksRequest.
 type CreateTasksRequest struct {
 	Dates       *Dates    `json:"dates,omitempty"`
 	Description *string   `json:"description,omitempty"`
 	Priority    *Priority `json:"priority,omitempty"`
+	Reminders   *[]string `json:"reminders,omitempty"`
+}
+
+// CreateWebhooksRequest defines model for CreateWebhooksRequest.
+type CreateWebhooksRequest struct {
+	EventTypes *[]string `json:"event_types,omitempty"`
+	Secret     *string   `json:"secret,omitempty"`
+	Url        *string   `json:"url,omitempty"`
+}
+
+// SaveNotificationPreferencesRequest defines model for SaveNotificationPreferencesRequest.
+type SaveNotificationPreferencesRequest struct {
+	Digest     *bool     `json:"digest,omitempty"`
+	EventTypes *[]string `json:"event_types,omitempty"`
 }
 
 // SearchTasksRequest defines model for SearchTasksRequest.
@@ -80,6 +198,50 @@ type UpdateTasksRequest struct {
 	Priority    *Priority `json:"priority,omitempty"`
 }
 
+// ReadCalendarParams defines parameters for ReadCalendar.
+type ReadCalendarParams struct {
+	Component *ReadCalendarParamsComponent `form:"component,omitempty" json:"component,omitempty"`
+}
+
+// ReadCalendarParamsComponent defines parameters for ReadCalendar.
+type ReadCalendarParamsComponent string
+
+// ExportTasksParams defines parameters for ExportTasks.
+type ExportTasksParams struct {
+	Format *ExportTasksParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// ExportTasksParamsFormat defines parameters for ExportTasks.
+type ExportTasksParamsFormat string
+
+// ImportCalendarTasksMultipartBody defines parameters for ImportCalendarTasks.
+type ImportCalendarTasksMultipartBody struct {
+	File *openapi_types.File `json:"file,omitempty"`
+}
+
+// ImportCalendarTasksParams defines parameters for ImportCalendarTasks.
+type ImportCalendarTasksParams struct {
+	// Priority Priority of the entries without one
+	Priority *Priority `form:"priority,omitempty" json:"priority,omitempty"`
+}
+
+// UpsertTasksParams defines parameters for UpsertTasks.
+type UpsertTasksParams struct {
+	Format *UpsertTasksParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// DryRun Validates the tasks and reports the changes without making them
+	DryRun *bool `form:"dry_run,omitempty" json:"dry_run,omitempty"`
+}
+
+// UpsertTasksParamsFormat defines parameters for UpsertTasks.
+type UpsertTasksParamsFormat string
+
+// SaveNotificationPreferenceJSONBody defines parameters for SaveNotificationPreference.
+type SaveNotificationPreferenceJSONBody struct {
+	Digest     *bool     `json:"digest,omitempty"`
+	EventTypes *[]string `json:"event_types,omitempty"`
+}
+
 // SearchTaskJSONBody defines parameters for SearchTask.
 type SearchTaskJSONBody struct {
 	Description *string   `json:"description"`
@@ -89,11 +251,18 @@ type SearchTaskJSONBody struct {
 	Size        *int64    `json:"size,omitempty"`
 }
 
+// SuggestTaskParams defines parameters for SuggestTask.
+type SuggestTaskParams struct {
+	Q    string `form:"q" json:"q"`
+	Size *int64 `form:"size,omitempty" json:"size,omitempty"`
+}
+
 // CreateTaskJSONBody defines parameters for CreateTask.
 type CreateTaskJSONBody struct {
 	Dates       *Dates    `json:"dates,omitempty"`
 	Description *string   `json:"description,omitempty"`
 	Priority    *Priority `json:"priority,omitempty"`
+	Reminders   *[]string `json:"reminders,omitempty"`
 }
 
 // UpdateTaskJSONBody defines parameters for UpdateTask.
@@ -104,6 +273,24 @@ type UpdateTaskJSONBody struct {
 	Priority    *Priority `json:"priority,omitempty"`
 }
 
+// CreateWebhookJSONBody defines parameters for CreateWebhook.
+type CreateWebhookJSONBody struct {
+	EventTypes *[]string `json:"event_types,omitempty"`
+	Secret     *string   `json:"secret,omitempty"`
+	Url        *string   `json:"url,omitempty"`
+}
+
+// ListWebhookDeliveriesParams defines parameters for ListWebhookDeliveries.
+type ListWebhookDeliveriesParams struct {
+	Size *int64 `form:"size,omitempty" json:"size,omitempty"`
+}
+
+// ImportCalendarTasksMultipartRequestBody defines body for ImportCalendarTasks for multipart/form-data ContentType.
+type ImportCalendarTasksMultipartRequestBody ImportCalendarTasksMultipartBody
+
+// SaveNotificationPreferenceJSONRequestBody defines body for SaveNotificationPreference for application/json ContentType.
+type SaveNotificationPreferenceJSONRequestBody SaveNotificationPreferenceJSONBody
+
 // SearchTaskJSONRequestBody defines body for SearchTask for application/json ContentType.
 type SearchTaskJSONRequestBody SearchTaskJSONBody
 
@@ -112,3 +299,6 @@ type CreateTaskJSONRequestBody CreateTaskJSONBody
 
 // UpdateTaskJSONRequestBody defines body for UpdateTask for application/json ContentType.
 type UpdateTaskJSONRequestBody UpdateTaskJSONBody
+
+// CreateWebhookJSONRequestBody defines body for CreateWebhook for application/json ContentType.
+type CreateWebhookJSONRequestBody CreateWebhookJSONBody